@@ -0,0 +1,101 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// applymerge computes the result of an apply without contacting an
+// apiserver: given a live object, an apply configuration, and the current
+// managedFields, it prints the merged object, the new managedFields, and
+// any conflicts. CI pipelines and GitOps controllers use it to predict
+// merge outcomes before submitting.
+// Usage:
+// $ applymerge --live=live.json --apply-config=config.json --managed-fields=managed.json --field-manager=deploy-tool
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	flag "github.com/spf13/pflag"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/apiserver/fieldmanager"
+)
+
+var (
+	livePath     = flag.String("live", "", "Path to the live object in JSON; may be empty for an apply that creates.")
+	configPath   = flag.String("apply-config", "", "Path to the apply configuration in JSON.")
+	managedPath  = flag.String("managed-fields", "", "Path to the current managedFields entries in JSON; may be empty.")
+	fieldManager = flag.String("field-manager", "", "Name the apply would be submitted under.")
+	force        = flag.Bool("force", false, "Take ownership of conflicting fields instead of reporting conflicts.")
+)
+
+// output is the printed result, mirroring OfflineMergeResult in the wire
+// casing of the object fields.
+type output struct {
+	Merged        map[string]interface{}   `json:"merged"`
+	ManagedFields []api.ManagedFieldsEntry `json:"managedFields"`
+	Conflicts     []string                 `json:"conflicts,omitempty"`
+}
+
+func main() {
+	flag.Parse()
+	if len(*configPath) == 0 || len(*fieldManager) == 0 {
+		fmt.Fprintln(os.Stderr, "--apply-config and --field-manager are required")
+		os.Exit(1)
+	}
+
+	config, err := ioutil.ReadFile(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read the apply configuration: %v\n", err)
+		os.Exit(1)
+	}
+	var live []byte
+	if len(*livePath) > 0 {
+		if live, err = ioutil.ReadFile(*livePath); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to read the live object: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	var managed []api.ManagedFieldsEntry
+	if len(*managedPath) > 0 {
+		data, err := ioutil.ReadFile(*managedPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to read the managedFields: %v\n", err)
+			os.Exit(1)
+		}
+		if err := json.Unmarshal(data, &managed); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to parse the managedFields: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	result, err := fieldmanager.OfflineApply(live, config, managed, *fieldManager, *force)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "merge failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	printed, err := json.MarshalIndent(output{Merged: result.Merged, ManagedFields: result.Managed, Conflicts: result.Conflicts}, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to print the result: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(printed))
+	if len(result.Conflicts) > 0 {
+		os.Exit(2)
+	}
+}
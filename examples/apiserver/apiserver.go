@@ -28,6 +28,7 @@ import (
 	"k8s.io/kubernetes/pkg/apimachinery/registered"
 	"k8s.io/kubernetes/pkg/genericapiserver"
 	genericoptions "k8s.io/kubernetes/pkg/genericapiserver/options"
+	"k8s.io/kubernetes/pkg/registry/generic/registry"
 	"k8s.io/kubernetes/pkg/storage/storagebackend"
 
 	// Install the testgroup API
@@ -57,6 +58,11 @@ func NewServerRunOptions() *genericoptions.ServerRunOptions {
 }
 
 func Run(serverOptions *genericoptions.ServerRunOptions) error {
+	// Maintain managedFields on every write. The testtypes storage in
+	// examples/apiserver/rest configures its own manager chain; resources
+	// that don't get the shared default chain.
+	registry.EnableFieldManagement = true
+
 	// Set ServiceClusterIPRange
 	_, serviceClusterIPRange, _ := net.ParseCIDR("10.0.0.0/24")
 	serverOptions.ServiceClusterIPRange = *serviceClusterIPRange
@@ -19,12 +19,15 @@ package rest
 import (
 	"k8s.io/kubernetes/cmd/libs/go2idl/client-gen/test_apis/testgroup.k8s.io"
 	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/apiserver/fieldmanager"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/generic"
 	"k8s.io/kubernetes/pkg/registry/generic/registry"
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/storage"
+
+	"github.com/golang/glog"
 )
 
 type REST struct {
@@ -61,6 +64,18 @@ func NewREST(s storage.Interface, storageDecorator generic.StorageDecorator) *RE
 		PredicateFunc: func(label labels.Selector, field fields.Selector) generic.Matcher {
 			return generic.MatcherFunc(nil)
 		},
+		// A per-resource manager chain. Leaving this nil uses the shared
+		// default chain; building one explicitly is how a resource adds
+		// decorators such as the ownership guardrail. Only consulted when
+		// registry.EnableFieldManagement is set (see examples/apiserver).
+		FieldManager: fieldmanager.NewTimingManager(
+			fieldmanager.NewGuardrailManager(
+				fieldmanager.NewStampManager(runtime.APIVersionInternal),
+				fieldmanager.OwnedFieldsLimits{Warn: 100, Reject: 500},
+				func(message string) { glog.Warning(message) },
+			),
+			"TestType",
+		),
 		Storage: storageInterface,
 	}
 	return &REST{store}
@@ -55,6 +55,18 @@ const namespaceKey key = 0
 // userKey is the context key for the request user.
 const userKey key = 1
 
+// impersonatingUserKey is the context key for the real user behind an
+// impersonated request.
+const impersonatingUserKey key = 2
+
+// fieldManagerKey is the context key for the field manager name the request
+// supplied.
+const fieldManagerKey key = 3
+
+// fieldManagerTTLKey is the context key for the ownership TTL the request
+// supplied.
+const fieldManagerTTLKey key = 4
+
 // NewContext instantiates a base context object for request flows.
 func NewContext() Context {
 	return context.TODO()
@@ -114,6 +126,43 @@ func WithUser(parent Context, user user.Info) Context {
 	return WithValue(parent, userKey, user)
 }
 
+// WithImpersonatingUser returns a copy of parent in which the real user
+// behind an impersonated request is set
+func WithImpersonatingUser(parent Context, user user.Info) Context {
+	return WithValue(parent, impersonatingUserKey, user)
+}
+
+// ImpersonatingUserFrom returns the real user behind an impersonated
+// request, if the request is impersonated
+func ImpersonatingUserFrom(ctx Context) (user.Info, bool) {
+	user, ok := ctx.Value(impersonatingUserKey).(user.Info)
+	return user, ok
+}
+
+// WithFieldManager returns a copy of parent in which the field manager name
+// supplied on the request is set
+func WithFieldManager(parent Context, manager string) Context {
+	return WithValue(parent, fieldManagerKey, manager)
+}
+
+// FieldManagerFrom returns the field manager name supplied on the request
+func FieldManagerFrom(ctx Context) (string, bool) {
+	manager, ok := ctx.Value(fieldManagerKey).(string)
+	return manager, ok
+}
+
+// WithFieldManagerTTL returns a copy of parent in which the ownership TTL
+// supplied on the request is set
+func WithFieldManagerTTL(parent Context, ttl time.Duration) Context {
+	return WithValue(parent, fieldManagerTTLKey, ttl)
+}
+
+// FieldManagerTTLFrom returns the ownership TTL supplied on the request
+func FieldManagerTTLFrom(ctx Context) (time.Duration, bool) {
+	ttl, ok := ctx.Value(fieldManagerTTLKey).(time.Duration)
+	return ttl, ok
+}
+
 // UserFrom returns the value of the user key on the ctx
 func UserFrom(ctx Context) (user.Info, bool) {
 	user, ok := ctx.Value(userKey).(user.Info)
@@ -66,3 +66,19 @@ func TestValidNamespace(t *testing.T) {
 		t.Errorf("Expected the empty string")
 	}
 }
+
+// TestFieldManagerContext validates that a field manager name can be get/set on a context object
+func TestFieldManagerContext(t *testing.T) {
+	ctx := api.NewDefaultContext()
+	if _, ok := api.FieldManagerFrom(ctx); ok {
+		t.Errorf("Should not be ok because there is no field manager on the context")
+	}
+	ctx = api.WithFieldManager(ctx, "deploy-tool")
+	result, ok := api.FieldManagerFrom(ctx)
+	if !ok {
+		t.Errorf("Error getting field manager")
+	}
+	if result != "deploy-tool" {
+		t.Errorf("Expected deploy-tool, got %v", result)
+	}
+}
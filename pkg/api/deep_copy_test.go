@@ -190,3 +190,35 @@ func BenchmarkReplicationControllerCopy(b *testing.B) {
 		b.Fatalf("Incorrect copy: expected %v, got %v", replicationController, *result)
 	}
 }
+
+func TestDeepCopySharesFieldsV1(t *testing.T) {
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			Name: "pod",
+			ManagedFields: []api.ManagedFieldsEntry{{
+				Manager:    "test",
+				Operation:  api.ManagedFieldsOperationApply,
+				APIVersion: "v1",
+				FieldsType: "FieldsV1",
+				FieldsV1:   &api.FieldsV1{Raw: []byte(`{"f:metadata":{}}`)},
+			}},
+		},
+	}
+	obj, err := api.Scheme.DeepCopy(pod)
+	if err != nil {
+		t.Fatalf("unexpected error copying pod: %v", err)
+	}
+	result := obj.(*api.Pod)
+	if !api.Semantic.DeepEqual(pod, result) {
+		t.Fatalf("incorrect copy: expected %v, got %v", pod, result)
+	}
+	// the raw field set bytes are immutable, so the copy shares them
+	original := pod.ManagedFields[0].FieldsV1
+	copied := result.ManagedFields[0].FieldsV1
+	if copied == original {
+		t.Fatalf("expected a new FieldsV1 struct, got the original pointer")
+	}
+	if &copied.Raw[0] != &original.Raw[0] {
+		t.Errorf("expected the copy to share the raw bytes, got a duplicate")
+	}
+}
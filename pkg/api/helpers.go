@@ -71,6 +71,22 @@ var Semantic = conversion.EqualitiesOrDie(
 	},
 )
 
+// SemanticIgnoringManagedFields is Semantic with any two managedFields
+// slices considered equal, so that controllers comparing desired and live
+// objects can cheaply ignore server-managed ownership metadata.
+// Example: api.SemanticIgnoringManagedFields.DeepEqual(desiredPod, livePod)
+var SemanticIgnoringManagedFields = func() conversion.Equalities {
+	equalities := conversion.EqualitiesOrDie(
+		func(a, b []ManagedFieldsEntry) bool {
+			return true
+		},
+	)
+	for t, fn := range Semantic.Equalities {
+		equalities.Equalities[t] = fn
+	}
+	return equalities
+}()
+
 var standardResourceQuotaScopes = sets.NewString(
 	string(ResourceQuotaScopeTerminating),
 	string(ResourceQuotaScopeNotTerminating),
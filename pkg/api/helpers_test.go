@@ -68,6 +68,25 @@ func TestSemantic(t *testing.T) {
 	}
 }
 
+func TestSemanticIgnoringManagedFields(t *testing.T) {
+	a := ObjectMeta{Name: "foo", ManagedFields: []ManagedFieldsEntry{{Manager: "a"}}}
+	b := ObjectMeta{Name: "foo", ManagedFields: []ManagedFieldsEntry{{Manager: "b"}}}
+	if Semantic.DeepEqual(a, b) {
+		t.Errorf("expected Semantic to see differing managedFields")
+	}
+	if !SemanticIgnoringManagedFields.DeepEqual(a, b) {
+		t.Errorf("expected SemanticIgnoringManagedFields to ignore managedFields")
+	}
+	b.Name = "bar"
+	if SemanticIgnoringManagedFields.DeepEqual(a, b) {
+		t.Errorf("expected other fields to still be compared")
+	}
+	// the base equalities are inherited
+	if !SemanticIgnoringManagedFields.DeepEqual(resource.MustParse("0"), resource.Quantity{}) {
+		t.Errorf("expected quantity semantics to be inherited")
+	}
+}
+
 func TestIsStandardResource(t *testing.T) {
 	testCases := []struct {
 		input  string
@@ -0,0 +1,70 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+// Components that adjust managedFields directly — migration tools, tests,
+// controllers repairing attribution — keep reimplementing the same slice
+// surgery, with subtle bugs around duplicate identities and entry order.
+// These helpers are the one shared implementation: entries are identified
+// by manager and operation, and the slice keeps its insertion order.
+
+// AddManagedFieldsEntry adds the entry to the object's managedFields. An
+// existing entry with the same manager and operation is replaced in place;
+// otherwise the entry is appended.
+func AddManagedFieldsEntry(meta *ObjectMeta, entry ManagedFieldsEntry) {
+	if existing := FindManagedFieldsEntry(meta, entry.Manager, entry.Operation); existing != nil {
+		*existing = entry
+		return
+	}
+	meta.ManagedFields = append(meta.ManagedFields, entry)
+}
+
+// RemoveEntriesForManager removes every entry recorded for the manager,
+// regardless of operation, and returns how many were removed. The
+// remaining entries keep their order.
+func RemoveEntriesForManager(meta *ObjectMeta, manager string) int {
+	kept := meta.ManagedFields[:0]
+	removed := 0
+	for i := range meta.ManagedFields {
+		if meta.ManagedFields[i].Manager == manager {
+			removed++
+			continue
+		}
+		kept = append(kept, meta.ManagedFields[i])
+	}
+	if removed == 0 {
+		return 0
+	}
+	if len(kept) == 0 {
+		meta.ManagedFields = nil
+		return removed
+	}
+	meta.ManagedFields = kept
+	return removed
+}
+
+// FindManagedFieldsEntry returns a pointer into the object's managedFields
+// for the entry with the given manager and operation, or nil when none
+// exists. The pointer stays valid until the slice is next modified.
+func FindManagedFieldsEntry(meta *ObjectMeta, manager string, operation ManagedFieldsOperationType) *ManagedFieldsEntry {
+	for i := range meta.ManagedFields {
+		if meta.ManagedFields[i].Manager == manager && meta.ManagedFields[i].Operation == operation {
+			return &meta.ManagedFields[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+)
+
+func TestAddManagedFieldsEntry(t *testing.T) {
+	meta := &ObjectMeta{}
+	AddManagedFieldsEntry(meta, ManagedFieldsEntry{Manager: "a", Operation: ManagedFieldsOperationUpdate})
+	AddManagedFieldsEntry(meta, ManagedFieldsEntry{Manager: "b", Operation: ManagedFieldsOperationApply})
+	if len(meta.ManagedFields) != 2 {
+		t.Fatalf("expected two entries, got %v", meta.ManagedFields)
+	}
+
+	// the same identity replaces in place, keeping the order
+	AddManagedFieldsEntry(meta, ManagedFieldsEntry{Manager: "a", Operation: ManagedFieldsOperationUpdate, APIVersion: "v1"})
+	if len(meta.ManagedFields) != 2 {
+		t.Fatalf("expected the entry to be replaced, not appended, got %v", meta.ManagedFields)
+	}
+	if meta.ManagedFields[0].Manager != "a" || meta.ManagedFields[0].APIVersion != "v1" {
+		t.Errorf("expected the first entry to be replaced in place, got %v", meta.ManagedFields)
+	}
+
+	// the same manager under a different operation is a distinct identity
+	AddManagedFieldsEntry(meta, ManagedFieldsEntry{Manager: "a", Operation: ManagedFieldsOperationApply})
+	if len(meta.ManagedFields) != 3 {
+		t.Errorf("expected a different operation to append, got %v", meta.ManagedFields)
+	}
+}
+
+func TestRemoveEntriesForManager(t *testing.T) {
+	meta := &ObjectMeta{ManagedFields: []ManagedFieldsEntry{
+		{Manager: "a", Operation: ManagedFieldsOperationUpdate},
+		{Manager: "b", Operation: ManagedFieldsOperationApply},
+		{Manager: "a", Operation: ManagedFieldsOperationApply},
+		{Manager: "c", Operation: ManagedFieldsOperationUpdate},
+	}}
+
+	if removed := RemoveEntriesForManager(meta, "a"); removed != 2 {
+		t.Errorf("expected two entries removed, got %d", removed)
+	}
+	if len(meta.ManagedFields) != 2 || meta.ManagedFields[0].Manager != "b" || meta.ManagedFields[1].Manager != "c" {
+		t.Errorf("expected the remaining entries to keep their order, got %v", meta.ManagedFields)
+	}
+
+	if removed := RemoveEntriesForManager(meta, "missing"); removed != 0 {
+		t.Errorf("expected no entries removed for an unknown manager, got %d", removed)
+	}
+
+	RemoveEntriesForManager(meta, "b")
+	RemoveEntriesForManager(meta, "c")
+	if meta.ManagedFields != nil {
+		t.Errorf("expected an emptied slice to become nil, got %v", meta.ManagedFields)
+	}
+}
+
+func TestFindManagedFieldsEntry(t *testing.T) {
+	meta := &ObjectMeta{ManagedFields: []ManagedFieldsEntry{
+		{Manager: "a", Operation: ManagedFieldsOperationUpdate},
+		{Manager: "a", Operation: ManagedFieldsOperationApply},
+	}}
+
+	entry := FindManagedFieldsEntry(meta, "a", ManagedFieldsOperationApply)
+	if entry == nil || entry.Operation != ManagedFieldsOperationApply {
+		t.Fatalf("expected the apply entry, got %v", entry)
+	}
+	// the pointer writes through to the slice
+	entry.APIVersion = "v1"
+	if meta.ManagedFields[1].APIVersion != "v1" {
+		t.Errorf("expected the returned pointer to alias the slice, got %v", meta.ManagedFields)
+	}
+
+	if entry := FindManagedFieldsEntry(meta, "b", ManagedFieldsOperationUpdate); entry != nil {
+		t.Errorf("expected nil for a missing identity, got %v", entry)
+	}
+}
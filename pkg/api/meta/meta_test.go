@@ -418,3 +418,52 @@ func BenchmarkAccessorSetFastPath(b *testing.B) {
 	}
 	b.StopTimer()
 }
+
+func TestStripManagedFields(t *testing.T) {
+	pod := &api.Pod{ObjectMeta: api.ObjectMeta{
+		Name:          "foo",
+		ManagedFields: []api.ManagedFieldsEntry{{Manager: "kubelet"}},
+	}}
+	if err := meta.StripManagedFields(pod); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pod.ManagedFields != nil {
+		t.Errorf("expected managedFields to be cleared, got %v", pod.ManagedFields)
+	}
+	if pod.Name != "foo" {
+		t.Errorf("expected the rest of the metadata to survive, got %v", pod.ObjectMeta)
+	}
+
+	versioned := &v1.Pod{ObjectMeta: v1.ObjectMeta{
+		Name:          "foo",
+		ManagedFields: []v1.ManagedFieldsEntry{{Manager: "kubelet"}},
+	}}
+	if err := meta.StripManagedFields(versioned); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if versioned.ManagedFields != nil {
+		t.Errorf("expected versioned managedFields to be cleared, got %v", versioned.ManagedFields)
+	}
+
+	unstructuredPod := &runtime.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":          "foo",
+			"managedFields": []interface{}{map[string]interface{}{"manager": "kubelet"}},
+		},
+	}}
+	if err := meta.StripManagedFields(unstructuredPod); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	metadata := unstructuredPod.Object["metadata"].(map[string]interface{})
+	if _, present := metadata["managedFields"]; present {
+		t.Errorf("expected unstructured managedFields to be removed, got %v", metadata)
+	}
+	if metadata["name"] != "foo" {
+		t.Errorf("expected the rest of the metadata to survive, got %v", metadata)
+	}
+
+	// objects without the field are left untouched
+	if err := meta.StripManagedFields(&api.PodList{}); err != nil {
+		t.Errorf("unexpected error for an object without managedFields: %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"reflect"
+
+	"k8s.io/kubernetes/pkg/conversion"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// Controllers comparing a desired object against the live copy read from
+// the server rarely care about metadata.managedFields: the server rewrites
+// it on every update, so it differs even when nothing of interest changed.
+// StripManagedFields clears the field on any object shape so callers do
+// not each hand-roll the scrubbing.
+
+// StripManagedFields clears metadata.managedFields on the object, if the
+// object carries any. Objects without the field are left untouched.
+func StripManagedFields(obj runtime.Object) error {
+	if unstructured, ok := obj.(*runtime.Unstructured); ok {
+		if metadata, ok := unstructured.Object["metadata"].(map[string]interface{}); ok {
+			delete(metadata, "managedFields")
+		}
+		return nil
+	}
+	v, err := conversion.EnforcePtr(obj)
+	if err != nil {
+		return err
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	objectMeta := v.FieldByName("ObjectMeta")
+	if !objectMeta.IsValid() || objectMeta.Kind() != reflect.Struct {
+		return nil
+	}
+	managedFields := objectMeta.FieldByName("ManagedFields")
+	if !managedFields.IsValid() || managedFields.Kind() != reflect.Slice || !managedFields.CanSet() {
+		return nil
+	}
+	managedFields.Set(reflect.Zero(managedFields.Type()))
+	return nil
+}
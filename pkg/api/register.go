@@ -106,6 +106,7 @@ func AddToScheme(scheme *runtime.Scheme) {
 	// Register Unversioned types under their own special group
 	Scheme.AddUnversionedTypes(Unversioned,
 		&unversioned.ExportOptions{},
+		&unversioned.ApplyOptions{},
 		&unversioned.Status{},
 		&unversioned.APIVersions{},
 		&unversioned.APIGroupList{},
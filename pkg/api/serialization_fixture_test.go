@@ -0,0 +1,157 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api_test
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/testapi"
+	apitesting "k8s.io/kubernetes/pkg/api/testing"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/runtime/serializer/protobuf"
+)
+
+// A change to generated.pb.go that renumbers or retypes a field decodes old
+// stored bytes into the wrong field, and nothing in the ordinary round-trip
+// tests notices: both sides of the round trip use the new code. The wire
+// fixtures pin the serialized form: every external API type has golden
+// protobuf bytes per release committed under testdata/wire/<release>/, the
+// current code must decode the fixtures of every committed release, and the
+// current release's fixtures must match what the current code emits.
+// Fixtures are regenerated with -update-wire-fixtures; a new release copies
+// the procedure with a bumped currentWireRelease. The prior release's CI
+// decoding the newer fixtures covers the reverse direction.
+
+var updateWireFixtures = flag.Bool("update-wire-fixtures", false, "If true, rewrite the current release's wire fixtures instead of comparing against them.")
+
+// currentWireRelease names the fixture directory for this release; bump it
+// when cutting a release so the previous fixtures stay behind as history.
+const currentWireRelease = "v1.4"
+
+// wireFixtureSeed keeps the fuzzed fixture content stable, so regenerated
+// fixtures change only when the encoding does.
+const wireFixtureSeed = 500
+
+const wireFixtureDir = "testdata/wire"
+
+// TestWireFixturesDecode decodes every committed fixture, of every release,
+// with the current code. A decode error means the current generated code no
+// longer understands bytes a previous release wrote.
+func TestWireFixturesDecode(t *testing.T) {
+	releases, err := ioutil.ReadDir(wireFixtureDir)
+	if os.IsNotExist(err) {
+		t.Skipf("no wire fixtures committed yet: generate them with -update-wire-fixtures")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := api.Codecs.UniversalDeserializer()
+	for _, release := range releases {
+		fixtures, err := ioutil.ReadDir(filepath.Join(wireFixtureDir, release.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, fixture := range fixtures {
+			path := filepath.Join(wireFixtureDir, release.Name(), fixture.Name())
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, _, err := d.Decode(data, nil, nil); err != nil {
+				t.Errorf("%s: current code cannot decode the %s fixture: %v", path, release.Name(), err)
+			}
+		}
+	}
+}
+
+// TestWireFixturesCurrent encodes a deterministic instance of every external
+// type and compares it to the current release's committed fixture, so an
+// unintended change to the serialized form fails before it ships.
+func TestWireFixturesCurrent(t *testing.T) {
+	// map keys must encode in sorted order or the fixture bytes change
+	// from run to run
+	e := protobuf.NewDeterministicSerializer(api.Scheme, api.Scheme, "application/vnd.kubernetes.protobuf")
+	dir := filepath.Join(wireFixtureDir, currentWireRelease)
+	if *updateWireFixtures {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	} else if _, err := os.Stat(dir); os.IsNotExist(err) {
+		// only an entirely absent directory means the release is being
+		// bootstrapped; a missing fixture inside an existing directory is a
+		// failure below
+		t.Skipf("no %s wire fixtures committed yet: generate them with -update-wire-fixtures", currentWireRelease)
+	}
+	for _, gv := range testapi.ExternalGroupVersions() {
+		fuzzer := apitesting.FuzzerFor(t, gv, rand.NewSource(wireFixtureSeed))
+		types := api.Scheme.KnownTypes(gv)
+		// fuzz in sorted order so the fixture content is deterministic
+		kinds := make([]string, 0, len(types))
+		for kind := range types {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		for _, kind := range kinds {
+			// WatchEvent frames a nested object on the stream and is never
+			// stored; its encoded form is not self-describing, so there is
+			// no wire form to pin
+			if strings.HasSuffix(kind, "List") || strings.HasSuffix(kind, "Options") || kind == "WatchEvent" {
+				continue
+			}
+			obj := reflect.New(types[kind]).Interface().(runtime.Object)
+			fuzzer.Fuzz(obj)
+			obj.GetObjectKind().SetGroupVersionKind(gv.WithKind(kind))
+
+			buf := &bytes.Buffer{}
+			if err := e.Encode(obj, buf); err != nil {
+				// types without generated protobuf support have no wire
+				// form to pin
+				continue
+			}
+			name := fmt.Sprintf("%s.%s", strings.Replace(gv.String(), "/", "_", -1), kind)
+			path := filepath.Join(dir, name)
+			if *updateWireFixtures {
+				if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+					t.Fatal(err)
+				}
+				continue
+			}
+			expected, err := ioutil.ReadFile(path)
+			if os.IsNotExist(err) {
+				t.Errorf("%s: no fixture for %s %s: generate one with -update-wire-fixtures", path, gv, kind)
+				continue
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(expected, buf.Bytes()) {
+				t.Errorf("%s: the serialized form of %s %s changed; if the change is intended, regenerate with -update-wire-fixtures", path, gv, kind)
+			}
+		}
+	}
+}
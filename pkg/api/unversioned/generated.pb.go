@@ -19,36 +19,38 @@ limitations under the License.
 // DO NOT EDIT!
 
 /*
-	Package unversioned is a generated protocol buffer package.
-
-	It is generated from these files:
-		k8s.io/kubernetes/pkg/api/unversioned/generated.proto
-
-	It has these top-level messages:
-		APIGroup
-		APIGroupList
-		APIResource
-		APIResourceList
-		APIVersions
-		Duration
-		ExportOptions
-		GroupKind
-		GroupResource
-		GroupVersion
-		GroupVersionForDiscovery
-		GroupVersionKind
-		GroupVersionResource
-		LabelSelector
-		LabelSelectorRequirement
-		ListMeta
-		RootPaths
-		ServerAddressByClientCIDR
-		Status
-		StatusCause
-		StatusDetails
-		Time
-		Timestamp
-		TypeMeta
+Package unversioned is a generated protocol buffer package.
+
+It is generated from these files:
+
+	k8s.io/kubernetes/pkg/api/unversioned/generated.proto
+
+It has these top-level messages:
+
+	APIGroup
+	APIGroupList
+	APIResource
+	APIResourceList
+	APIVersions
+	Duration
+	ExportOptions
+	GroupKind
+	GroupResource
+	GroupVersion
+	GroupVersionForDiscovery
+	GroupVersionKind
+	GroupVersionResource
+	LabelSelector
+	LabelSelectorRequirement
+	ListMeta
+	RootPaths
+	ServerAddressByClientCIDR
+	Status
+	StatusCause
+	StatusDetails
+	Time
+	Timestamp
+	TypeMeta
 */
 package unversioned
 
@@ -18,6 +18,8 @@ package unversioned
 
 import (
 	"fmt"
+	"net/url"
+	"time"
 
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/util/sets"
@@ -152,3 +154,53 @@ func ExtractGroupVersions(l *APIGroupList) []string {
 	}
 	return groupVersions
 }
+
+// NewApplyOptions returns apply options for the given manager, forcing
+// conflicts when force is true.
+func NewApplyOptions(fieldManager string, force bool) *ApplyOptions {
+	return &ApplyOptions{FieldManager: fieldManager, Force: force}
+}
+
+// ApplyOptionsFromQuery reads apply options from request query parameters.
+// Absent parameters leave their fields at the zero value; validation decides
+// whether the result is acceptable. A fieldManagerTTL that does not parse
+// as a duration is an error.
+func ApplyOptionsFromQuery(query url.Values) (*ApplyOptions, error) {
+	options := &ApplyOptions{
+		FieldManager:    query.Get("fieldManager"),
+		Force:           query.Get("force") == "true",
+		DryRun:          query.Get("dryRun") == "true",
+		ResourceVersion: query.Get("resourceVersion"),
+	}
+	if value := query.Get("fieldManagerTTL"); len(value) > 0 {
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("fieldManagerTTL %q is not a valid duration: %v", value, err)
+		}
+		options.TTL = Duration{Duration: ttl}
+	}
+	return options, nil
+}
+
+// AsQuery returns the query parameters that transport the options, the
+// inverse of ApplyOptionsFromQuery. Unset options are omitted so requests
+// stay compatible with servers predating an option.
+func (o *ApplyOptions) AsQuery() url.Values {
+	query := url.Values{}
+	if len(o.FieldManager) > 0 {
+		query.Set("fieldManager", o.FieldManager)
+	}
+	if o.Force {
+		query.Set("force", "true")
+	}
+	if o.DryRun {
+		query.Set("dryRun", "true")
+	}
+	if len(o.ResourceVersion) > 0 {
+		query.Set("resourceVersion", o.ResourceVersion)
+	}
+	if o.TTL.Duration > 0 {
+		query.Set("fieldManagerTTL", o.TTL.Duration.String())
+	}
+	return query
+}
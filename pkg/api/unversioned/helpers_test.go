@@ -17,8 +17,10 @@ limitations under the License.
 package unversioned
 
 import (
+	"net/url"
 	"reflect"
 	"testing"
+	"time"
 
 	"k8s.io/kubernetes/pkg/labels"
 )
@@ -81,3 +83,34 @@ func TestLabelSelectorAsSelector(t *testing.T) {
 		}
 	}
 }
+
+func TestApplyOptionsQueryRoundTrip(t *testing.T) {
+	tc := []struct {
+		options  ApplyOptions
+		expected string
+	}{
+		{ApplyOptions{FieldManager: "deploy-tool"}, "fieldManager=deploy-tool"},
+		{ApplyOptions{FieldManager: "deploy-tool", Force: true}, "fieldManager=deploy-tool&force=true"},
+		{ApplyOptions{FieldManager: "deploy-tool", DryRun: true}, "dryRun=true&fieldManager=deploy-tool"},
+		{ApplyOptions{FieldManager: "deploy-tool", ResourceVersion: "5"}, "fieldManager=deploy-tool&resourceVersion=5"},
+		{ApplyOptions{FieldManager: "deploy-tool", TTL: Duration{time.Hour}}, "fieldManager=deploy-tool&fieldManagerTTL=1h0m0s"},
+		{ApplyOptions{}, ""},
+	}
+	for i, tt := range tc {
+		query := tt.options.AsQuery()
+		if encoded := query.Encode(); encoded != tt.expected {
+			t.Errorf("[%v]expected query %q, got %q", i, tt.expected, encoded)
+		}
+		back, err := ApplyOptionsFromQuery(query)
+		if err != nil {
+			t.Errorf("[%v]unexpected error: %v", i, err)
+			continue
+		}
+		if *back != tt.options {
+			t.Errorf("[%v]expected options to round-trip, got %+v", i, back)
+		}
+	}
+	if _, err := ApplyOptionsFromQuery(url.Values{"fieldManagerTTL": []string{"soon"}}); err == nil {
+		t.Errorf("expected an error for an unparseable TTL")
+	}
+}
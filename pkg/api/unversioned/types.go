@@ -17,10 +17,11 @@ limitations under the License.
 // Package unversioned contains API types that are common to all versions.
 //
 // The package contains two categories of types:
-// - external (serialized) types that lack their own version (e.g TypeMeta)
-// - internal (never-serialized) types that are needed by several different
-//   api groups, and so live here, to avoid duplication and/or import loops
-//   (e.g. LabelSelector).
+//   - external (serialized) types that lack their own version (e.g TypeMeta)
+//   - internal (never-serialized) types that are needed by several different
+//     api groups, and so live here, to avoid duplication and/or import loops
+//     (e.g. LabelSelector).
+//
 // In the future, we will probably move these categories of objects into
 // separate packages.
 package unversioned
@@ -71,6 +72,34 @@ type ExportOptions struct {
 	Exact bool `json:"exact" protobuf:"varint,2,opt,name=exact"`
 }
 
+// ApplyOptions carries the options of an apply request: which field manager
+// is acting, whether conflicts are forced, and whether the result should be
+// persisted. One shared definition keeps clients and servers agreeing on the
+// option names and defaults instead of each hand-building query parameters.
+type ApplyOptions struct {
+	TypeMeta `json:",inline"`
+	// FieldManager is the name of the manager performing the apply.
+	// Required.
+	FieldManager string `json:"fieldManager" protobuf:"bytes,1,opt,name=fieldManager"`
+	// Force acknowledges conflicts with other field managers, taking
+	// ownership of the disputed fields.
+	Force bool `json:"force,omitempty" protobuf:"varint,2,opt,name=force"`
+	// DryRun asks the server to run the apply without persisting the
+	// result. Servers that cannot honor dry-run reject requests that set
+	// it.
+	DryRun bool `json:"dryRun,omitempty" protobuf:"varint,3,opt,name=dryRun"`
+	// ResourceVersion, when set, makes the apply conditional: the request
+	// fails with a conflict if the live object is not at this resource
+	// version, layering optimistic concurrency on top of the field-level
+	// semantics.
+	ResourceVersion string `json:"resourceVersion,omitempty" protobuf:"bytes,4,opt,name=resourceVersion"`
+	// TTL, when set, declares the ownership recorded by this request
+	// ephemeral: once the TTL has passed, the manager's entries are cleaned
+	// up lazily on subsequent writes. Zero means the ownership is
+	// permanent.
+	TTL Duration `json:"fieldManagerTTL,omitempty" protobuf:"bytes,5,opt,name=fieldManagerTTL"`
+}
+
 // Status is a return value for calls that don't return other objects.
 type Status struct {
 	TypeMeta `json:",inline"`
@@ -429,6 +458,7 @@ type LabelSelector struct {
 	// matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels
 	// map is equivalent to an element of matchExpressions, whose key field is "key", the
 	// operator is "In", and the values array contains only "value". The requirements are ANDed.
+	// +mapType=atomic
 	MatchLabels map[string]string `json:"matchLabels,omitempty" protobuf:"bytes,1,rep,name=matchLabels"`
 	// matchExpressions is a list of label selector requirements. The requirements are ANDed.
 	MatchExpressions []LabelSelectorRequirement `json:"matchExpressions,omitempty" protobuf:"bytes,2,rep,name=matchExpressions"`
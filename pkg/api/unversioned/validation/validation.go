@@ -61,6 +61,20 @@ func ValidateLabelName(labelName string, fldPath *field.Path) field.ErrorList {
 	return allErrs
 }
 
+// ValidateApplyOptions validates the options of an apply request. Servers
+// that cannot honor dry-run pass allowDryRun false and reject requests that
+// ask for it rather than silently persisting.
+func ValidateApplyOptions(options *unversioned.ApplyOptions, allowDryRun bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(options.FieldManager) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("fieldManager"), "fieldManager is required for apply requests"))
+	}
+	if options.DryRun && !allowDryRun {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("dryRun"), "dry-run is not supported for apply requests"))
+	}
+	return allErrs
+}
+
 // ValidateLabels validates that a set of labels are correctly defined.
 func ValidateLabels(labels map[string]string, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
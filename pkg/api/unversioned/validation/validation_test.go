@@ -20,6 +20,7 @@ import (
 	"strings"
 	"testing"
 
+	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/util/validation/field"
 )
 
@@ -87,3 +88,38 @@ func TestValidateLabels(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateApplyOptions(t *testing.T) {
+	successCases := []struct {
+		options     unversioned.ApplyOptions
+		allowDryRun bool
+	}{
+		{unversioned.ApplyOptions{FieldManager: "deploy-tool"}, false},
+		{unversioned.ApplyOptions{FieldManager: "deploy-tool", Force: true}, false},
+		{unversioned.ApplyOptions{FieldManager: "deploy-tool", DryRun: true}, true},
+	}
+	for i := range successCases {
+		if errs := ValidateApplyOptions(&successCases[i].options, successCases[i].allowDryRun, field.NewPath("options")); len(errs) != 0 {
+			t.Errorf("case[%d]: unexpected errors: %v", i, errs)
+		}
+	}
+
+	errorCases := []struct {
+		options     unversioned.ApplyOptions
+		allowDryRun bool
+		expect      string
+	}{
+		{unversioned.ApplyOptions{}, false, "fieldManager is required"},
+		{unversioned.ApplyOptions{FieldManager: "deploy-tool", DryRun: true}, false, "dry-run is not supported"},
+	}
+	for i := range errorCases {
+		errs := ValidateApplyOptions(&errorCases[i].options, errorCases[i].allowDryRun, field.NewPath("options"))
+		if len(errs) != 1 {
+			t.Errorf("case[%d]: expected one failure, got %v", i, errs)
+			continue
+		}
+		if !strings.Contains(errs[0].Detail, errorCases[i].expect) {
+			t.Errorf("case[%d]: error details do not include %q: %q", i, errorCases[i].expect, errs[0].Detail)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"bytes"
+	"errors"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/conversion"
+)
+
+func init() {
+	// As with the internal type, the raw bytes are immutable once stored, so
+	// deep copies share them instead of duplicating them.
+	if err := api.Scheme.AddDeepCopyFuncs(
+		func(in *FieldsV1, out *FieldsV1, c *conversion.Cloner) error {
+			out.Raw = in.Raw
+			return nil
+		},
+	); err != nil {
+		panic(err)
+	}
+}
+
+// MarshalJSON implements json.Marshaler: the raw bytes are the serialization.
+func (f FieldsV1) MarshalJSON() ([]byte, error) {
+	if f.Raw == nil {
+		return []byte("null"), nil
+	}
+	return f.Raw, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (f *FieldsV1) UnmarshalJSON(b []byte) error {
+	if f == nil {
+		return errors.New("v1.FieldsV1: UnmarshalJSON on nil pointer")
+	}
+	if !bytes.Equal(b, []byte("null")) {
+		// allocate rather than reuse: the previous bytes may be shared with
+		// deep copies of the object
+		f.Raw = append([]byte(nil), b...)
+	}
+	return nil
+}
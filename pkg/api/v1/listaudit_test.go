@@ -0,0 +1,34 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api/v1"
+	"k8s.io/kubernetes/pkg/apiserver/fieldmanager"
+)
+
+// TestListRulesMatchPatchTags checks that the strategic-merge patch tags on
+// the versioned types agree with the merge schema registered for apply, so
+// kubectl's strategic merge and server-side apply produce the same result
+// for the same request.
+func TestListRulesMatchPatchTags(t *testing.T) {
+	for _, err := range fieldmanager.AuditListRules(&v1.ObjectMeta{}, "metadata") {
+		t.Errorf("%v", err)
+	}
+}
@@ -174,6 +174,30 @@ func ValidateOwnerReferences(ownerReferences []api.OwnerReference, fldPath *fiel
 	return allErrs
 }
 
+// ValidateManagedFields validates that managedFields entries are well
+// formed, so malformed entries are rejected at the API boundary instead of
+// surfacing as odd behavior deep in the field management machinery.
+func ValidateManagedFields(entries []api.ManagedFieldsEntry, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, entry := range entries {
+		idxPath := fldPath.Index(i)
+		switch entry.Operation {
+		case api.ManagedFieldsOperationApply, api.ManagedFieldsOperationUpdate:
+		default:
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("operation"), entry.Operation, "must be `Apply` or `Update`"))
+		}
+		if entry.FieldsV1 != nil && entry.FieldsType != "FieldsV1" {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("fieldsType"), entry.FieldsType, "must be `FieldsV1` when fieldsV1 is set"))
+		}
+		if len(entry.APIVersion) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("apiVersion"), ""))
+		} else if _, err := unversioned.ParseGroupVersion(entry.APIVersion); err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("apiVersion"), entry.APIVersion, "must be a valid group/version"))
+		}
+	}
+	return allErrs
+}
+
 // ValidateNameFunc validates that the provided name is valid for a given resource type.
 // Not all resources have the same validation rules for names. Prefix is true
 // if the name will have a value appended to it.  If the name is not valid,
@@ -331,6 +355,7 @@ func ValidateObjectMeta(meta *api.ObjectMeta, requiresNamespace bool, nameFn Val
 	allErrs = append(allErrs, unversionedvalidation.ValidateLabels(meta.Labels, fldPath.Child("labels"))...)
 	allErrs = append(allErrs, ValidateAnnotations(meta.Annotations, fldPath.Child("annotations"))...)
 	allErrs = append(allErrs, ValidateOwnerReferences(meta.OwnerReferences, fldPath.Child("ownerReferences"))...)
+	allErrs = append(allErrs, ValidateManagedFields(meta.ManagedFields, fldPath.Child("managedFields"))...)
 	for _, finalizer := range meta.Finalizers {
 		allErrs = append(allErrs, validateFinalizerName(finalizer, fldPath.Child("finalizers"))...)
 	}
@@ -392,6 +417,7 @@ func ValidateObjectMetaUpdate(newMeta, oldMeta *api.ObjectMeta, fldPath *field.P
 	allErrs = append(allErrs, unversionedvalidation.ValidateLabels(newMeta.Labels, fldPath.Child("labels"))...)
 	allErrs = append(allErrs, ValidateAnnotations(newMeta.Annotations, fldPath.Child("annotations"))...)
 	allErrs = append(allErrs, ValidateOwnerReferences(newMeta.OwnerReferences, fldPath.Child("ownerReferences"))...)
+	allErrs = append(allErrs, ValidateManagedFields(newMeta.ManagedFields, fldPath.Child("managedFields"))...)
 
 	return allErrs
 }
@@ -1586,8 +1612,8 @@ func validateTaintEffect(effect *api.TaintEffect, allowEmpty bool, fldPath *fiel
 	return allErrors
 }
 
-// validateTolerations tests if given tolerations have valid data.
-func validateTolerations(tolerations []api.Toleration, fldPath *field.Path) field.ErrorList {
+// ValidateTolerations tests if given tolerations have valid data.
+func ValidateTolerations(tolerations []api.Toleration, fldPath *field.Path) field.ErrorList {
 	allErrors := field.ErrorList{}
 	for i, toleration := range tolerations {
 		idxPath := fldPath.Index(i)
@@ -1901,7 +1927,7 @@ func ValidateTolerationsInPodAnnotations(annotations map[string]string, fldPath
 		return allErrs
 	}
 	if len(tolerations) > 0 {
-		allErrs = append(allErrs, validateTolerations(tolerations, fldPath.Child(api.TolerationsAnnotationKey))...)
+		allErrs = append(allErrs, ValidateTolerations(tolerations, fldPath.Child(api.TolerationsAnnotationKey))...)
 	}
 
 	return allErrs
@@ -385,6 +385,46 @@ func TestValidateObjectMetaTrimsTrailingSlash(t *testing.T) {
 	}
 }
 
+func TestValidateManagedFields(t *testing.T) {
+	fields := &api.FieldsV1{Raw: []byte(`{"f:metadata":{}}`)}
+	successCases := [][]api.ManagedFieldsEntry{
+		nil,
+		{},
+		{{Manager: "kubelet", Operation: api.ManagedFieldsOperationUpdate, APIVersion: "v1"}},
+		{{Manager: "deploy-tool", Operation: api.ManagedFieldsOperationApply, APIVersion: "extensions/v1beta1", FieldsType: "FieldsV1", FieldsV1: fields}},
+	}
+	for i := range successCases {
+		errs := ValidateManagedFields(successCases[i], field.NewPath("field"))
+		if len(errs) != 0 {
+			t.Errorf("case[%d] expected success, got %#v", i, errs)
+		}
+	}
+
+	errorCases := []struct {
+		entries []api.ManagedFieldsEntry
+		expect  string
+	}{
+		{[]api.ManagedFieldsEntry{{Manager: "a", Operation: "Create", APIVersion: "v1"}}, "must be `Apply` or `Update`"},
+		{[]api.ManagedFieldsEntry{{Manager: "a", Operation: "", APIVersion: "v1"}}, "must be `Apply` or `Update`"},
+		{[]api.ManagedFieldsEntry{{Manager: "a", Operation: api.ManagedFieldsOperationApply, APIVersion: "v1", FieldsV1: fields}}, "must be `FieldsV1` when fieldsV1 is set"},
+		{[]api.ManagedFieldsEntry{{Manager: "a", Operation: api.ManagedFieldsOperationUpdate, APIVersion: "a/b/c"}}, "must be a valid group/version"},
+	}
+	for i := range errorCases {
+		errs := ValidateManagedFields(errorCases[i].entries, field.NewPath("field"))
+		if len(errs) != 1 {
+			t.Errorf("case[%d]: expected one failure, got %#v", i, errs)
+		} else if !strings.Contains(errs[0].Detail, errorCases[i].expect) {
+			t.Errorf("case[%d]: error details do not include %q: %q", i, errorCases[i].expect, errs[0].Detail)
+		}
+	}
+
+	// missing apiVersion is required, not invalid
+	errs := ValidateManagedFields([]api.ManagedFieldsEntry{{Manager: "a", Operation: api.ManagedFieldsOperationUpdate}}, field.NewPath("field"))
+	if len(errs) != 1 || errs[0].Type != field.ErrorTypeRequired {
+		t.Errorf("expected a required error for a missing apiVersion, got %#v", errs)
+	}
+}
+
 func TestValidateAnnotations(t *testing.T) {
 	successCases := []map[string]string{
 		{"simple": "bar"},
@@ -4646,11 +4686,11 @@ func TestValidateNode(t *testing.T) {
 		for i := range errs {
 			field := errs[i].Field
 			expectedFields := map[string]bool{
-				"metadata.name":                                                                                               true,
-				"metadata.labels":                                                                                             true,
-				"metadata.annotations":                                                                                        true,
-				"metadata.namespace":                                                                                          true,
-				"spec.externalID":                                                                                             true,
+				"metadata.name":        true,
+				"metadata.labels":      true,
+				"metadata.annotations": true,
+				"metadata.namespace":   true,
+				"spec.externalID":      true,
 				"metadata.annotations.scheduler.alpha.kubernetes.io/taints[0].key":                                            true,
 				"metadata.annotations.scheduler.alpha.kubernetes.io/taints[0].value":                                          true,
 				"metadata.annotations.scheduler.alpha.kubernetes.io/taints[0].effect":                                         true,
@@ -6036,7 +6076,7 @@ func TestValidateBasicAuthSecret(t *testing.T) {
 		secret api.Secret
 		valid  bool
 	}{
-		"valid": {validBasicAuthSecret(), true},
+		"valid":                         {validBasicAuthSecret(), true},
 		"missing username and password": {missingBasicAuthUsernamePasswordKeys, false},
 		// "invalid username and password": {invalidBasicAuthUsernamePasswordKey, false},
 		// "empty username":   {emptyBasicAuthUsernameKey, false},
@@ -0,0 +1,84 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+)
+
+// +genclient=true
+// +nonNamespaced=true
+
+// RuntimeClass defines a class of container runtime supported in the cluster.
+// The RuntimeClass is used to determine which container runtime is used to
+// run all containers in a pod. RuntimeClasses are manually defined by a user
+// or cluster provisioner, and referenced in the PodSpec.
+type RuntimeClass struct {
+	unversioned.TypeMeta `json:",inline"`
+	api.ObjectMeta       `json:"metadata,omitempty"`
+
+	// Handler specifies the underlying runtime and configuration that the
+	// container runtime implementation will use to handle pods of this
+	// class. The Handler must conform to the DNS Label (RFC 1123)
+	// requirements and is immutable.
+	Handler string `json:"handler"`
+
+	// Overhead represents the resource overhead associated with running a
+	// pod for a given RuntimeClass, so that sandboxed runtimes can be
+	// accounted for in scheduling and quota.
+	Overhead *Overhead `json:"overhead,omitempty"`
+
+	// Scheduling holds the scheduling constraints to ensure that pods
+	// running with this RuntimeClass are scheduled to nodes that support
+	// it. If scheduling is nil, this RuntimeClass is assumed to be
+	// supported by all nodes.
+	Scheduling *Scheduling `json:"scheduling,omitempty"`
+}
+
+// Overhead structure represents the resource overhead associated with running
+// a pod.
+type Overhead struct {
+	// PodFixed represents the fixed resource overhead associated with
+	// running a pod.
+	PodFixed api.ResourceList `json:"podFixed,omitempty"`
+}
+
+// Scheduling specifies the scheduling constraints for nodes supporting a
+// RuntimeClass.
+type Scheduling struct {
+	// NodeSelector lists labels that must be present on nodes that support
+	// this RuntimeClass. Pods using this RuntimeClass can only be
+	// scheduled to a node matched by this selector. The RuntimeClass
+	// nodeSelector is merged with a pod's existing nodeSelector. Any
+	// conflicts will cause the pod to be rejected in admission.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations are appended (excluding duplicates) to pods running
+	// with this RuntimeClass during admission, effectively unioning the
+	// set of nodes tolerated by the pod and the RuntimeClass.
+	Tolerations []api.Toleration `json:"tolerations,omitempty"`
+}
+
+// RuntimeClassList is a list of RuntimeClass objects.
+type RuntimeClassList struct {
+	unversioned.TypeMeta `json:",inline"`
+	unversioned.ListMeta `json:"metadata,omitempty"`
+
+	// Items is a list of schema objects.
+	Items []RuntimeClass `json:"items"`
+}
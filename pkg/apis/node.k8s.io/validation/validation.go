@@ -0,0 +1,77 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	unversionedvalidation "k8s.io/kubernetes/pkg/api/unversioned/validation"
+	apivalidation "k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/apis/node.k8s.io"
+	"k8s.io/kubernetes/pkg/util/validation"
+	"k8s.io/kubernetes/pkg/util/validation/field"
+)
+
+// ValidateRuntimeClassName can be used to check whether the given name is
+// valid for a RuntimeClass.
+func ValidateRuntimeClassName(name string, prefix bool) []string {
+	return apivalidation.NameIsDNSSubdomain(name, prefix)
+}
+
+// ValidateRuntimeClass validates a RuntimeClass.
+func ValidateRuntimeClass(rc *node.RuntimeClass) field.ErrorList {
+	allErrs := apivalidation.ValidateObjectMeta(&rc.ObjectMeta, false, ValidateRuntimeClassName, field.NewPath("metadata"))
+
+	for _, msg := range validation.IsDNS1123Label(rc.Handler) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("handler"), rc.Handler, msg))
+	}
+
+	if rc.Overhead != nil {
+		allErrs = append(allErrs, validateOverhead(rc.Overhead, field.NewPath("overhead"))...)
+	}
+
+	if rc.Scheduling != nil {
+		allErrs = append(allErrs, validateScheduling(rc.Scheduling, field.NewPath("scheduling"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateRuntimeClassUpdate validates an update to a RuntimeClass. The
+// Handler is immutable: pods already admitted against the class must keep
+// running with the runtime they were scheduled for.
+func ValidateRuntimeClassUpdate(newClass, oldClass *node.RuntimeClass) field.ErrorList {
+	allErrs := apivalidation.ValidateObjectMetaUpdate(&newClass.ObjectMeta, &oldClass.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newClass.Handler, oldClass.Handler, field.NewPath("handler"))...)
+	allErrs = append(allErrs, ValidateRuntimeClass(newClass)...)
+	return allErrs
+}
+
+func validateOverhead(overhead *node.Overhead, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	podFixedPath := fldPath.Child("podFixed")
+	for k, v := range overhead.PodFixed {
+		resPath := podFixedPath.Key(string(k))
+		allErrs = append(allErrs, apivalidation.ValidateResourceQuantityValue(string(k), v, resPath)...)
+	}
+	return allErrs
+}
+
+func validateScheduling(scheduling *node.Scheduling, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, unversionedvalidation.ValidateLabels(scheduling.NodeSelector, fldPath.Child("nodeSelector"))...)
+	allErrs = append(allErrs, apivalidation.ValidateTolerations(scheduling.Tolerations, fldPath.Child("tolerations"))...)
+	return allErrs
+}
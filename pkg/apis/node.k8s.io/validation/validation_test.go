@@ -0,0 +1,127 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/apis/node.k8s.io"
+)
+
+func validRuntimeClass() *node.RuntimeClass {
+	return &node.RuntimeClass{
+		ObjectMeta: api.ObjectMeta{Name: "sandboxed"},
+		Handler:    "kata",
+	}
+}
+
+func TestValidateRuntimeClass(t *testing.T) {
+	successCases := map[string]*node.RuntimeClass{
+		"minimal": validRuntimeClass(),
+		"with overhead": {
+			ObjectMeta: api.ObjectMeta{Name: "sandboxed"},
+			Handler:    "kata",
+			Overhead: &node.Overhead{
+				PodFixed: api.ResourceList{
+					api.ResourceCPU:    resource.MustParse("250m"),
+					api.ResourceMemory: resource.MustParse("64Mi"),
+				},
+			},
+		},
+	}
+	successCases["with scheduling"] = &node.RuntimeClass{
+		ObjectMeta: api.ObjectMeta{Name: "sandboxed"},
+		Handler:    "kata",
+		Scheduling: &node.Scheduling{
+			NodeSelector: map[string]string{"runtime": "kata"},
+			Tolerations: []api.Toleration{{
+				Key:      "sandboxed",
+				Operator: api.TolerationOpExists,
+				Effect:   api.TaintEffectNoSchedule,
+			}},
+		},
+	}
+	for name, rc := range successCases {
+		if errs := ValidateRuntimeClass(rc); len(errs) != 0 {
+			t.Errorf("%s: unexpected errors: %v", name, errs)
+		}
+	}
+
+	errorCases := map[string]*node.RuntimeClass{
+		"missing name": {
+			Handler: "kata",
+		},
+		"invalid handler": {
+			ObjectMeta: api.ObjectMeta{Name: "sandboxed"},
+			Handler:    "not a label",
+		},
+		"invalid node selector": {
+			ObjectMeta: api.ObjectMeta{Name: "sandboxed"},
+			Handler:    "kata",
+			Scheduling: &node.Scheduling{
+				NodeSelector: map[string]string{"not a valid label": "kata"},
+			},
+		},
+		"invalid toleration operator": {
+			ObjectMeta: api.ObjectMeta{Name: "sandboxed"},
+			Handler:    "kata",
+			Scheduling: &node.Scheduling{
+				Tolerations: []api.Toleration{{
+					Key:      "sandboxed",
+					Operator: "DoesNotExist",
+				}},
+			},
+		},
+		"negative overhead": {
+			ObjectMeta: api.ObjectMeta{Name: "sandboxed"},
+			Handler:    "kata",
+			Overhead: &node.Overhead{
+				PodFixed: api.ResourceList{
+					api.ResourceCPU: resource.MustParse("-250m"),
+				},
+			},
+		},
+	}
+	for name, rc := range errorCases {
+		if errs := ValidateRuntimeClass(rc); len(errs) == 0 {
+			t.Errorf("%s: expected errors, got none", name)
+		}
+	}
+}
+
+func TestValidateRuntimeClassUpdate(t *testing.T) {
+	oldClass := validRuntimeClass()
+	oldClass.ResourceVersion = "1"
+
+	newClass := validRuntimeClass()
+	newClass.ResourceVersion = "1"
+	newClass.Overhead = &node.Overhead{
+		PodFixed: api.ResourceList{api.ResourceCPU: resource.MustParse("100m")},
+	}
+	if errs := ValidateRuntimeClassUpdate(newClass, oldClass); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	newClass = validRuntimeClass()
+	newClass.ResourceVersion = "1"
+	newClass.Handler = "other"
+	if errs := ValidateRuntimeClassUpdate(newClass, oldClass); len(errs) == 0 {
+		t.Errorf("expected handler change to be rejected")
+	}
+}
@@ -1237,9 +1237,9 @@ func TestMetadata(t *testing.T) {
 			matches[s] = i + 1
 		}
 	}
-	if matches["text/plain,application/json,application/yaml,application/vnd.kubernetes.protobuf"] == 0 ||
+	if matches["text/plain,application/json,application/yaml,application/cbor,application/vnd.kubernetes.protobuf"] == 0 ||
 		matches["application/json,application/json;stream=watch,application/vnd.kubernetes.protobuf,application/vnd.kubernetes.protobuf;stream=watch"] == 0 ||
-		matches["application/json,application/yaml,application/vnd.kubernetes.protobuf"] == 0 ||
+		matches["application/json,application/yaml,application/cbor,application/vnd.kubernetes.protobuf"] == 0 ||
 		matches["application/json"] == 0 ||
 		matches["*/*"] == 0 ||
 		len(matches) != 5 {
@@ -2058,6 +2058,39 @@ func TestPatch(t *testing.T) {
 	}
 }
 
+func TestApplyPatch(t *testing.T) {
+	storage := map[string]rest.Storage{}
+	ID := "id"
+	item := &apiservertesting.Simple{
+		ObjectMeta: api.ObjectMeta{
+			Name:      ID,
+			Namespace: "",
+			UID:       "uid",
+		},
+		Other: "bar",
+	}
+	simpleStorage := SimpleRESTStorage{item: *item}
+	storage["simple"] = &simpleStorage
+	handler := handle(storage)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := http.Client{}
+	request, err := http.NewRequest("PATCH", server.URL+"/"+prefix+"/"+testGroupVersion.Group+"/"+testGroupVersion.Version+"/namespaces/default/simple/"+ID, bytes.NewReader([]byte(`{"labels":{"foo":"bar"}}`)))
+	request.Header.Set("Content-Type", string(api.ApplyPatchType))
+	response, err := client.Do(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("Unexpected response %#v", response)
+	}
+
+	if simpleStorage.updated == nil || simpleStorage.updated.Labels["foo"] != "bar" {
+		t.Errorf("Unexpected update value %#v, expected %#v.", simpleStorage.updated, item)
+	}
+}
+
 func TestPatchRequiresMatchingName(t *testing.T) {
 	storage := map[string]rest.Storage{}
 	ID := "id"
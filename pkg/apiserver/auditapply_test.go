@@ -0,0 +1,129 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/util/sets"
+)
+
+func TestWithApplyAudit(t *testing.T) {
+	out := &bytes.Buffer{}
+	config := ApplyAuditConfig{
+		Resources: sets.NewString("pods", "secrets"),
+		Out:       out,
+	}
+	mapper := api.NewRequestContextMapper()
+
+	var downstreamBody []byte
+	inner := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		downstreamBody, _ = ioutil.ReadAll(req.Body)
+	})
+	withUser := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx, _ := mapper.Get(req)
+		mapper.Update(req, api.WithUser(ctx, &user.DefaultInfo{Name: "deployer"}))
+		WithApplyAudit(inner, config, mapper, newTestRequestInfoResolver()).ServeHTTP(w, req)
+	})
+	handler, err := api.NewRequestContextFilter(mapper, withUser)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	send := func(method, url, contentType, body string) {
+		req, _ := http.NewRequest(method, url, strings.NewReader(body))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// apply against a selected resource is recorded and the body still
+	// reaches the downstream handler
+	send("PATCH", "/api/v1/namespaces/other/pods/foo", string(api.ApplyPatchType), `{"metadata":{"labels":{"app":"web"}}}`)
+	line := out.String()
+	if !strings.Contains(line, `user="deployer"`) || !strings.Contains(line, `resource="pods"`) || !strings.Contains(line, `"app":"web"`) {
+		t.Errorf("expected the apply body to be recorded with its user, got %q", line)
+	}
+	if string(downstreamBody) != `{"metadata":{"labels":{"app":"web"}}}` {
+		t.Errorf("expected the body to be restored for downstream handlers, got %q", downstreamBody)
+	}
+
+	// secret values are redacted
+	out.Reset()
+	send("PATCH", "/api/v1/namespaces/other/secrets/creds", string(api.ApplyPatchType), `{"data":{"password":"aHVudGVyMg=="}}`)
+	line = out.String()
+	if strings.Contains(line, "aHVudGVyMg==") {
+		t.Errorf("expected secret values to be redacted, got %q", line)
+	}
+	if !strings.Contains(line, `"REDACTED"`) {
+		t.Errorf("expected a redaction marker, got %q", line)
+	}
+
+	// impersonated requests record both identities
+	out.Reset()
+	withImpersonator := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx, _ := mapper.Get(req)
+		ctx = api.WithUser(ctx, &user.DefaultInfo{Name: "deployer"})
+		ctx = api.WithImpersonatingUser(ctx, &user.DefaultInfo{Name: "admin"})
+		mapper.Update(req, ctx)
+		WithApplyAudit(inner, config, mapper, newTestRequestInfoResolver()).ServeHTTP(w, req)
+	})
+	impersonated, err := api.NewRequestContextFilter(mapper, withImpersonator)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req, _ := http.NewRequest("PATCH", "/api/v1/namespaces/other/pods/foo", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", string(api.ApplyPatchType))
+	impersonated.ServeHTTP(httptest.NewRecorder(), req)
+	line = out.String()
+	if !strings.Contains(line, `user="admin"`) || !strings.Contains(line, `as-user="deployer"`) {
+		t.Errorf("expected both identities to be recorded, got %q", line)
+	}
+
+	// a media type parameter must not let an apply evade being recorded
+	out.Reset()
+	send("PATCH", "/api/v1/namespaces/other/pods/foo", string(api.ApplyPatchType)+"; charset=utf-8", `{"metadata":{"labels":{"app":"web"}}}`)
+	if !strings.Contains(out.String(), `"app":"web"`) {
+		t.Errorf("expected a parameterized content type to still be recorded, got %q", out.String())
+	}
+
+	// non-apply patches and unselected resources are not recorded
+	out.Reset()
+	send("PATCH", "/api/v1/namespaces/other/pods/foo", string(api.StrategicMergePatchType), `{}`)
+	send("PATCH", "/api/v1/namespaces/other/services/svc", string(api.ApplyPatchType), `{}`)
+	if out.Len() != 0 {
+		t.Errorf("expected nothing to be recorded, got %q", out.String())
+	}
+}
+
+func TestRedactSecretValuesUnparseable(t *testing.T) {
+	redacted := redactSecretValues([]byte(`not json`))
+	if strings.Contains(string(redacted), "not json") {
+		t.Errorf("expected an unparseable body to be dropped, got %q", redacted)
+	}
+	if !strings.Contains(string(redacted), "REDACTED") {
+		t.Errorf("expected a redaction marker, got %q", redacted)
+	}
+}
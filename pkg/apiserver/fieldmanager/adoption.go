@@ -0,0 +1,142 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// A new controller taking over pre-existing objects faces a dilemma: its
+// first apply conflicts, and force is a blunt instrument that would also
+// override genuinely divergent values. The adoption manager resolves the
+// common safe case: when the apply would not change any value — every
+// applied field already equals the live object — taking ownership cannot
+// break anything, so the conflict is waived and the fields are adopted.
+
+type adoptionManager struct {
+	delegate Manager
+}
+
+var _ Manager = &adoptionManager{}
+
+// NewAdoptionManager wraps a manager so that an apply refused as a takeover
+// is retried with force when the applied configuration changes no values.
+// Applies that would change a conflicting field still require force.
+func NewAdoptionManager(delegate Manager) Manager {
+	return &adoptionManager{delegate: delegate}
+}
+
+// Update implements Manager.
+func (a *adoptionManager) Update(liveObj, newObj runtime.Object, managed []api.ManagedFieldsEntry, manager string) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	return a.delegate.Update(liveObj, newObj, managed, manager)
+}
+
+// Apply implements Manager.
+func (a *adoptionManager) Apply(liveObj, appliedObj runtime.Object, managed []api.ManagedFieldsEntry, manager string, force bool) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	obj, out, err := a.delegate.Apply(liveObj, appliedObj, managed, manager, force)
+	if err == nil || force || !IsTakeoverConflict(err) {
+		return obj, out, err
+	}
+	adoptable, containsErr := objectContains(liveObj, appliedObj)
+	if containsErr != nil || !adoptable {
+		// when in doubt, keep the conflict
+		return nil, nil, err
+	}
+	return a.delegate.Apply(liveObj, appliedObj, managed, manager, true)
+}
+
+// objectContains returns true when every field set in the applied object
+// equals the corresponding field of the live object, i.e. the apply would
+// change no values. The comparison runs on the JSON forms so a partial
+// configuration only constrains the fields it mentions.
+func objectContains(liveObj, appliedObj runtime.Object) (bool, error) {
+	live, err := objectToMap(liveObj)
+	if err != nil {
+		return false, err
+	}
+	applied, err := objectToMap(appliedObj)
+	if err != nil {
+		return false, err
+	}
+	return mapContains(live, applied), nil
+}
+
+func objectToMap(obj runtime.Object) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]interface{}{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func mapContains(live, applied map[string]interface{}) bool {
+	for key, value := range applied {
+		liveValue, ok := live[key]
+		if !ok {
+			if isEmptyValue(value) {
+				// omitted and zero-valued are the same field on the wire
+				continue
+			}
+			return false
+		}
+		appliedMap, appliedIsMap := value.(map[string]interface{})
+		liveMap, liveIsMap := liveValue.(map[string]interface{})
+		if appliedIsMap && liveIsMap {
+			if !mapContains(liveMap, appliedMap) {
+				return false
+			}
+			continue
+		}
+		if !reflect.DeepEqual(value, liveValue) {
+			return false
+		}
+	}
+	return true
+}
+
+// isEmptyValue mirrors the encoding/json notion of emptiness for fields
+// that internal types serialize without omitempty.
+func isEmptyValue(value interface{}) bool {
+	switch typed := value.(type) {
+	case nil:
+		return true
+	case string:
+		return len(typed) == 0
+	case bool:
+		return !typed
+	case float64:
+		return typed == 0
+	case []interface{}:
+		return len(typed) == 0
+	case map[string]interface{}:
+		for _, nested := range typed {
+			if !isEmptyValue(nested) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
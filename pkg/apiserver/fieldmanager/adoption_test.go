@@ -0,0 +1,138 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+func TestAdoptionWaivesNoChangeConflicts(t *testing.T) {
+	manager := NewAdoptionManager(NewStrictTakeoverManager(NewStampManager(runtime.APIVersionInternal)))
+
+	// an object whose only ownership record is a tombstone conflicts under
+	// the strict takeover manager
+	live := podWithManagedFields(validEntry(TombstoneManager, 0))
+	live.Labels = map[string]string{"app": "web"}
+
+	applied := &api.Pod{ObjectMeta: api.ObjectMeta{
+		Name:   live.Name,
+		Labels: map[string]string{"app": "web"},
+	}}
+	applied.Namespace = live.Namespace
+
+	obj, managed, err := manager.Apply(live, applied, live.ManagedFields, "new-controller", false)
+	if err != nil {
+		t.Fatalf("expected a value-preserving apply to adopt without force, got %v", err)
+	}
+	if obj == nil {
+		t.Fatalf("expected the adopted object back")
+	}
+	found := false
+	for _, entry := range managed {
+		if entry.Manager == "new-controller" {
+			found = true
+		}
+		if entry.Manager == TombstoneManager {
+			t.Errorf("expected the tombstone to be consumed by the adoption, got %v", managed)
+		}
+	}
+	if !found {
+		t.Errorf("expected an entry for the adopting manager, got %v", managed)
+	}
+}
+
+func TestAdoptionKeepsValueChangingConflicts(t *testing.T) {
+	manager := NewAdoptionManager(NewStrictTakeoverManager(NewStampManager(runtime.APIVersionInternal)))
+
+	live := podWithManagedFields(validEntry(TombstoneManager, 0))
+	live.Labels = map[string]string{"app": "web"}
+
+	applied := &api.Pod{ObjectMeta: api.ObjectMeta{
+		Name:   live.Name,
+		Labels: map[string]string{"app": "db"},
+	}}
+	applied.Namespace = live.Namespace
+
+	if _, _, err := manager.Apply(live, applied, live.ManagedFields, "new-controller", false); !IsTakeoverConflict(err) {
+		t.Fatalf("expected a value-changing apply to keep its conflict, got %v", err)
+	}
+
+	// force still works as the escape hatch
+	if _, _, err := manager.Apply(live, applied, live.ManagedFields, "new-controller", true); err != nil {
+		t.Errorf("expected force to resolve the conflict, got %v", err)
+	}
+}
+
+func TestMapContains(t *testing.T) {
+	tests := []struct {
+		name     string
+		live     map[string]interface{}
+		applied  map[string]interface{}
+		expected bool
+	}{
+		{
+			name:     "empty configuration is contained",
+			live:     map[string]interface{}{"a": "b"},
+			applied:  map[string]interface{}{},
+			expected: true,
+		},
+		{
+			name:     "equal leaves are contained",
+			live:     map[string]interface{}{"a": "b", "c": "d"},
+			applied:  map[string]interface{}{"a": "b"},
+			expected: true,
+		},
+		{
+			name:     "differing leaves are not",
+			live:     map[string]interface{}{"a": "b"},
+			applied:  map[string]interface{}{"a": "x"},
+			expected: false,
+		},
+		{
+			name:     "new fields are not",
+			live:     map[string]interface{}{},
+			applied:  map[string]interface{}{"a": "b"},
+			expected: false,
+		},
+		{
+			name:     "empty values match omitted fields",
+			live:     map[string]interface{}{},
+			applied:  map[string]interface{}{"a": "", "b": float64(0), "c": map[string]interface{}{}},
+			expected: true,
+		},
+		{
+			name:     "nested maps recurse",
+			live:     map[string]interface{}{"m": map[string]interface{}{"x": "1", "y": "2"}},
+			applied:  map[string]interface{}{"m": map[string]interface{}{"x": "1"}},
+			expected: true,
+		},
+		{
+			name:     "lists compare whole",
+			live:     map[string]interface{}{"l": []interface{}{"a", "b"}},
+			applied:  map[string]interface{}{"l": []interface{}{"a"}},
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		if contained := mapContains(test.live, test.applied); contained != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, contained)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// The merge and compare work of a write grows with the depth and size of
+// the object, and an adversarial payload — thousands of nesting levels,
+// enormous lists — can pin an apiserver goroutine for far longer than any
+// legitimate request. The budget manager bounds that work: a chain that
+// runs past its budget is abandoned and the request fails with an error
+// naming the phase that blew the budget, instead of the whole server
+// degrading.
+
+// DefaultMergeBudget is the merge budget the generic registry uses when no
+// explicit budget is configured. Legitimate objects finish in well under a
+// millisecond; five seconds only cuts off the pathological cases.
+const DefaultMergeBudget = 5 * time.Second
+
+// BudgetExceededError reports a merge that was abandoned because it ran
+// past its time budget.
+type BudgetExceededError struct {
+	// Phase is the phase that blew the budget: "update" or "apply".
+	Phase string
+	// Budget is the budget that was exceeded.
+	Budget time.Duration
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("the %s phase exceeded its merge budget of %v: the object may be too large or too deeply nested to process", e.Phase, e.Budget)
+}
+
+// IsBudgetExceeded returns true when the error reports an abandoned merge.
+func IsBudgetExceeded(err error) bool {
+	_, ok := err.(*BudgetExceededError)
+	return ok
+}
+
+type budgetManager struct {
+	delegate Manager
+	budget   time.Duration
+}
+
+var _ Manager = &budgetManager{}
+
+// NewBudgetManager wraps a manager so its calls are abandoned once they run
+// past the budget. The delegate's goroutine is left to finish on its own —
+// its result is discarded — so the budget bounds request latency and caps
+// how many merges a flood of pathological payloads can keep in flight.
+func NewBudgetManager(delegate Manager, budget time.Duration) Manager {
+	return &budgetManager{delegate: delegate, budget: budget}
+}
+
+// Update implements Manager.
+func (b *budgetManager) Update(liveObj, newObj runtime.Object, managed []api.ManagedFieldsEntry, manager string) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	return b.run("update", func() (runtime.Object, []api.ManagedFieldsEntry, error) {
+		return b.delegate.Update(liveObj, newObj, managed, manager)
+	})
+}
+
+// Apply implements Manager.
+func (b *budgetManager) Apply(liveObj, appliedObj runtime.Object, managed []api.ManagedFieldsEntry, manager string, force bool) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	return b.run("apply", func() (runtime.Object, []api.ManagedFieldsEntry, error) {
+		return b.delegate.Apply(liveObj, appliedObj, managed, manager, force)
+	})
+}
+
+type managerResult struct {
+	obj     runtime.Object
+	managed []api.ManagedFieldsEntry
+	err     error
+}
+
+func (b *budgetManager) run(phase string, fn func() (runtime.Object, []api.ManagedFieldsEntry, error)) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	// buffered so the delegate's goroutine can finish and be collected even
+	// after the budget fires and nobody receives
+	done := make(chan managerResult, 1)
+	go func() {
+		obj, managed, err := fn()
+		done <- managerResult{obj: obj, managed: managed, err: err}
+	}()
+
+	timer := time.NewTimer(b.budget)
+	defer timer.Stop()
+	select {
+	case result := <-done:
+		return result.obj, result.managed, result.err
+	case <-timer.C:
+		RecordMergeBudgetExceeded(phase)
+		return nil, nil, &BudgetExceededError{Phase: phase, Budget: b.budget}
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// stallManager blocks until released, standing in for a merge that runs
+// away on a pathological object.
+type stallManager struct {
+	release chan struct{}
+}
+
+var _ Manager = &stallManager{}
+
+func (s *stallManager) Update(liveObj, newObj runtime.Object, managed []api.ManagedFieldsEntry, manager string) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	<-s.release
+	return newObj, managed, nil
+}
+
+func (s *stallManager) Apply(liveObj, appliedObj runtime.Object, managed []api.ManagedFieldsEntry, manager string, force bool) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	<-s.release
+	return appliedObj, managed, nil
+}
+
+func TestBudgetPassesFastMerges(t *testing.T) {
+	wrapped := NewBudgetManager(NewStampManager(runtime.APIVersionInternal), DefaultMergeBudget)
+	obj, managed, err := wrapped.Update(nil, &api.Pod{}, nil, "deploy-tool")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if obj == nil || len(managed) != 1 {
+		t.Errorf("expected the delegate's result to pass through, got obj=%v managed=%v", obj, managed)
+	}
+}
+
+func TestBudgetAbandonsSlowMerges(t *testing.T) {
+	stall := &stallManager{release: make(chan struct{})}
+	defer close(stall.release)
+	wrapped := NewBudgetManager(stall, time.Millisecond)
+
+	_, _, err := wrapped.Apply(nil, &api.Pod{}, nil, "deploy-tool", false)
+	if !IsBudgetExceeded(err) {
+		t.Fatalf("expected a budget exceeded error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "apply") {
+		t.Errorf("expected the error to name the phase, got %q", err.Error())
+	}
+
+	_, _, err = wrapped.Update(nil, &api.Pod{}, nil, "deploy-tool")
+	if !IsBudgetExceeded(err) {
+		t.Fatalf("expected a budget exceeded error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "update") {
+		t.Errorf("expected the error to name the phase, got %q", err.Error())
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/record"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+const (
+	// DefaultMaxManagedFieldsEntries is the default limit on the number of
+	// managedFields entries kept for a single object. Entries in excess of
+	// the limit are dropped, oldest first.
+	DefaultMaxManagedFieldsEntries = 10
+
+	// DefaultMaxManagedFieldsSize is the default limit, in bytes, on the
+	// total size of the field sets stored in an object's managedFields.
+	// When the limit is exceeded the managedFields are reset entirely:
+	// losing ownership history is preferable to unbounded growth of
+	// object metadata.
+	DefaultMaxManagedFieldsSize = 128 * 1024
+)
+
+// Reasons used on events recorded when the server alters an object's
+// managedFields. Owners watching for these reasons can tell that ownership
+// history was changed by the server rather than by a client.
+const (
+	// ManagedFieldsCappedReason is used when entries were dropped to keep
+	// the number of managers below the configured limit.
+	ManagedFieldsCappedReason = "ManagedFieldsCapped"
+	// ManagedFieldsResetReason is used when the managedFields were cleared
+	// entirely, either because they were too large or because they
+	// contained invalid entries.
+	ManagedFieldsResetReason = "ManagedFieldsReset"
+)
+
+// CapManagedFields enforces limits on the managedFields of the given object.
+// Invalid entries cause the managedFields to be reset, and if the valid
+// entries exceed the configured limits the oldest entries are dropped. Any
+// alteration is recorded as a warning event against the object through the
+// given recorder, which may be nil.
+func CapManagedFields(obj runtime.Object, recorder record.EventRecorder, maxEntries int, maxSize int) error {
+	meta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		return fmt.Errorf("unable to access managedFields: %v", err)
+	}
+	if len(meta.ManagedFields) == 0 {
+		return nil
+	}
+
+	if err := validateManagedFields(meta.ManagedFields); err != nil {
+		meta.ManagedFields = nil
+		recordEvent(recorder, obj, ManagedFieldsResetReason, fmt.Sprintf("managedFields were reset because they contained an invalid entry: %v", err))
+		return nil
+	}
+
+	if size := managedFieldsSize(meta.ManagedFields); size > maxSize {
+		meta.ManagedFields = nil
+		recordEvent(recorder, obj, ManagedFieldsResetReason, fmt.Sprintf("managedFields were reset because their size (%d bytes) exceeded the maximum (%d bytes)", size, maxSize))
+		return nil
+	}
+
+	if len(meta.ManagedFields) > maxEntries {
+		dropped := len(meta.ManagedFields) - maxEntries
+		meta.ManagedFields = capEntries(meta.ManagedFields, maxEntries)
+		recordEvent(recorder, obj, ManagedFieldsCappedReason, fmt.Sprintf("the %d oldest managedFields entries were dropped to stay below the maximum of %d entries", dropped, maxEntries))
+	}
+	return nil
+}
+
+// validateManagedFields returns an error if any entry is structurally
+// invalid. Validation here is deliberately shallow: the field sets
+// themselves are opaque to the server.
+func validateManagedFields(entries []api.ManagedFieldsEntry) error {
+	for i, entry := range entries {
+		if len(entry.Manager) == 0 {
+			return fmt.Errorf("entry %d has no manager", i)
+		}
+		switch entry.Operation {
+		case api.ManagedFieldsOperationApply, api.ManagedFieldsOperationUpdate:
+		default:
+			return fmt.Errorf("entry %d has invalid operation %q", i, entry.Operation)
+		}
+		if len(entry.APIVersion) == 0 {
+			return fmt.Errorf("entry %d has no apiVersion", i)
+		}
+	}
+	return nil
+}
+
+// managedFieldsSize returns the number of bytes of field set data stored in
+// the given entries.
+func managedFieldsSize(entries []api.ManagedFieldsEntry) int {
+	size := 0
+	for _, entry := range entries {
+		if entry.FieldsV1 != nil {
+			size += len(entry.FieldsV1.Raw)
+		}
+	}
+	return size
+}
+
+// capEntries drops the oldest entries until at most maxEntries remain.
+// Entries without a timestamp are considered oldest. The relative order of
+// the remaining entries is preserved.
+func capEntries(entries []api.ManagedFieldsEntry, maxEntries int) []api.ManagedFieldsEntry {
+	byAge := make([]int, len(entries))
+	for i := range byAge {
+		byAge[i] = i
+	}
+	sort.Stable(&indexesByAge{indexes: byAge, entries: entries})
+	drop := make(map[int]bool, len(entries)-maxEntries)
+	for _, i := range byAge[:len(entries)-maxEntries] {
+		drop[i] = true
+	}
+	kept := make([]api.ManagedFieldsEntry, 0, maxEntries)
+	for i, entry := range entries {
+		if !drop[i] {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+// indexesByAge sorts indexes into entries from oldest to newest.
+type indexesByAge struct {
+	indexes []int
+	entries []api.ManagedFieldsEntry
+}
+
+func (s *indexesByAge) Len() int      { return len(s.indexes) }
+func (s *indexesByAge) Swap(i, j int) { s.indexes[i], s.indexes[j] = s.indexes[j], s.indexes[i] }
+func (s *indexesByAge) Less(i, j int) bool {
+	return olderThan(s.entries[s.indexes[i]], s.entries[s.indexes[j]])
+}
+
+func olderThan(a, b api.ManagedFieldsEntry) bool {
+	if a.Time == nil {
+		return true
+	}
+	if b.Time == nil {
+		return false
+	}
+	return a.Time.Before(*b.Time)
+}
+
+func recordEvent(recorder record.EventRecorder, obj runtime.Object, reason, message string) {
+	if recorder == nil {
+		return
+	}
+	recorder.Event(obj, api.EventTypeWarning, reason, message)
+}
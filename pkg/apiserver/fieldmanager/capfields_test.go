@@ -0,0 +1,123 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/client/record"
+)
+
+func validEntry(manager string, age time.Duration) api.ManagedFieldsEntry {
+	t := unversioned.NewTime(time.Now().Add(-age))
+	return api.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  api.ManagedFieldsOperationUpdate,
+		APIVersion: "v1",
+		Time:       &t,
+		FieldsType: "FieldsV1",
+		FieldsV1:   &api.FieldsV1{Raw: []byte(`{"f:metadata":{}}`)},
+	}
+}
+
+func podWithManagedFields(entries ...api.ManagedFieldsEntry) *api.Pod {
+	return &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			Name:          "test",
+			Namespace:     api.NamespaceDefault,
+			ManagedFields: entries,
+		},
+	}
+}
+
+func TestCapManagedFieldsNoop(t *testing.T) {
+	recorder := &record.FakeRecorder{Events: make(chan string, 1)}
+	pod := podWithManagedFields(validEntry("a", time.Hour), validEntry("b", time.Minute))
+	if err := CapManagedFields(pod, recorder, DefaultMaxManagedFieldsEntries, DefaultMaxManagedFieldsSize); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pod.ManagedFields) != 2 {
+		t.Errorf("expected managedFields to be untouched, got %v", pod.ManagedFields)
+	}
+	select {
+	case e := <-recorder.Events:
+		t.Errorf("unexpected event: %s", e)
+	default:
+	}
+}
+
+func TestCapManagedFieldsDropsOldest(t *testing.T) {
+	recorder := &record.FakeRecorder{Events: make(chan string, 1)}
+	entries := []api.ManagedFieldsEntry{}
+	for i := 0; i < 5; i++ {
+		entries = append(entries, validEntry(fmt.Sprintf("manager-%d", i), time.Duration(5-i)*time.Hour))
+	}
+	pod := podWithManagedFields(entries...)
+	if err := CapManagedFields(pod, recorder, 3, DefaultMaxManagedFieldsSize); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pod.ManagedFields) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(pod.ManagedFields))
+	}
+	for i, entry := range pod.ManagedFields {
+		if expected := fmt.Sprintf("manager-%d", i+2); entry.Manager != expected {
+			t.Errorf("entry %d: expected manager %q, got %q", i, expected, entry.Manager)
+		}
+	}
+	event := <-recorder.Events
+	if !strings.Contains(event, ManagedFieldsCappedReason) {
+		t.Errorf("expected %s event, got %q", ManagedFieldsCappedReason, event)
+	}
+}
+
+func TestCapManagedFieldsResetsInvalid(t *testing.T) {
+	recorder := &record.FakeRecorder{Events: make(chan string, 1)}
+	invalid := validEntry("", time.Hour)
+	pod := podWithManagedFields(validEntry("a", time.Hour), invalid)
+	if err := CapManagedFields(pod, recorder, DefaultMaxManagedFieldsEntries, DefaultMaxManagedFieldsSize); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pod.ManagedFields != nil {
+		t.Errorf("expected managedFields to be reset, got %v", pod.ManagedFields)
+	}
+	event := <-recorder.Events
+	if !strings.Contains(event, ManagedFieldsResetReason) {
+		t.Errorf("expected %s event, got %q", ManagedFieldsResetReason, event)
+	}
+}
+
+func TestCapManagedFieldsResetsOversized(t *testing.T) {
+	recorder := &record.FakeRecorder{Events: make(chan string, 1)}
+	big := validEntry("big", time.Hour)
+	big.FieldsV1 = &api.FieldsV1{Raw: make([]byte, 2048)}
+	pod := podWithManagedFields(big)
+	if err := CapManagedFields(pod, recorder, DefaultMaxManagedFieldsEntries, 1024); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pod.ManagedFields != nil {
+		t.Errorf("expected managedFields to be reset, got %v", pod.ManagedFields)
+	}
+	event := <-recorder.Events
+	if !strings.Contains(event, ManagedFieldsResetReason) {
+		t.Errorf("expected %s event, got %q", ManagedFieldsResetReason, event)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// CleanupReport summarizes a normalization pass over a set of objects. It is
+// the payload a cleanup controller or kubectl command prints for the cluster
+// administrator.
+type CleanupReport struct {
+	// Objects is the number of objects examined.
+	Objects int
+	// NormalizedObjects is the number of objects whose entries changed.
+	NormalizedObjects int
+	// BytesBefore and BytesAfter measure the serialized managedFields size
+	// across all examined objects.
+	BytesBefore int
+	BytesAfter  int
+}
+
+// ReclaimedBytes is the total managedFields storage freed by the pass.
+func (r CleanupReport) ReclaimedBytes() int {
+	return r.BytesBefore - r.BytesAfter
+}
+
+func (r CleanupReport) String() string {
+	return fmt.Sprintf("normalized %d of %d objects, reclaimed %d bytes", r.NormalizedObjects, r.Objects, r.ReclaimedBytes())
+}
+
+// NormalizeManagedFields merges entries that share a manager, operation and
+// version into one, and drops entries that own no fields at all. Clusters
+// upgraded through several iterations of the apply implementation accumulate
+// both kinds of residue. The returned bool reports whether anything changed.
+func NormalizeManagedFields(managed []api.ManagedFieldsEntry) ([]api.ManagedFieldsEntry, bool, error) {
+	changed := false
+	out := make([]api.ManagedFieldsEntry, 0, len(managed))
+	for _, entry := range managed {
+		if emptyEntry(entry) {
+			changed = true
+			continue
+		}
+		merged := false
+		for i := range out {
+			if !sameOwner(out[i], entry) {
+				continue
+			}
+			combined, err := mergeFieldsV1(out[i].FieldsV1, entry.FieldsV1)
+			if err != nil {
+				return nil, false, err
+			}
+			out[i].FieldsV1 = combined
+			if entry.Time != nil && (out[i].Time == nil || entry.Time.After(out[i].Time.Time)) {
+				out[i].Time = entry.Time
+			}
+			merged = true
+			changed = true
+			break
+		}
+		if !merged {
+			out = append(out, entry)
+		}
+	}
+	return out, changed, nil
+}
+
+// emptyEntry returns true for entries that record no owned fields. They take
+// space in every read of the object without contributing to merges or
+// conflict detection.
+func emptyEntry(entry api.ManagedFieldsEntry) bool {
+	return entry.FieldsV1 == nil || len(entry.FieldsV1.Raw) == 0 || string(entry.FieldsV1.Raw) == "{}"
+}
+
+// CleanupObjects normalizes the managedFields of every provided object in
+// place and reports what was reclaimed. Objects without object metadata are
+// counted but left alone.
+func CleanupObjects(objs []runtime.Object) (CleanupReport, error) {
+	report := CleanupReport{Objects: len(objs)}
+	for _, obj := range objs {
+		objectMeta, err := api.ObjectMetaFor(obj)
+		if err != nil {
+			continue
+		}
+		before := managedFieldsSize(objectMeta.ManagedFields)
+		normalized, changed, err := NormalizeManagedFields(objectMeta.ManagedFields)
+		if err != nil {
+			return report, err
+		}
+		report.BytesBefore += before
+		if !changed {
+			report.BytesAfter += before
+			continue
+		}
+		objectMeta.ManagedFields = normalized
+		report.BytesAfter += managedFieldsSize(normalized)
+		report.NormalizedObjects++
+	}
+	return report, nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+func TestNormalizeManagedFields(t *testing.T) {
+	managed := []api.ManagedFieldsEntry{
+		{
+			Manager:    "kubectl",
+			Operation:  api.ManagedFieldsOperationApply,
+			APIVersion: "v1",
+			FieldsType: "FieldsV1",
+			FieldsV1:   &api.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+		},
+		{
+			Manager:    "kubectl",
+			Operation:  api.ManagedFieldsOperationApply,
+			APIVersion: "v1",
+			FieldsType: "FieldsV1",
+			FieldsV1:   &api.FieldsV1{Raw: []byte(`{"f:spec":{"f:paused":{}}}`)},
+		},
+		{Manager: "empty-tool", Operation: api.ManagedFieldsOperationUpdate, APIVersion: "v1"},
+		{
+			Manager:    "controller",
+			Operation:  api.ManagedFieldsOperationUpdate,
+			APIVersion: "v1",
+			FieldsType: "FieldsV1",
+			FieldsV1:   &api.FieldsV1{Raw: []byte(`{"f:status":{}}`)},
+		},
+	}
+
+	out, changed, err := NormalizeManagedFields(managed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !changed {
+		t.Fatalf("expected the entries to change")
+	}
+	if len(out) != 2 {
+		t.Fatalf("expected duplicates and empty entries to be removed, got %#v", out)
+	}
+	if out[0].Manager != "kubectl" || out[1].Manager != "controller" {
+		t.Errorf("unexpected entries: %#v", out)
+	}
+
+	// normalizing again is a no-op
+	if _, changed, err := NormalizeManagedFields(out); err != nil || changed {
+		t.Errorf("expected a second pass to be a no-op, got changed=%v, err=%v", changed, err)
+	}
+}
+
+func TestCleanupObjects(t *testing.T) {
+	duplicated := &api.Pod{ObjectMeta: api.ObjectMeta{
+		Name: "duplicated",
+		ManagedFields: []api.ManagedFieldsEntry{
+			{Manager: "m", Operation: api.ManagedFieldsOperationApply, APIVersion: "v1", FieldsV1: &api.FieldsV1{Raw: []byte(`{"f:a":{}}`)}},
+			{Manager: "m", Operation: api.ManagedFieldsOperationApply, APIVersion: "v1", FieldsV1: &api.FieldsV1{Raw: []byte(`{"f:a":{}}`)}},
+		},
+	}}
+	clean := &api.Pod{ObjectMeta: api.ObjectMeta{
+		Name: "clean",
+		ManagedFields: []api.ManagedFieldsEntry{
+			{Manager: "m", Operation: api.ManagedFieldsOperationApply, APIVersion: "v1", FieldsV1: &api.FieldsV1{Raw: []byte(`{"f:b":{}}`)}},
+		},
+	}}
+
+	report, err := CleanupObjects([]runtime.Object{duplicated, clean})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Objects != 2 || report.NormalizedObjects != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	if report.ReclaimedBytes() <= 0 {
+		t.Errorf("expected bytes to be reclaimed, got %d", report.ReclaimedBytes())
+	}
+	if len(duplicated.ManagedFields) != 1 {
+		t.Errorf("expected the duplicate entries to merge in place, got %#v", duplicated.ManagedFields)
+	}
+	if len(clean.ManagedFields) != 1 {
+		t.Errorf("expected the clean object to be untouched, got %#v", clean.ManagedFields)
+	}
+}
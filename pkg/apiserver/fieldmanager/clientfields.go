@@ -0,0 +1,51 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// managedFields is maintained by the server; a client that writes its own
+// entries is almost always a buggy automation that decoded an object,
+// mutated it, and sent the bookkeeping back along with the change. The
+// strict mode rejects such writes loudly instead of letting the entries
+// through, so the bug surfaces where it is introduced.
+
+// ClientManagedFieldsMessage is the explanation returned with rejections of
+// client-supplied managedFields.
+const ClientManagedFieldsMessage = "metadata.managedFields is managed by the server and may not be changed by clients: clear the field before writing, or send back exactly the entries last read"
+
+// ClientSuppliedManagedFields reports whether the incoming object carries
+// managedFields entries the client made up. Echoing back exactly the live
+// object's entries is the normal read-modify-write pattern and does not
+// count; neither does sending no entries at all.
+func ClientSuppliedManagedFields(existing, obj runtime.Object) bool {
+	meta, err := api.ObjectMetaFor(obj)
+	if err != nil || len(meta.ManagedFields) == 0 {
+		return false
+	}
+	if existing != nil {
+		if existingMeta, err := api.ObjectMetaFor(existing); err == nil {
+			if api.Semantic.DeepEqual(existingMeta.ManagedFields, meta.ManagedFields) {
+				return false
+			}
+		}
+	}
+	return true
+}
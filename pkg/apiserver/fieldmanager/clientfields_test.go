@@ -0,0 +1,49 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func TestClientSuppliedManagedFields(t *testing.T) {
+	entry := validEntry("kubelet", 0)
+	live := podWithManagedFields(entry)
+
+	// no entries at all is fine
+	if ClientSuppliedManagedFields(live, &api.Pod{}) {
+		t.Errorf("expected an object without entries to pass")
+	}
+
+	// echoing back exactly the live entries is the normal
+	// read-modify-write pattern
+	if ClientSuppliedManagedFields(live, podWithManagedFields(entry)) {
+		t.Errorf("expected an exact echo of the live entries to pass")
+	}
+
+	// made-up entries are client-supplied
+	if !ClientSuppliedManagedFields(live, podWithManagedFields(validEntry("impostor", 0))) {
+		t.Errorf("expected made-up entries to be detected")
+	}
+
+	// entries on a create (no live entries to echo) are client-supplied
+	if !ClientSuppliedManagedFields(&api.Pod{}, podWithManagedFields(entry)) {
+		t.Errorf("expected entries on a create to be detected")
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"sort"
+
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// A list field with no registered merge rule falls back to atomic, the
+// conservative deduced typing: the apply keeps working, but the whole list
+// changes hands as a unit, which surprises manifests written against set or
+// keyed semantics. Rather than failing the request or deducing silently,
+// the server names the affected paths in a response header and counts them
+// in a metric, so missing registrations surface during rollout instead of
+// as mysterious ownership behavior later.
+
+// DeducedTypesHeader is the response header listing the field paths of an
+// applied configuration whose merge semantics were deduced rather than
+// registered.
+const DeducedTypesHeader = "X-Kubernetes-Deduced-Types"
+
+// DeducedListPaths returns the sorted field paths in the object holding
+// lists that have no registered merge rule and therefore merge with deduced
+// atomic semantics.
+func DeducedListPaths(obj runtime.Object) ([]string, error) {
+	objMap, err := objectToMap(obj)
+	if err != nil {
+		return nil, err
+	}
+	found := map[string]bool{}
+	collectDeducedListPaths("", objMap, found)
+	paths := make([]string, 0, len(found))
+	for path := range found {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func collectDeducedListPaths(path string, value interface{}, found map[string]bool) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, child := range typed {
+			collectDeducedListPaths(joinFieldPath(path, key), child, found)
+		}
+	case []interface{}:
+		if _, registered := LookupListRuleOK(path); !registered {
+			found[path] = true
+		}
+		// list entries share their list's path, the same convention the
+		// rule registry uses
+		for _, element := range typed {
+			collectDeducedListPaths(path, element, found)
+		}
+	}
+}
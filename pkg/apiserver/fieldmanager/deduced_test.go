@@ -0,0 +1,68 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func TestDeducedListPaths(t *testing.T) {
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			Name: "pod",
+			// finalizers and ownerReferences have registered rules
+			Finalizers: []string{"example.com/cleanup"},
+			OwnerReferences: []api.OwnerReference{
+				{UID: "rs-1", Name: "web", Kind: "ReplicaSet"},
+			},
+		},
+		Spec: api.PodSpec{
+			// containers and their nested lists have no rules
+			Containers: []api.Container{
+				{Name: "web", Image: "nginx", Args: []string{"-g", "daemon off;"}},
+			},
+		},
+	}
+
+	paths, err := DeducedListPaths(pod)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"spec.containers", "spec.containers.args"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected deduced paths %v, got %v", expected, paths)
+	}
+}
+
+func TestDeducedListPathsAllRegistered(t *testing.T) {
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			Name:       "pod",
+			Finalizers: []string{"example.com/cleanup"},
+		},
+	}
+	paths, err := DeducedListPaths(pod)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(paths) != 0 {
+		t.Errorf("expected no deduced paths, got %v", paths)
+	}
+}
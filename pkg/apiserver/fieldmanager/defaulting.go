@@ -0,0 +1,91 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// DefaultsManager is the reserved manager name owning values filled in by
+// defaulters. Attributing those values to the applying manager would make it
+// appear to have written fields it never sent, causing surprising pruning
+// and conflicts on later applies.
+const DefaultsManager = "defaults"
+
+// defaultingManager runs the registered defaulter over applied objects and
+// attributes the values it fills in to the reserved defaults manager instead
+// of the applier.
+type defaultingManager struct {
+	delegate  Manager
+	defaulter func(runtime.Object) error
+}
+
+var _ Manager = &defaultingManager{}
+
+// NewDefaultingAttributionManager wraps a Manager so that applied objects
+// are defaulted with the given function before merging, and any values the
+// defaulter adds are recorded against DefaultsManager rather than the
+// applying manager.
+func NewDefaultingAttributionManager(delegate Manager, defaulter func(runtime.Object) error) Manager {
+	return &defaultingManager{delegate: delegate, defaulter: defaulter}
+}
+
+// Update implements Manager. Regular updates carry the writer's full intent,
+// defaults included, so they pass through unchanged.
+func (d *defaultingManager) Update(liveObj, newObj runtime.Object, managed []api.ManagedFieldsEntry, manager string) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	return d.delegate.Update(liveObj, newObj, managed, manager)
+}
+
+// Apply implements Manager.
+func (d *defaultingManager) Apply(liveObj, appliedObj runtime.Object, managed []api.ManagedFieldsEntry, manager string, force bool) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	defaulted, err := api.Scheme.Copy(appliedObj)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := d.defaulter(defaulted); err != nil {
+		return nil, nil, err
+	}
+	obj, managed, err := d.delegate.Apply(liveObj, defaulted, managed, manager, force)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !api.Semantic.DeepEqual(appliedObj, defaulted) {
+		managed = stampDefaults(managed)
+	}
+	return obj, managed, nil
+}
+
+// stampDefaults refreshes or appends the reserved defaults entry.
+func stampDefaults(managed []api.ManagedFieldsEntry) []api.ManagedFieldsEntry {
+	now := unversioned.NewTime(time.Now())
+	for i := range managed {
+		if managed[i].Manager == DefaultsManager {
+			managed[i].Time = &now
+			return managed
+		}
+	}
+	return append(managed, api.ManagedFieldsEntry{
+		Manager:    DefaultsManager,
+		Operation:  api.ManagedFieldsOperationUpdate,
+		APIVersion: runtime.APIVersionInternal,
+		Time:       &now,
+	})
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+func restartPolicyDefaulter(obj runtime.Object) error {
+	pod, ok := obj.(*api.Pod)
+	if !ok {
+		return nil
+	}
+	if pod.Spec.RestartPolicy == "" {
+		pod.Spec.RestartPolicy = api.RestartPolicyAlways
+	}
+	return nil
+}
+
+func TestDefaultingAttribution(t *testing.T) {
+	manager := NewDefaultingAttributionManager(NewStampManager("v1"), restartPolicyDefaulter)
+	live := &api.Pod{}
+	applied := &api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo"}}
+
+	obj, managed, err := manager.Apply(live, applied, nil, "kubectl", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pod, ok := obj.(*api.Pod); !ok || pod.Spec.RestartPolicy != api.RestartPolicyAlways {
+		t.Errorf("expected the applied object to be defaulted, got %#v", obj)
+	}
+	if applied.Spec.RestartPolicy != "" {
+		t.Errorf("expected the applier's object to be left untouched")
+	}
+	var owners []string
+	for _, entry := range managed {
+		owners = append(owners, entry.Manager)
+	}
+	if len(managed) != 2 || managed[0].Manager != "kubectl" || managed[1].Manager != DefaultsManager {
+		t.Fatalf("expected the defaulted values to be owned by %q, got owners %v", DefaultsManager, owners)
+	}
+
+	// a second apply refreshes both entries rather than duplicating them
+	_, managed, err = manager.Apply(live, applied, managed, "kubectl", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(managed) != 2 {
+		t.Errorf("expected re-apply to be stable, got %#v", managed)
+	}
+}
+
+func TestDefaultingAttributionNoChange(t *testing.T) {
+	manager := NewDefaultingAttributionManager(NewStampManager("v1"), restartPolicyDefaulter)
+	applied := &api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: "foo"},
+		Spec:       api.PodSpec{RestartPolicy: api.RestartPolicyNever},
+	}
+	_, managed, err := manager.Apply(&api.Pod{}, applied, nil, "kubectl", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, entry := range managed {
+		if entry.Manager == DefaultsManager {
+			t.Errorf("expected no defaults entry when the defaulter changed nothing, got %#v", managed)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Aggregated apiservers that embed the apply handler get atomic-by-default
+// merge semantics for every list unless they populate the rule registry,
+// and they have no generated registrations to lean on. Their types already
+// declare merge semantics in the same strategic-merge patch tags the core
+// types use, so the rules can be derived from the types directly instead of
+// introspecting served OpenAPI documents: patchStrategy=merge with a
+// patchMergeKey is a keyed list, patchStrategy=merge without one is a set,
+// and everything else stays atomic.
+
+// DeriveListRules walks the struct type of the given object and returns the
+// list rules its strategic-merge patch tags declare, keyed by field path.
+// basePath is the json path prefix of the object, e.g. "" for a top-level
+// resource whose fields start at spec.
+func DeriveListRules(obj interface{}, basePath string) (map[string]ListRule, error) {
+	t := reflect.TypeOf(obj)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct, got %v", t)
+	}
+	rules := map[string]ListRule{}
+	deriveStruct(t, basePath, rules, map[reflect.Type]bool{})
+	return rules, nil
+}
+
+// RegisterDerivedListRules derives the rules of the given object's type and
+// registers them, so an aggregated apiserver can declare its types' merge
+// semantics with one call per resource at startup.
+func RegisterDerivedListRules(obj interface{}, basePath string) error {
+	rules, err := DeriveListRules(obj, basePath)
+	if err != nil {
+		return err
+	}
+	// register in sorted order so a conflict surfaces deterministically
+	paths := make([]string, 0, len(rules))
+	for path := range rules {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if err := RegisterListRule(path, rules[path]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deriveStruct(t reflect.Type, path string, rules map[string]ListRule, visited map[reflect.Type]bool) {
+	if visited[t] {
+		return
+	}
+	visited[t] = true
+	defer delete(visited, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldPath := joinFieldPath(path, jsonName(field))
+		if field.Type.Kind() == reflect.Slice {
+			if rule, ok := deriveListRule(field); ok {
+				rules[fieldPath] = rule
+			}
+			elem := field.Type.Elem()
+			for elem.Kind() == reflect.Ptr {
+				elem = elem.Elem()
+			}
+			if elem.Kind() == reflect.Struct {
+				deriveStruct(elem, fieldPath, rules, visited)
+			}
+			continue
+		}
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct {
+			if field.Anonymous {
+				fieldPath = path
+			}
+			deriveStruct(fieldType, fieldPath, rules, visited)
+		}
+	}
+}
+
+// deriveListRule translates one slice field's patch tags into a rule. Fields
+// without a merge patchStrategy derive nothing: atomic is already the
+// default, and an explicit registration would only turn future tag changes
+// into conflicts.
+func deriveListRule(field reflect.StructField) (ListRule, bool) {
+	if !strings.Contains(field.Tag.Get("patchStrategy"), "merge") {
+		return ListRule{}, false
+	}
+	if mergeKey := field.Tag.Get("patchMergeKey"); len(mergeKey) > 0 {
+		return ListRule{Type: ListTypeMap, Keys: []string{mergeKey}}, true
+	}
+	return ListRule{Type: ListTypeSet}, true
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"reflect"
+	"testing"
+)
+
+type derivedSpec struct {
+	Containers []derivedContainer `json:"containers" patchStrategy:"merge" patchMergeKey:"name"`
+	Finalizers []string           `json:"finalizers,omitempty" patchStrategy:"merge"`
+	Args       []string           `json:"args,omitempty"`
+	Linked     *derivedSpec       `json:"linked,omitempty"`
+}
+
+type derivedContainer struct {
+	Name  string        `json:"name"`
+	Ports []derivedPort `json:"ports,omitempty" patchStrategy:"merge" patchMergeKey:"containerPort"`
+}
+
+type derivedPort struct {
+	ContainerPort int `json:"containerPort"`
+}
+
+type derivedResource struct {
+	derivedMeta `json:",inline"`
+	Spec        derivedSpec `json:"spec,omitempty"`
+}
+
+type derivedMeta struct {
+	Owners []string `json:"owners,omitempty" patchStrategy:"merge"`
+}
+
+func TestDeriveListRules(t *testing.T) {
+	rules, err := DeriveListRules(&derivedResource{}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]ListRule{
+		"owners":                {Type: ListTypeSet},
+		"spec.containers":       {Type: ListTypeMap, Keys: []string{"name"}},
+		"spec.containers.ports": {Type: ListTypeMap, Keys: []string{"containerPort"}},
+		"spec.finalizers":       {Type: ListTypeSet},
+	}
+	if !reflect.DeepEqual(expected, rules) {
+		t.Errorf("expected rules %v, got %v", expected, rules)
+	}
+}
+
+func TestDeriveListRulesRejectsNonStructs(t *testing.T) {
+	if _, err := DeriveListRules("not a struct", ""); err == nil {
+		t.Errorf("expected an error for a non-struct argument")
+	}
+}
+
+func TestRegisterDerivedListRules(t *testing.T) {
+	if err := RegisterDerivedListRules(&derivedResource{}, "derivetest"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rule, ok := LookupListRuleOK("derivetest.spec.containers")
+	if !ok {
+		t.Fatalf("expected the derived rule to be registered")
+	}
+	if rule.Type != ListTypeMap || !reflect.DeepEqual(rule.Keys, []string{"name"}) {
+		t.Errorf("unexpected registered rule %v", rule)
+	}
+	// deriving the same type again registers identical rules, which is fine
+	if err := RegisterDerivedListRules(&derivedResource{}, "derivetest"); err != nil {
+		t.Errorf("expected identical re-registration to succeed, got %v", err)
+	}
+	// but a derived rule that disagrees with an existing registration errors
+	if err := RegisterListRule("conflicttest.spec.containers", ListRule{Type: ListTypeSet}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := RegisterDerivedListRules(&derivedResource{}, "conflicttest"); err == nil {
+		t.Errorf("expected a conflicting derived rule to report an error")
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// ApplySummaryHeader is both the request header a client sets to opt into a
+// diff summary and the response header carrying the summary. The summary
+// lets CI tools assert that an apply was a no-op without downloading and
+// diffing the whole object.
+const ApplySummaryHeader = "X-Kubernetes-Apply-Summary"
+
+// DiffSummary is a compact description of how a write changed an object.
+type DiffSummary struct {
+	// Added is the number of fields present after the write that were not
+	// present before.
+	Added int
+	// Changed is the number of fields whose value was altered.
+	Changed int
+	// Removed is the number of fields present before the write that are
+	// gone after it.
+	Removed int
+	// Transfers is the number of managers that no longer own any fields
+	// after the write.
+	Transfers int
+}
+
+// IsNoop returns true if the write did not change the object.
+func (s DiffSummary) IsNoop() bool {
+	return s == DiffSummary{}
+}
+
+// String formats the summary the way it is transported in the response
+// header.
+func (s DiffSummary) String() string {
+	return fmt.Sprintf("added=%d,changed=%d,removed=%d,transfers=%d", s.Added, s.Changed, s.Removed, s.Transfers)
+}
+
+// SummarizeDiff compares the object before and after a write and counts the
+// fields that were added, changed and removed, along with the managers that
+// lost ownership. Fields maintained by the server itself (resourceVersion,
+// generation and the managedFields) are not counted.
+func SummarizeDiff(original, patched runtime.Object) (DiffSummary, error) {
+	summary := DiffSummary{}
+
+	originalMap, err := toComparableMap(original)
+	if err != nil {
+		return summary, err
+	}
+	patchedMap, err := toComparableMap(patched)
+	if err != nil {
+		return summary, err
+	}
+	diffMaps(originalMap, patchedMap, &summary)
+
+	originalManagers, err := managerNames(original)
+	if err != nil {
+		return summary, err
+	}
+	patchedManagers, err := managerNames(patched)
+	if err != nil {
+		return summary, err
+	}
+	for manager := range originalManagers {
+		if !patchedManagers[manager] {
+			summary.Transfers++
+		}
+	}
+	return summary, nil
+}
+
+// toComparableMap converts the object to a generic map with the fields that
+// change on every write removed.
+func toComparableMap(obj runtime.Object) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	if metadata, ok := result["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "resourceVersion")
+		delete(metadata, "generation")
+		delete(metadata, "managedFields")
+	}
+	return result, nil
+}
+
+// diffMaps recursively compares two maps, counting each leaf field that
+// differs. Lists and scalars are leaves; maps are recursed into.
+func diffMaps(original, patched map[string]interface{}, summary *DiffSummary) {
+	for key, originalValue := range original {
+		patchedValue, ok := patched[key]
+		if !ok {
+			summary.Removed++
+			continue
+		}
+		originalMap, originalIsMap := originalValue.(map[string]interface{})
+		patchedMap, patchedIsMap := patchedValue.(map[string]interface{})
+		if originalIsMap && patchedIsMap {
+			diffMaps(originalMap, patchedMap, summary)
+			continue
+		}
+		if !reflect.DeepEqual(originalValue, patchedValue) {
+			summary.Changed++
+		}
+	}
+	for key := range patched {
+		if _, ok := original[key]; !ok {
+			summary.Added++
+		}
+	}
+}
+
+func managerNames(obj runtime.Object) (map[string]bool, error) {
+	objectMeta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		return nil, err
+	}
+	managers := map[string]bool{}
+	for _, entry := range objectMeta.ManagedFields {
+		managers[entry.Manager] = true
+	}
+	return managers, nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func TestSummarizeDiffNoop(t *testing.T) {
+	original := podWithManagedFields(validEntry("a", time.Hour))
+	patched := podWithManagedFields(validEntry("a", time.Hour))
+	// Fields maintained by the server should not count as changes.
+	patched.ResourceVersion = "2"
+	patched.Generation = 7
+
+	summary, err := SummarizeDiff(original, patched)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !summary.IsNoop() {
+		t.Errorf("expected a no-op summary, got %s", summary)
+	}
+}
+
+func TestSummarizeDiffCounts(t *testing.T) {
+	original := podWithManagedFields(validEntry("a", time.Hour), validEntry("b", time.Hour))
+	original.Labels = map[string]string{"app": "web", "tier": "frontend"}
+	patched := podWithManagedFields(validEntry("a", time.Hour))
+	patched.Labels = map[string]string{"app": "db", "zone": "east"}
+
+	summary, err := SummarizeDiff(original, patched)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Added != 1 {
+		t.Errorf("expected 1 added field, got %d", summary.Added)
+	}
+	if summary.Changed != 1 {
+		t.Errorf("expected 1 changed field, got %d", summary.Changed)
+	}
+	if summary.Removed != 1 {
+		t.Errorf("expected 1 removed field, got %d", summary.Removed)
+	}
+	if summary.Transfers != 1 {
+		t.Errorf("expected 1 ownership transfer, got %d", summary.Transfers)
+	}
+	if expected := "added=1,changed=1,removed=1,transfers=1"; summary.String() != expected {
+		t.Errorf("expected %q, got %q", expected, summary.String())
+	}
+}
+
+func TestSummarizeDiffIgnoresManagedFields(t *testing.T) {
+	original := podWithManagedFields(validEntry("a", time.Hour))
+	entry := validEntry("a", time.Minute)
+	entry.FieldsV1 = &api.FieldsV1{Raw: []byte(`{"f:spec":{}}`)}
+	patched := podWithManagedFields(entry)
+
+	summary, err := SummarizeDiff(original, patched)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !summary.IsNoop() {
+		t.Errorf("expected managedFields changes to be ignored, got %s", summary)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"sync"
+)
+
+// During incident response, or for namespaces with pathologically large
+// objects, administrators may need to switch off field management for a
+// tenant without restarting the apiserver or affecting the rest of the
+// cluster. Writes in a disabled namespace leave managedFields untouched.
+
+var (
+	disabledNamespacesLock sync.RWMutex
+	disabledNamespaces     = map[string]bool{}
+)
+
+// DisableNamespace switches off field management for the given namespace.
+func DisableNamespace(namespace string) {
+	disabledNamespacesLock.Lock()
+	defer disabledNamespacesLock.Unlock()
+	disabledNamespaces[namespace] = true
+}
+
+// EnableNamespace switches field management back on for the given
+// namespace.
+func EnableNamespace(namespace string) {
+	disabledNamespacesLock.Lock()
+	defer disabledNamespacesLock.Unlock()
+	delete(disabledNamespaces, namespace)
+}
+
+// SetDisabledNamespaces replaces the disabled set wholesale, for wiring the
+// switch to apiserver configuration.
+func SetDisabledNamespaces(namespaces []string) {
+	disabledNamespacesLock.Lock()
+	defer disabledNamespacesLock.Unlock()
+	disabledNamespaces = map[string]bool{}
+	for _, namespace := range namespaces {
+		disabledNamespaces[namespace] = true
+	}
+}
+
+// NamespaceDisabled returns true when field management is switched off for
+// the given namespace.
+func NamespaceDisabled(namespace string) bool {
+	disabledNamespacesLock.RLock()
+	defer disabledNamespacesLock.RUnlock()
+	return disabledNamespaces[namespace]
+}
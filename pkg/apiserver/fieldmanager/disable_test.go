@@ -0,0 +1,50 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+)
+
+func TestNamespaceDisable(t *testing.T) {
+	defer SetDisabledNamespaces(nil)
+
+	if NamespaceDisabled("tenant-a") {
+		t.Fatalf("expected namespaces to start enabled")
+	}
+
+	DisableNamespace("tenant-a")
+	if !NamespaceDisabled("tenant-a") {
+		t.Errorf("expected tenant-a to be disabled")
+	}
+	if NamespaceDisabled("tenant-b") {
+		t.Errorf("expected other namespaces to be unaffected")
+	}
+
+	EnableNamespace("tenant-a")
+	if NamespaceDisabled("tenant-a") {
+		t.Errorf("expected tenant-a to be enabled again")
+	}
+
+	SetDisabledNamespaces([]string{"tenant-b", "tenant-c"})
+	if !NamespaceDisabled("tenant-b") || !NamespaceDisabled("tenant-c") {
+		t.Errorf("expected the configured set to be disabled")
+	}
+	if NamespaceDisabled("tenant-a") {
+		t.Errorf("expected the configured set to replace earlier state")
+	}
+}
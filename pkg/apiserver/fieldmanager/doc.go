@@ -0,0 +1,43 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fieldmanager maintains metadata.managedFields: which manager owns
+// which fields of an object, recorded on every write and consulted on
+// apply.
+//
+// The package is deliberately importable by servers built outside this
+// repository. Its stable surface is:
+//
+//   - the Manager interface and the decorator constructors (NewStampManager,
+//     NewTimingManager, NewGuardrailManager, NewStrictTakeoverManager,
+//     NewTransformManager, NewDefaultingAttributionManager), which stack
+//     into per-resource chains;
+//   - NewDefaultManager, the chain installed by the generic registry when a
+//     resource does not configure its own;
+//   - ApplyHandler and ApplyStore, the embeddable apply endpoint for
+//     servers with bespoke storage;
+//   - the schema registries (RegisterListRule, RegisterMapRule,
+//     RegisterReplaceRule and their lookups), which declare merge semantics
+//     by field path.
+//
+// The testing subpackage provides harnesses (TestFieldManager, TestServer),
+// conformance checks, and invariants for exercising a Manager without an
+// apiserver.
+//
+// Everything else in the package — the maintenance helpers around entry
+// expiry, capping, migration, and cleanup — serves this server's own
+// operational needs and may change between releases.
+package fieldmanager
@@ -0,0 +1,52 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// TimestampOnlyChange returns true when newObj differs from liveObj at most
+// in the timestamps of its managedFields entries. Heartbeat-style writers
+// touch the same fields with the same values over and over; persisting those
+// updates would create an etcd revision and a watch event whose only content
+// is a bumped managedFields time. Callers can return the live object from
+// their update instead, eliding the write.
+func TimestampOnlyChange(liveObj, newObj runtime.Object) (bool, error) {
+	liveCopy, err := api.Scheme.Copy(liveObj)
+	if err != nil {
+		return false, err
+	}
+	newCopy, err := api.Scheme.Copy(newObj)
+	if err != nil {
+		return false, err
+	}
+	clearManagedFieldsTimes(liveCopy)
+	clearManagedFieldsTimes(newCopy)
+	return api.Semantic.DeepEqual(liveCopy, newCopy), nil
+}
+
+func clearManagedFieldsTimes(obj runtime.Object) {
+	objectMeta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		return
+	}
+	for i := range objectMeta.ManagedFields {
+		objectMeta.ManagedFields[i].Time = nil
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+)
+
+func podManagedAt(stamp time.Time) *api.Pod {
+	entryTime := unversioned.NewTime(stamp)
+	return &api.Pod{ObjectMeta: api.ObjectMeta{
+		Name: "foo",
+		ManagedFields: []api.ManagedFieldsEntry{{
+			Manager:    "heartbeat-controller",
+			Operation:  api.ManagedFieldsOperationUpdate,
+			APIVersion: "v1",
+			Time:       &entryTime,
+		}},
+	}}
+}
+
+func TestTimestampOnlyChange(t *testing.T) {
+	now := time.Now()
+	live := podManagedAt(now.Add(-time.Minute))
+
+	// only the entry timestamp moved
+	updated := podManagedAt(now)
+	timestampOnly, err := TimestampOnlyChange(live, updated)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !timestampOnly {
+		t.Errorf("expected a timestamp-only change to be detected")
+	}
+
+	// a real change alongside the timestamp bump
+	changed := podManagedAt(now)
+	changed.Labels = map[string]string{"touched": "true"}
+	timestampOnly, err = TimestampOnlyChange(live, changed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if timestampOnly {
+		t.Errorf("expected a label change to be preserved")
+	}
+
+	// the inputs are not mutated
+	if live.ManagedFields[0].Time == nil || updated.ManagedFields[0].Time == nil {
+		t.Errorf("expected the compared objects to keep their timestamps")
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"k8s.io/kubernetes/pkg/api/unversioned"
+)
+
+// Field management is safest to roll out one resource at a time: enable it
+// for deployments, watch for trouble, and keep high-churn resources like
+// events and endpoints excluded until the cost is understood. These rules
+// refine the cluster-wide switch per resource or per group; a write to a
+// resource whose rule disables management leaves managedFields untouched.
+
+var (
+	resourceRulesLock sync.RWMutex
+	// resourceRules maps a selector to whether field management is enabled
+	// for the resources it matches. Selectors are "resource.group" for one
+	// resource, "*.group" for a whole group, and "*" for everything;
+	// resources in the core group use "resource" and "*." respectively.
+	resourceRules = map[string]bool{}
+)
+
+// SetResourceRule declares whether field management is enabled for the
+// resources the selector matches. The selector is "resource.group" for a
+// single resource, "*.group" for every resource in a group, or "*" for
+// every resource; core-group selectors omit the group suffix.
+func SetResourceRule(selector string, enabled bool) error {
+	if len(selector) == 0 {
+		return fmt.Errorf("resource rule selector must not be empty")
+	}
+	resourceRulesLock.Lock()
+	defer resourceRulesLock.Unlock()
+	resourceRules[selector] = enabled
+	return nil
+}
+
+// SetResourceRules replaces the rules wholesale, for wiring the switch to
+// apiserver configuration. Each rule is "selector=true" or
+// "selector=false".
+func SetResourceRules(rules []string) error {
+	parsed := map[string]bool{}
+	for _, rule := range rules {
+		parts := strings.SplitN(rule, "=", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 || (parts[1] != "true" && parts[1] != "false") {
+			return fmt.Errorf("resource rule %q is not of the form selector=true|false", rule)
+		}
+		parsed[parts[0]] = parts[1] == "true"
+	}
+	resourceRulesLock.Lock()
+	defer resourceRulesLock.Unlock()
+	resourceRules = parsed
+	return nil
+}
+
+// ResourceEnabled returns whether field management is enabled for the given
+// resource. The most specific rule wins: an exact resource rule beats a
+// group rule, which beats the "*" rule; with no matching rule, management
+// is enabled.
+func ResourceEnabled(resource unversioned.GroupResource) bool {
+	resourceRulesLock.RLock()
+	defer resourceRulesLock.RUnlock()
+	if enabled, ok := resourceRules[resource.String()]; ok {
+		return enabled
+	}
+	if enabled, ok := resourceRules[groupSelector(resource.Group)]; ok {
+		return enabled
+	}
+	if enabled, ok := resourceRules["*"]; ok {
+		return enabled
+	}
+	return true
+}
+
+func groupSelector(group string) string {
+	if len(group) == 0 {
+		return "*."
+	}
+	return "*." + group
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api/unversioned"
+)
+
+func TestResourceEnabled(t *testing.T) {
+	defer SetResourceRules(nil)
+	if err := SetResourceRules([]string{
+		"*=false",
+		"*.extensions=true",
+		"replicasets.extensions=false",
+		"events=false",
+		"*.=true",
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		resource unversioned.GroupResource
+		expected bool
+	}{
+		// exact rules beat group rules
+		{unversioned.GroupResource{Group: "extensions", Resource: "replicasets"}, false},
+		// group rules beat the catch-all
+		{unversioned.GroupResource{Group: "extensions", Resource: "deployments"}, true},
+		{unversioned.GroupResource{Group: "batch", Resource: "jobs"}, false},
+		// core-group selectors omit the group
+		{unversioned.GroupResource{Resource: "events"}, false},
+		{unversioned.GroupResource{Resource: "pods"}, true},
+	}
+	for _, test := range tests {
+		if enabled := ResourceEnabled(test.resource); enabled != test.expected {
+			t.Errorf("ResourceEnabled(%v): expected %v, got %v", test.resource, test.expected, enabled)
+		}
+	}
+}
+
+func TestResourceEnabledDefaults(t *testing.T) {
+	defer SetResourceRules(nil)
+	if err := SetResourceRules(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ResourceEnabled(unversioned.GroupResource{Resource: "pods"}) {
+		t.Errorf("expected resources without rules to be enabled")
+	}
+
+	if err := SetResourceRule("pods", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ResourceEnabled(unversioned.GroupResource{Resource: "pods"}) {
+		t.Errorf("expected the single rule to apply")
+	}
+}
+
+func TestSetResourceRulesRejectsMalformedRules(t *testing.T) {
+	for _, rule := range []string{"pods", "pods=", "=true", "pods=yes"} {
+		if err := SetResourceRules([]string{rule}); err == nil {
+			t.Errorf("expected rule %q to be rejected", rule)
+		}
+	}
+	if err := SetResourceRule("", true); err == nil {
+		t.Errorf("expected an empty selector to be rejected")
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+// TombstoneManager is the manager name under which expired Update entries are
+// coalesced. The fields stay owned (a later apply can still take them over or
+// detect conflicts against them), but the identity of the long-gone writer is
+// dropped.
+const TombstoneManager = "ancient-changes"
+
+// ExpireStaleEntries merges Update-operation entries whose manager has not
+// written for longer than maxAge into a single tombstone entry per API
+// version. One-off tools otherwise leave a permanent entry on every object
+// they ever touched. Apply-operation entries represent declared intent and
+// never expire; entries without a timestamp are kept as well. The returned
+// bool reports whether anything changed.
+func ExpireStaleEntries(managed []api.ManagedFieldsEntry, maxAge time.Duration, now time.Time) ([]api.ManagedFieldsEntry, bool, error) {
+	out := make([]api.ManagedFieldsEntry, 0, len(managed))
+	// tombstones maps an API version to the index of its tombstone entry in
+	// out. Field sets recorded at different versions cannot be merged because
+	// they cannot be converted.
+	tombstones := map[string]int{}
+	changed := false
+
+	for _, entry := range managed {
+		if !stale(entry, maxAge, now) {
+			if entry.Manager == TombstoneManager && entry.Operation == api.ManagedFieldsOperationUpdate {
+				// merge newly expired entries into the existing tombstone
+				if _, ok := tombstones[entry.APIVersion]; !ok {
+					tombstones[entry.APIVersion] = len(out)
+				}
+			}
+			out = append(out, entry)
+			continue
+		}
+		changed = true
+		i, ok := tombstones[entry.APIVersion]
+		if !ok {
+			tombstone := entry
+			tombstone.Manager = TombstoneManager
+			tombstones[entry.APIVersion] = len(out)
+			out = append(out, tombstone)
+			continue
+		}
+		combined, err := mergeFieldsV1(out[i].FieldsV1, entry.FieldsV1)
+		if err != nil {
+			return nil, false, err
+		}
+		out[i].FieldsV1 = combined
+		if entry.Time != nil && (out[i].Time == nil || entry.Time.After(out[i].Time.Time)) {
+			out[i].Time = entry.Time
+		}
+	}
+	return out, changed, nil
+}
+
+func stale(entry api.ManagedFieldsEntry, maxAge time.Duration, now time.Time) bool {
+	if entry.Operation != api.ManagedFieldsOperationUpdate {
+		return false
+	}
+	if entry.Manager == TombstoneManager {
+		// an existing tombstone stays a tombstone; without this, expiring it
+		// again would be reported as a change on every pass
+		return false
+	}
+	if entry.Time == nil {
+		return false
+	}
+	return now.Sub(entry.Time.Time) > maxAge
+}
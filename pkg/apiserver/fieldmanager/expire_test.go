@@ -0,0 +1,136 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+)
+
+func entryAged(manager string, operation api.ManagedFieldsOperationType, age time.Duration, now time.Time, fields string) api.ManagedFieldsEntry {
+	stamp := unversioned.NewTime(now.Add(-age))
+	entry := api.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  operation,
+		APIVersion: "v1",
+		Time:       &stamp,
+	}
+	if len(fields) > 0 {
+		entry.FieldsType = "FieldsV1"
+		entry.FieldsV1 = &api.FieldsV1{Raw: []byte(fields)}
+	}
+	return entry
+}
+
+func TestExpireStaleEntries(t *testing.T) {
+	now := time.Now()
+	maxAge := 7 * 24 * time.Hour
+
+	managed := []api.ManagedFieldsEntry{
+		entryAged("one-off-tool", api.ManagedFieldsOperationUpdate, 30*24*time.Hour, now, `{"f:metadata":{"f:labels":{}}}`),
+		entryAged("another-tool", api.ManagedFieldsOperationUpdate, 14*24*time.Hour, now, `{"f:spec":{"f:replicas":{}}}`),
+		entryAged("active-controller", api.ManagedFieldsOperationUpdate, time.Hour, now, `{"f:status":{}}`),
+		entryAged("kubectl", api.ManagedFieldsOperationApply, 60*24*time.Hour, now, `{"f:spec":{"f:paused":{}}}`),
+	}
+
+	out, changed, err := ExpireStaleEntries(managed, maxAge, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !changed {
+		t.Fatalf("expected the entries to change")
+	}
+	if len(out) != 3 {
+		t.Fatalf("expected the two stale entries to collapse into a tombstone, got %#v", out)
+	}
+
+	tombstone := out[0]
+	if tombstone.Manager != TombstoneManager {
+		t.Errorf("unexpected tombstone manager: %q", tombstone.Manager)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(tombstone.FieldsV1.Raw, &fields); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]interface{}{
+		"f:metadata": map[string]interface{}{"f:labels": map[string]interface{}{}},
+		"f:spec":     map[string]interface{}{"f:replicas": map[string]interface{}{}},
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("unexpected tombstone fields:\n%#v\n%#v", fields, expected)
+	}
+	// the tombstone carries the newest merged timestamp
+	if age := now.Sub(tombstone.Time.Time); age != 14*24*time.Hour {
+		t.Errorf("unexpected tombstone age: %v", age)
+	}
+
+	// the fresh Update entry and the Apply entry survive untouched
+	if out[1].Manager != "active-controller" || out[2].Manager != "kubectl" {
+		t.Errorf("unexpected surviving entries: %#v", out)
+	}
+
+	// a second pass is a no-op
+	if _, changed, err := ExpireStaleEntries(out, maxAge, now); err != nil || changed {
+		t.Errorf("expected a second pass to be a no-op, got changed=%v, err=%v", changed, err)
+	}
+}
+
+func TestExpireStaleEntriesMergesIntoExistingTombstone(t *testing.T) {
+	now := time.Now()
+	maxAge := 7 * 24 * time.Hour
+
+	managed := []api.ManagedFieldsEntry{
+		entryAged(TombstoneManager, api.ManagedFieldsOperationUpdate, 90*24*time.Hour, now, `{"f:metadata":{}}`),
+		entryAged("one-off-tool", api.ManagedFieldsOperationUpdate, 30*24*time.Hour, now, `{"f:spec":{}}`),
+	}
+
+	out, changed, err := ExpireStaleEntries(managed, maxAge, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !changed {
+		t.Fatalf("expected the entries to change")
+	}
+	if len(out) != 1 {
+		t.Fatalf("expected a single tombstone, got %#v", out)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(out[0].FieldsV1.Raw, &fields); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fields) != 2 {
+		t.Errorf("expected the tombstone to absorb the expired fields, got %#v", fields)
+	}
+}
+
+func TestExpireStaleEntriesKeepsUntimedEntries(t *testing.T) {
+	managed := []api.ManagedFieldsEntry{
+		{Manager: "legacy", Operation: api.ManagedFieldsOperationUpdate, APIVersion: "v1"},
+	}
+	out, changed, err := ExpireStaleEntries(managed, time.Hour, time.Now())
+	if err != nil || changed {
+		t.Fatalf("expected a no-op, got changed=%v, err=%v", changed, err)
+	}
+	if !reflect.DeepEqual(out, managed) {
+		t.Errorf("unexpected entries: %#v", out)
+	}
+}
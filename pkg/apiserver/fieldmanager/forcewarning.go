@@ -0,0 +1,106 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// force=true rewrites ownership history: fields move from their previous
+// managers to the applier with nothing in the response to show it. That is
+// fine for a human retrying an apply by hand, but automated force usage
+// silently accumulates ownership and the transfer leaves no trace. A forced
+// apply that actually took fields therefore carries a Warning header naming
+// the managers that lost them, and records the transfer as an annotation on
+// the object so it survives in the audit trail.
+
+// WarningHeader is the response header forced takeovers are reported on.
+const WarningHeader = "Warning"
+
+// forcedTakeoverAnnotation records the most recent forced ownership
+// transfer on the object.
+const forcedTakeoverAnnotation = "managed-fields.alpha.kubernetes.io/last-forced-takeover"
+
+// DispossessedManagers returns the managers, other than the applier, that
+// owned fields before the apply and no longer own all of them after it:
+// their entry disappeared or its field set shrank. The result is sorted.
+func DispossessedManagers(before, after []api.ManagedFieldsEntry, manager string) []string {
+	var losers []string
+	seen := map[string]bool{}
+	for _, entry := range before {
+		if entry.Manager == manager || seen[entry.Manager] {
+			continue
+		}
+		owned, err := entryLeafPaths(entry)
+		if err != nil || len(owned) == 0 {
+			continue
+		}
+		if lostFields(entry, after, owned) {
+			seen[entry.Manager] = true
+			losers = append(losers, entry.Manager)
+		}
+	}
+	sort.Strings(losers)
+	return losers
+}
+
+// lostFields reports whether the entry's surviving counterpart, if any,
+// still owns every path the entry owned.
+func lostFields(entry api.ManagedFieldsEntry, after []api.ManagedFieldsEntry, owned map[string]bool) bool {
+	for _, survivor := range after {
+		if !SameIdentity(survivor, entry) {
+			continue
+		}
+		remaining, err := entryLeafPaths(survivor)
+		if err != nil {
+			return true
+		}
+		for path := range owned {
+			if !remaining[path] {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// ForcedTakeoverWarning formats the Warning header value for a forced
+// transfer, in the 299 miscellaneous-warning form.
+func ForcedTakeoverWarning(manager string, losers []string) string {
+	return fmt.Sprintf("299 - %q", fmt.Sprintf("apply by %q forcibly took fields owned by %s", manager, strings.Join(losers, ", ")))
+}
+
+// RecordForcedTakeover annotates the object with the transfer so the audit
+// trail shows who took fields from whom, and when.
+func RecordForcedTakeover(obj runtime.Object, manager string, losers []string, now time.Time) error {
+	meta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		return err
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[forcedTakeoverAnnotation] = fmt.Sprintf("%s took fields owned by %s at %s", manager, strings.Join(losers, ", "), now.UTC().Format(time.RFC3339))
+	return nil
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func TestDispossessedManagers(t *testing.T) {
+	gone := validEntry("old-tool", 0)
+	kept := validEntry("bystander", 0)
+	shrunk := validEntry("shrunk-tool", 0)
+	shrunk.FieldsV1 = &api.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{}},"f:spec":{}}`)}
+	shrunkAfter := shrunk
+	shrunkAfter.FieldsV1 = &api.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{}}}`)}
+
+	before := []api.ManagedFieldsEntry{gone, kept, shrunk}
+	after := []api.ManagedFieldsEntry{kept, shrunkAfter, validEntry("deploy-tool", 0)}
+
+	losers := DispossessedManagers(before, after, "deploy-tool")
+	if expected := []string{"old-tool", "shrunk-tool"}; !reflect.DeepEqual(losers, expected) {
+		t.Errorf("expected %v, got %v", expected, losers)
+	}
+
+	if losers := DispossessedManagers(before, before, "deploy-tool"); len(losers) != 0 {
+		t.Errorf("expected no losers when nothing changed, got %v", losers)
+	}
+}
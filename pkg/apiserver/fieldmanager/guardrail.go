@@ -0,0 +1,139 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// A buggy controller that applies entire objects verbatim ends up owning
+// every field, which turns each later write by anybody else into a conflict
+// storm. The ownership guardrail caps how many leaf fields a single entry
+// may own: past a warning threshold the write is logged, past the rejection
+// threshold it fails.
+
+// OwnedFieldsLimits configures the guardrail thresholds. Zero values
+// disable the corresponding check.
+type OwnedFieldsLimits struct {
+	// Warn is the owned leaf-field count past which a warning is emitted.
+	Warn int
+	// Reject is the owned leaf-field count past which the write fails.
+	Reject int
+}
+
+// OwnershipLimitError reports a write refused because an entry would own
+// too many fields.
+type OwnershipLimitError struct {
+	Manager string
+	Owned   int
+	Limit   int
+}
+
+func (e *OwnershipLimitError) Error() string {
+	return fmt.Sprintf("manager %q would own %d fields, more than the configured maximum of %d: a controller applying whole objects verbatim is the usual cause", e.Manager, e.Owned, e.Limit)
+}
+
+// IsOwnershipLimit returns true when the error reports a refused write over
+// the owned-field limit.
+func IsOwnershipLimit(err error) bool {
+	_, ok := err.(*OwnershipLimitError)
+	return ok
+}
+
+// CountLeafFields returns the number of leaf fields recorded in the entry's
+// field set. Entries without a field set own no leaves.
+func CountLeafFields(entry api.ManagedFieldsEntry) (int, error) {
+	if entry.FieldsV1 == nil || len(entry.FieldsV1.Raw) == 0 {
+		return 0, nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+		return 0, fmt.Errorf("failed to parse the field set of manager %q: %v", entry.Manager, err)
+	}
+	return countLeaves(fields), nil
+}
+
+func countLeaves(fields map[string]interface{}) int {
+	count := 0
+	for _, value := range fields {
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			count += countLeaves(nested)
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+type guardrailManager struct {
+	delegate Manager
+	limits   OwnedFieldsLimits
+	warn     func(message string)
+}
+
+var _ Manager = &guardrailManager{}
+
+// NewGuardrailManager wraps a manager so that writes leaving a single entry
+// owning more leaf fields than the limits allow are warned about or
+// rejected. The warn function may be nil.
+func NewGuardrailManager(delegate Manager, limits OwnedFieldsLimits, warn func(message string)) Manager {
+	return &guardrailManager{delegate: delegate, limits: limits, warn: warn}
+}
+
+// Update implements Manager.
+func (g *guardrailManager) Update(liveObj, newObj runtime.Object, managed []api.ManagedFieldsEntry, manager string) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	obj, managed, err := g.delegate.Update(liveObj, newObj, managed, manager)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := g.check(managed); err != nil {
+		return nil, nil, err
+	}
+	return obj, managed, nil
+}
+
+// Apply implements Manager.
+func (g *guardrailManager) Apply(liveObj, appliedObj runtime.Object, managed []api.ManagedFieldsEntry, manager string, force bool) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	obj, managed, err := g.delegate.Apply(liveObj, appliedObj, managed, manager, force)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := g.check(managed); err != nil {
+		return nil, nil, err
+	}
+	return obj, managed, nil
+}
+
+func (g *guardrailManager) check(managed []api.ManagedFieldsEntry) error {
+	for _, entry := range managed {
+		owned, err := CountLeafFields(entry)
+		if err != nil {
+			return err
+		}
+		if g.limits.Reject > 0 && owned > g.limits.Reject {
+			return &OwnershipLimitError{Manager: entry.Manager, Owned: owned, Limit: g.limits.Reject}
+		}
+		if g.limits.Warn > 0 && owned > g.limits.Warn && g.warn != nil {
+			g.warn(fmt.Sprintf("manager %q owns %d fields, more than the warning threshold of %d", entry.Manager, owned, g.limits.Warn))
+		}
+	}
+	return nil
+}
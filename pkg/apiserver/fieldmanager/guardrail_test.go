@@ -0,0 +1,98 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+func entryWithFields(manager string, raw string) api.ManagedFieldsEntry {
+	entry := validEntry(manager, 0)
+	entry.FieldsV1 = &api.FieldsV1{Raw: []byte(raw)}
+	return entry
+}
+
+func TestCountLeafFields(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected int
+	}{
+		{`{}`, 0},
+		{`{"f:metadata":{}}`, 1},
+		{`{"f:metadata":{"f:labels":{"f:app":{},"f:tier":{}}}}`, 2},
+		{`{"f:spec":{"f:replicas":{}},"f:metadata":{"f:name":{}}}`, 2},
+	}
+	for _, test := range tests {
+		count, err := CountLeafFields(entryWithFields("m", test.raw))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.raw, err)
+			continue
+		}
+		if count != test.expected {
+			t.Errorf("%s: expected %d leaves, got %d", test.raw, test.expected, count)
+		}
+	}
+
+	if count, err := CountLeafFields(validEntry("m", 0)); err != nil || count != 1 {
+		t.Errorf("expected the shared fixture entry to own one leaf, got %d, %v", count, err)
+	}
+	if _, err := CountLeafFields(entryWithFields("m", `not json`)); err == nil {
+		t.Errorf("expected an unparseable field set to be an error")
+	}
+}
+
+func TestGuardrailManager(t *testing.T) {
+	var warnings []string
+	manager := NewGuardrailManager(NewStampManager(runtime.APIVersionInternal), OwnedFieldsLimits{Warn: 2, Reject: 3}, func(message string) {
+		warnings = append(warnings, message)
+	})
+
+	// under both thresholds: silent
+	managed := []api.ManagedFieldsEntry{entryWithFields("ok-tool", `{"f:metadata":{"f:name":{}}}`)}
+	if _, _, err := manager.Apply(&api.Pod{}, &api.Pod{}, managed, "ok-tool", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+
+	// over the warning threshold: the write succeeds but is flagged
+	managed = []api.ManagedFieldsEntry{entryWithFields("greedy-tool", `{"f:a":{},"f:b":{},"f:c":{}}`)}
+	if _, _, err := manager.Update(&api.Pod{}, &api.Pod{}, managed, "greedy-tool"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "greedy-tool") {
+		t.Errorf("expected a warning naming the manager, got %v", warnings)
+	}
+
+	// over the rejection threshold: the write fails
+	managed = []api.ManagedFieldsEntry{entryWithFields("verbatim-tool", `{"f:a":{},"f:b":{},"f:c":{},"f:d":{}}`)}
+	_, _, err := manager.Apply(&api.Pod{}, &api.Pod{}, managed, "verbatim-tool", false)
+	if !IsOwnershipLimit(err) {
+		t.Fatalf("expected an ownership limit error, got %v", err)
+	}
+
+	// zero limits disable the guardrail
+	unlimited := NewGuardrailManager(NewStampManager(runtime.APIVersionInternal), OwnedFieldsLimits{}, nil)
+	if _, _, err := unlimited.Apply(&api.Pod{}, &api.Pod{}, managed, "verbatim-tool", false); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
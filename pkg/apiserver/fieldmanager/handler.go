@@ -0,0 +1,248 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/api/unversioned/validation"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/validation/field"
+)
+
+// Custom apiservers with bespoke storage should not have to reimplement the
+// apply endpoint: the query-parameter handling, the manager invocation, the
+// conflict translation, and the response writing are the same everywhere.
+// ApplyHandler is that endpoint as an embeddable building block; only the
+// storage access and the object codec are supplied by the embedder.
+
+// ApplyStore is the minimal storage surface the apply handler needs.
+type ApplyStore interface {
+	// Get returns the live object and its managedFields, or a nil object
+	// when none exists yet.
+	Get(namespace, name string) (runtime.Object, []api.ManagedFieldsEntry, error)
+	// Save persists the merged object together with its managedFields.
+	Save(namespace, name string, obj runtime.Object, managed []api.ManagedFieldsEntry) error
+}
+
+// ApplyHandler serves apply requests against an ApplyStore. All fields are
+// required except Manager, which defaults to the shared default chain.
+type ApplyHandler struct {
+	// Manager is the manager chain run for each apply.
+	Manager Manager
+	// Store provides access to live objects.
+	Store ApplyStore
+	// NewFunc returns an empty object of the resource's kind, used as the
+	// live object for applies that create.
+	NewFunc func() runtime.Object
+	// Decode turns a request body into an object.
+	Decode func(data []byte) (runtime.Object, error)
+	// Encode writes an object as a response body.
+	Encode func(obj runtime.Object, w io.Writer) error
+	// Resource is the resource name, used for conflict redaction.
+	Resource string
+}
+
+// Apply handles one apply request for the named object. The fieldManager
+// and force query parameters are read from the request; conflicts are
+// written as a 409 Status. With dryRun=true the would-be result is
+// computed and returned without being persisted.
+func (h *ApplyHandler) Apply(w http.ResponseWriter, req *http.Request, namespace, name string) {
+	options, err := unversioned.ApplyOptionsFromQuery(req.URL.Query())
+	if err != nil {
+		writeStatus(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errs := validation.ValidateApplyOptions(options, true, field.NewPath("options")); len(errs) > 0 {
+		writeStatus(w, http.StatusBadRequest, errs.ToAggregate().Error())
+		return
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		writeStatus(w, http.StatusInternalServerError, fmt.Sprintf("failed to read the request body: %v", err))
+		return
+	}
+	appliedObj, err := h.Decode(body)
+	if err != nil {
+		writeStatus(w, http.StatusBadRequest, fmt.Sprintf("failed to decode the apply configuration: %v", err))
+		return
+	}
+
+	liveObj, managed, err := h.Store.Get(namespace, name)
+	if err != nil {
+		writeStatus(w, http.StatusInternalServerError, fmt.Sprintf("failed to fetch the live object: %v", err))
+		return
+	}
+	created := liveObj == nil
+	if expected := applyPrecondition(options, appliedObj); len(expected) > 0 {
+		liveVersion := ""
+		if !created {
+			if objectMeta, err := api.ObjectMetaFor(liveObj); err == nil {
+				liveVersion = objectMeta.ResourceVersion
+			}
+		}
+		if liveVersion != expected {
+			writeStatus(w, http.StatusConflict, fmt.Sprintf("the apply is conditional on resourceVersion %q, but the live object is at %q", expected, liveVersion))
+			return
+		}
+	}
+	if created {
+		liveObj = h.NewFunc()
+	}
+
+	var before []api.ManagedFieldsEntry
+	if options.Force {
+		before = append([]api.ManagedFieldsEntry(nil), managed...)
+	}
+	obj, managed, err := h.manager().Apply(liveObj, appliedObj, managed, options.FieldManager, options.Force)
+	if err != nil {
+		if IsTakeoverConflict(err) {
+			writeConflict(w, h.Resource, err)
+			return
+		}
+		if IsBudgetExceeded(err) {
+			writeStatus(w, http.StatusGatewayTimeout, err.Error())
+			return
+		}
+		writeStatus(w, http.StatusInternalServerError, fmt.Sprintf("apply failed: %v", err))
+		return
+	}
+
+	if options.Force {
+		if losers := DispossessedManagers(before, managed, options.FieldManager); len(losers) > 0 {
+			w.Header().Set(WarningHeader, ForcedTakeoverWarning(options.FieldManager, losers))
+			if err := RecordForcedTakeover(obj, options.FieldManager, losers, time.Now()); err != nil {
+				writeStatus(w, http.StatusInternalServerError, fmt.Sprintf("failed to record the forced takeover: %v", err))
+				return
+			}
+		}
+	}
+
+	// A dry-run apply is done once the merge and the conflict detection
+	// have run: the would-be result is returned without persisting
+	// anything, so CI systems can gate on server-verified manifests.
+	if !options.DryRun {
+		now := time.Now()
+		if expired, changed, err := ExpireEphemeralEntries(obj, managed, now); err == nil && changed {
+			managed = expired
+		}
+		if options.TTL.Duration > 0 {
+			if err := RecordEntryExpiry(obj, options.FieldManager, now.Add(options.TTL.Duration)); err != nil {
+				writeStatus(w, http.StatusInternalServerError, fmt.Sprintf("failed to record the ownership TTL: %v", err))
+				return
+			}
+		}
+
+		if err := h.Store.Save(namespace, name, obj, managed); err != nil {
+			writeStatus(w, http.StatusInternalServerError, fmt.Sprintf("failed to persist the merged object: %v", err))
+			return
+		}
+	}
+
+	if deduced, err := DeducedListPaths(appliedObj); err == nil && len(deduced) > 0 {
+		w.Header().Set(DeducedTypesHeader, strings.Join(deduced, ","))
+		for _, path := range deduced {
+			RecordDeducedListType(path)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if created {
+		w.WriteHeader(http.StatusCreated)
+	}
+	if err := h.Encode(obj, w); err != nil {
+		writeStatus(w, http.StatusInternalServerError, fmt.Sprintf("failed to encode the merged object: %v", err))
+	}
+}
+
+// applyPrecondition returns the resource version the apply is conditional
+// on, or the empty string for an unconditional apply. An explicit option
+// wins over a resourceVersion carried in the applied configuration's
+// metadata.
+func applyPrecondition(options *unversioned.ApplyOptions, appliedObj runtime.Object) string {
+	if len(options.ResourceVersion) > 0 {
+		return options.ResourceVersion
+	}
+	if objectMeta, err := api.ObjectMetaFor(appliedObj); err == nil {
+		return objectMeta.ResourceVersion
+	}
+	return ""
+}
+
+func (h *ApplyHandler) manager() Manager {
+	if h.Manager != nil {
+		return h.Manager
+	}
+	return NewDefaultManager()
+}
+
+// writeConflict writes a 409 Status for a refused apply, naming the
+// conflicting managers without leaking field values.
+func writeConflict(w http.ResponseWriter, resource string, err error) {
+	status := unversioned.Status{
+		Status:  unversioned.StatusFailure,
+		Code:    http.StatusConflict,
+		Reason:  unversioned.StatusReasonConflict,
+		Message: err.Error(),
+	}
+	if takeover, ok := err.(*TakeoverConflictError); ok {
+		details := &unversioned.StatusDetails{}
+		for _, orphan := range takeover.Orphans {
+			details.Causes = append(details.Causes, unversioned.StatusCause{
+				Type:    CauseTypeFieldManagerConflict,
+				Message: fmt.Sprintf("fields are owned by %s", orphan),
+			})
+		}
+		status.Details = details
+	}
+	writeStatusObject(w, http.StatusConflict, status)
+}
+
+func writeStatus(w http.ResponseWriter, code int, message string) {
+	reason := unversioned.StatusReasonUnknown
+	switch code {
+	case http.StatusBadRequest:
+		reason = unversioned.StatusReasonBadRequest
+	case http.StatusInternalServerError:
+		reason = unversioned.StatusReasonInternalError
+	case http.StatusConflict:
+		reason = unversioned.StatusReasonConflict
+	case http.StatusGatewayTimeout:
+		reason = unversioned.StatusReasonTimeout
+	}
+	writeStatusObject(w, code, unversioned.Status{
+		Status:  unversioned.StatusFailure,
+		Code:    int32(code),
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+func writeStatusObject(w http.ResponseWriter, code int, status unversioned.Status) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(status)
+}
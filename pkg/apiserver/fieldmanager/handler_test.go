@@ -0,0 +1,267 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+type memoryApplyStore struct {
+	objects map[string]runtime.Object
+	managed map[string][]api.ManagedFieldsEntry
+}
+
+func newMemoryApplyStore() *memoryApplyStore {
+	return &memoryApplyStore{
+		objects: map[string]runtime.Object{},
+		managed: map[string][]api.ManagedFieldsEntry{},
+	}
+}
+
+func (s *memoryApplyStore) Get(namespace, name string) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	key := namespace + "/" + name
+	return s.objects[key], s.managed[key], nil
+}
+
+func (s *memoryApplyStore) Save(namespace, name string, obj runtime.Object, managed []api.ManagedFieldsEntry) error {
+	key := namespace + "/" + name
+	s.objects[key] = obj
+	s.managed[key] = managed
+	return nil
+}
+
+func newTestApplyHandler(store *memoryApplyStore) *ApplyHandler {
+	return &ApplyHandler{
+		Store:   store,
+		NewFunc: func() runtime.Object { return &api.Pod{} },
+		Decode: func(data []byte) (runtime.Object, error) {
+			pod := &api.Pod{}
+			if err := json.Unmarshal(data, pod); err != nil {
+				return nil, err
+			}
+			return pod, nil
+		},
+		Encode: func(obj runtime.Object, w io.Writer) error {
+			return json.NewEncoder(w).Encode(obj)
+		},
+		Resource: "pods",
+	}
+}
+
+func doApply(handler *ApplyHandler, query, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("PATCH", "/apply?"+query, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.Apply(w, req, "ns", "foo")
+	return w
+}
+
+func TestApplyHandlerCreatesAndUpdates(t *testing.T) {
+	store := newMemoryApplyStore()
+	handler := newTestApplyHandler(store)
+
+	w := doApply(handler, "fieldManager=deploy-tool", `{"metadata":{"name":"foo","labels":{"app":"web"}}}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected a create to return 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	obj, managed, err := store.Get("ns", "foo")
+	if err != nil || obj == nil {
+		t.Fatalf("expected the object to be persisted, got %v, %v", obj, err)
+	}
+	if len(managed) != 1 || managed[0].Manager != "deploy-tool" || managed[0].Operation != api.ManagedFieldsOperationApply {
+		t.Errorf("expected an apply entry for the manager, got %v", managed)
+	}
+
+	w = doApply(handler, "fieldManager=deploy-tool", `{"metadata":{"name":"foo","labels":{"app":"web","tier":"frontend"}}}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected a repeat apply to return 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if _, managed, _ := store.Get("ns", "foo"); len(managed) != 1 {
+		t.Errorf("expected the entry to be refreshed, not duplicated, got %v", managed)
+	}
+}
+
+func TestApplyHandlerRequiresFieldManager(t *testing.T) {
+	handler := newTestApplyHandler(newMemoryApplyStore())
+	w := doApply(handler, "", `{}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected a missing fieldManager to return 400, got %d", w.Code)
+	}
+	status := unversioned.Status{}
+	if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil {
+		t.Fatalf("expected a Status body, got %q", w.Body.String())
+	}
+	if status.Reason != unversioned.StatusReasonBadRequest {
+		t.Errorf("expected reason %q, got %q", unversioned.StatusReasonBadRequest, status.Reason)
+	}
+}
+
+func TestApplyHandlerRejectsBadBodies(t *testing.T) {
+	handler := newTestApplyHandler(newMemoryApplyStore())
+	if w := doApply(handler, "fieldManager=deploy-tool", `not json`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected an undecodable body to return 400, got %d", w.Code)
+	}
+}
+
+func TestApplyHandlerTranslatesConflicts(t *testing.T) {
+	store := newMemoryApplyStore()
+	handler := newTestApplyHandler(store)
+	handler.Manager = NewStrictTakeoverManager(NewStampManager(runtime.APIVersionInternal))
+
+	// seed an object whose only ownership record is a tombstone
+	store.Save("ns", "foo", &api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo"}}, []api.ManagedFieldsEntry{
+		validEntry(TombstoneManager, 0),
+	})
+
+	w := doApply(handler, "fieldManager=deploy-tool", `{"metadata":{"name":"foo"}}`)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected a conflict to return 409, got %d: %s", w.Code, w.Body.String())
+	}
+	status := unversioned.Status{}
+	if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil {
+		t.Fatalf("expected a Status body, got %q", w.Body.String())
+	}
+	if status.Reason != unversioned.StatusReasonConflict || status.Details == nil || len(status.Details.Causes) == 0 {
+		t.Errorf("expected conflict details, got %#v", status)
+	}
+
+	w = doApply(handler, "fieldManager=deploy-tool&force=true", `{"metadata":{"name":"foo"}}`)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected force to resolve the conflict, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestApplyHandlerResourceVersionPrecondition(t *testing.T) {
+	store := newMemoryApplyStore()
+	handler := newTestApplyHandler(store)
+	store.Save("ns", "foo", &api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo", ResourceVersion: "5"}}, nil)
+
+	w := doApply(handler, "fieldManager=deploy-tool&resourceVersion=4", `{"metadata":{"name":"foo"}}`)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected a stale precondition to return 409, got %d: %s", w.Code, w.Body.String())
+	}
+	status := unversioned.Status{}
+	if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil {
+		t.Fatalf("expected a Status body, got %q", w.Body.String())
+	}
+	if status.Reason != unversioned.StatusReasonConflict {
+		t.Errorf("expected reason %q, got %q", unversioned.StatusReasonConflict, status.Reason)
+	}
+
+	if w := doApply(handler, "fieldManager=deploy-tool&resourceVersion=5", `{"metadata":{"name":"foo"}}`); w.Code != http.StatusOK {
+		t.Errorf("expected a matching precondition to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// the applied configuration's metadata carries the precondition too
+	if w := doApply(handler, "fieldManager=deploy-tool", `{"metadata":{"name":"foo","resourceVersion":"4"}}`); w.Code != http.StatusConflict {
+		t.Errorf("expected a stale metadata precondition to return 409, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// a conditional apply against a missing object has nothing at the
+	// expected version
+	empty := newTestApplyHandler(newMemoryApplyStore())
+	if w := doApply(empty, "fieldManager=deploy-tool&resourceVersion=5", `{"metadata":{"name":"foo"}}`); w.Code != http.StatusConflict {
+		t.Errorf("expected a precondition against a missing object to return 409, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestApplyHandlerDryRun(t *testing.T) {
+	store := newMemoryApplyStore()
+	handler := newTestApplyHandler(store)
+
+	w := doApply(handler, "fieldManager=deploy-tool&dryRun=true", `{"metadata":{"name":"foo","labels":{"app":"web"}}}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected a dry-run create to return 201, got %d: %s", w.Code, w.Body.String())
+	}
+	pod := api.Pod{}
+	if err := json.Unmarshal(w.Body.Bytes(), &pod); err != nil {
+		t.Fatalf("expected the would-be object in the body, got %q", w.Body.String())
+	}
+	if pod.Labels["app"] != "web" {
+		t.Errorf("expected the merged result, got %v", pod.Labels)
+	}
+	if obj, _, _ := store.Get("ns", "foo"); obj != nil {
+		t.Errorf("expected a dry-run apply not to persist anything, got %v", obj)
+	}
+}
+
+func TestApplyHandlerDryRunDetectsConflicts(t *testing.T) {
+	store := newMemoryApplyStore()
+	handler := newTestApplyHandler(store)
+	handler.Manager = NewStrictTakeoverManager(NewStampManager(runtime.APIVersionInternal))
+
+	store.Save("ns", "foo", &api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo"}}, []api.ManagedFieldsEntry{
+		validEntry(TombstoneManager, 0),
+	})
+	_, before, _ := store.Get("ns", "foo")
+
+	w := doApply(handler, "fieldManager=deploy-tool&dryRun=true", `{"metadata":{"name":"foo"}}`)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected a dry run to report conflicts, got %d: %s", w.Code, w.Body.String())
+	}
+	if _, after, _ := store.Get("ns", "foo"); !reflect.DeepEqual(before, after) {
+		t.Errorf("expected the dry run to leave managedFields untouched, got %v", after)
+	}
+}
+
+func TestApplyHandlerWarnsOnForcedTakeover(t *testing.T) {
+	store := newMemoryApplyStore()
+	handler := newTestApplyHandler(store)
+	handler.Manager = NewStrictTakeoverManager(NewStampManager(runtime.APIVersionInternal))
+
+	store.Save("ns", "foo", &api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo"}}, []api.ManagedFieldsEntry{
+		validEntry(TombstoneManager, 0),
+	})
+
+	w := doApply(handler, "fieldManager=deploy-tool&force=true", `{"metadata":{"name":"foo"}}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected the forced apply to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+	warning := w.Header().Get(WarningHeader)
+	if !strings.Contains(warning, TombstoneManager) {
+		t.Errorf("expected a Warning header naming the dispossessed manager, got %q", warning)
+	}
+	obj, _, _ := store.Get("ns", "foo")
+	meta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(meta.Annotations[forcedTakeoverAnnotation], TombstoneManager) {
+		t.Errorf("expected the transfer to be recorded on the object, got %v", meta.Annotations)
+	}
+}
+
+func TestApplyHandlerNoWarningWithoutTakeover(t *testing.T) {
+	handler := newTestApplyHandler(newMemoryApplyStore())
+	w := doApply(handler, "fieldManager=deploy-tool&force=true", `{"metadata":{"name":"foo"}}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected the apply to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+	if warning := w.Header().Get(WarningHeader); warning != "" {
+		t.Errorf("expected no Warning header when nothing was taken, got %q", warning)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+// External tooling that computes or compares ownership needs to agree with
+// the apiserver byte-for-byte on what identifies an entry and how entries
+// are ordered. These functions are that agreement; internal code and
+// external consumers alike should use them instead of hand-built keys.
+
+// EntryIdentifier returns the canonical identity string of an entry: the
+// quoted manager, operation, and apiVersion joined by slashes, for example
+// "deploy-tool"/"Apply"/"v1". Quoting keeps manager names that contain
+// slashes unambiguous.
+func EntryIdentifier(entry api.ManagedFieldsEntry) string {
+	return fmt.Sprintf("%q/%q/%q", entry.Manager, string(entry.Operation), entry.APIVersion)
+}
+
+// SameIdentity returns true when two entries describe the same acting
+// manager, i.e. the stamp manager would fold them into one entry: they
+// share a manager and operation. The apiVersion is an attribute of the
+// recorded field set, not part of the folding identity.
+func SameIdentity(a, b api.ManagedFieldsEntry) bool {
+	return a.Manager == b.Manager && a.Operation == b.Operation
+}
+
+// SortEntries sorts entries in place into the canonical order: by manager,
+// then operation, then apiVersion, then time with missing and older times
+// first. Two managedFields slices holding the same entries compare equal
+// after sorting.
+func SortEntries(entries []api.ManagedFieldsEntry) {
+	sort.Stable(byCanonicalOrder(entries))
+}
+
+type byCanonicalOrder []api.ManagedFieldsEntry
+
+func (e byCanonicalOrder) Len() int      { return len(e) }
+func (e byCanonicalOrder) Swap(i, j int) { e[i], e[j] = e[j], e[i] }
+func (e byCanonicalOrder) Less(i, j int) bool {
+	if e[i].Manager != e[j].Manager {
+		return e[i].Manager < e[j].Manager
+	}
+	if e[i].Operation != e[j].Operation {
+		return e[i].Operation < e[j].Operation
+	}
+	if e[i].APIVersion != e[j].APIVersion {
+		return e[i].APIVersion < e[j].APIVersion
+	}
+	switch {
+	case e[i].Time == nil:
+		return e[j].Time != nil
+	case e[j].Time == nil:
+		return false
+	default:
+		return e[i].Time.Before(*e[j].Time)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+)
+
+func TestEntryIdentifier(t *testing.T) {
+	tests := []struct {
+		entry    api.ManagedFieldsEntry
+		expected string
+	}{
+		{
+			entry:    api.ManagedFieldsEntry{Manager: "deploy-tool", Operation: api.ManagedFieldsOperationApply, APIVersion: "v1"},
+			expected: `"deploy-tool"/"Apply"/"v1"`,
+		},
+		{
+			entry:    api.ManagedFieldsEntry{Manager: "kubectl", Operation: api.ManagedFieldsOperationUpdate, APIVersion: "__internal"},
+			expected: `"kubectl"/"Update"/"__internal"`,
+		},
+		{
+			// slashes in the manager stay unambiguous under quoting
+			entry:    api.ManagedFieldsEntry{Manager: `a"/b`, Operation: api.ManagedFieldsOperationApply, APIVersion: "v1"},
+			expected: `"a\"/b"/"Apply"/"v1"`,
+		},
+	}
+	for _, test := range tests {
+		if id := EntryIdentifier(test.entry); id != test.expected {
+			t.Errorf("expected identifier %s, got %s", test.expected, id)
+		}
+	}
+}
+
+func TestSameIdentity(t *testing.T) {
+	a := api.ManagedFieldsEntry{Manager: "kubectl", Operation: api.ManagedFieldsOperationApply, APIVersion: "v1"}
+	b := a
+	b.APIVersion = "__internal"
+	if !SameIdentity(a, b) {
+		t.Errorf("expected the apiVersion to not be part of the identity")
+	}
+	b = a
+	b.Operation = api.ManagedFieldsOperationUpdate
+	if SameIdentity(a, b) {
+		t.Errorf("expected a different operation to be a different identity")
+	}
+	b = a
+	b.Manager = "other"
+	if SameIdentity(a, b) {
+		t.Errorf("expected a different manager to be a different identity")
+	}
+}
+
+func TestSortEntries(t *testing.T) {
+	older := unversioned.NewTime(time.Now().Add(-time.Hour))
+	newer := unversioned.NewTime(time.Now())
+	entries := []api.ManagedFieldsEntry{
+		{Manager: "b", Operation: api.ManagedFieldsOperationUpdate},
+		{Manager: "a", Operation: api.ManagedFieldsOperationUpdate, Time: &newer},
+		{Manager: "a", Operation: api.ManagedFieldsOperationUpdate, Time: &older},
+		{Manager: "a", Operation: api.ManagedFieldsOperationApply, APIVersion: "v1"},
+		{Manager: "a", Operation: api.ManagedFieldsOperationApply, APIVersion: "__internal"},
+	}
+	SortEntries(entries)
+	expected := []api.ManagedFieldsEntry{
+		{Manager: "a", Operation: api.ManagedFieldsOperationApply, APIVersion: "__internal"},
+		{Manager: "a", Operation: api.ManagedFieldsOperationApply, APIVersion: "v1"},
+		{Manager: "a", Operation: api.ManagedFieldsOperationUpdate, Time: &older},
+		{Manager: "a", Operation: api.ManagedFieldsOperationUpdate, Time: &newer},
+		{Manager: "b", Operation: api.ManagedFieldsOperationUpdate},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("expected the canonical order %v, got %v", expected, entries)
+	}
+
+	// two slices holding the same entries compare equal after sorting
+	shuffled := []api.ManagedFieldsEntry{expected[3], expected[0], expected[4], expected[2], expected[1]}
+	SortEntries(shuffled)
+	if !reflect.DeepEqual(shuffled, entries) {
+		t.Errorf("expected the order to be independent of the input order, got %v", shuffled)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"github.com/golang/glog"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// Entries recorded at an apiVersion the server can no longer interpret —
+// the version was removed, or its conversion is unsupported — used to fail
+// the whole apply. That punishes today's applier for yesterday's upgrade.
+// The inert version manager instead sets those entries aside: they are kept
+// on the object untouched, excluded from conflict detection, and counted in
+// a metric so operators notice the stale attribution.
+
+type inertVersionManager struct {
+	delegate Manager
+	// recognizes reports whether the server can still convert from the
+	// given apiVersion. Callers typically pass a closure over the
+	// registered version list.
+	recognizes func(apiVersion string) bool
+}
+
+var _ Manager = &inertVersionManager{}
+
+// NewInertVersionManager wraps a manager so applies tolerate entries whose
+// recorded apiVersion is no longer convertible. Such entries ride along
+// inert: they keep their recorded field sets, never raise conflicts, and
+// are logged and counted rather than failing the request.
+func NewInertVersionManager(delegate Manager, recognizes func(apiVersion string) bool) Manager {
+	return &inertVersionManager{delegate: delegate, recognizes: recognizes}
+}
+
+// Update implements Manager. Updates do not compare field sets across
+// versions, so they pass through.
+func (m *inertVersionManager) Update(liveObj, newObj runtime.Object, managed []api.ManagedFieldsEntry, manager string) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	return m.delegate.Update(liveObj, newObj, managed, manager)
+}
+
+// Apply implements Manager.
+func (m *inertVersionManager) Apply(liveObj, appliedObj runtime.Object, managed []api.ManagedFieldsEntry, manager string, force bool) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	convertible := make([]api.ManagedFieldsEntry, 0, len(managed))
+	var inert []api.ManagedFieldsEntry
+	for _, entry := range managed {
+		if len(entry.APIVersion) > 0 && !m.recognizes(entry.APIVersion) {
+			inert = append(inert, entry)
+			continue
+		}
+		convertible = append(convertible, entry)
+	}
+	for _, entry := range inert {
+		glog.Warningf("managedFields entry %s is recorded at unconvertible version %q: keeping it inert for this apply by manager %q", EntryIdentifier(entry), entry.APIVersion, manager)
+		RecordInertVersionEntry(entry.APIVersion)
+	}
+
+	newObj, newManaged, err := m.delegate.Apply(liveObj, appliedObj, convertible, manager, force)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the inert entries ride along untouched so their attribution survives
+	// until the version can be interpreted again or the entries expire
+	return newObj, append(newManaged, inert...), nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// captureManager remembers the managed entries its delegate methods were
+// handed.
+type captureManager struct {
+	seen []api.ManagedFieldsEntry
+}
+
+var _ Manager = &captureManager{}
+
+func (c *captureManager) Update(liveObj, newObj runtime.Object, managed []api.ManagedFieldsEntry, manager string) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	c.seen = managed
+	return newObj, managed, nil
+}
+
+func (c *captureManager) Apply(liveObj, appliedObj runtime.Object, managed []api.ManagedFieldsEntry, manager string, force bool) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	c.seen = managed
+	return appliedObj, managed, nil
+}
+
+func TestInertVersionExcludesUnconvertibleEntries(t *testing.T) {
+	recognizes := func(apiVersion string) bool { return apiVersion != "extensions/v0" }
+	capture := &captureManager{}
+	wrapped := NewInertVersionManager(capture, recognizes)
+
+	stale := api.ManagedFieldsEntry{Manager: "old-tool", Operation: api.ManagedFieldsOperationApply, APIVersion: "extensions/v0"}
+	current := validEntry("deploy-tool", 0)
+	managed := []api.ManagedFieldsEntry{stale, current}
+
+	_, out, err := wrapped.Apply(nil, &api.Pod{}, managed, "deploy-tool", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(capture.seen) != 1 || capture.seen[0].Manager != "deploy-tool" {
+		t.Errorf("expected the delegate to only see convertible entries, got %v", capture.seen)
+	}
+	// the inert entry survives on the object untouched
+	found := false
+	for _, entry := range out {
+		if entry.Manager == "old-tool" && entry.APIVersion == "extensions/v0" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected the inert entry to be kept, got %v", out)
+	}
+}
+
+func TestInertVersionPassesConvertibleEntries(t *testing.T) {
+	recognizes := func(string) bool { return true }
+	capture := &captureManager{}
+	wrapped := NewInertVersionManager(capture, recognizes)
+
+	managed := []api.ManagedFieldsEntry{validEntry("a", 0), validEntry("b", 0)}
+	_, out, err := wrapped.Apply(nil, &api.Pod{}, managed, "a", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(capture.seen) != 2 || len(out) != 2 {
+		t.Errorf("expected every entry to pass through, delegate saw %v, returned %v", capture.seen, out)
+	}
+
+	// updates pass through regardless of versions
+	managed = []api.ManagedFieldsEntry{{Manager: "old-tool", Operation: api.ManagedFieldsOperationUpdate, APIVersion: "gone/v0"}}
+	_, out, err = NewInertVersionManager(capture, func(string) bool { return false }).Update(nil, &api.Pod{}, managed, "a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 1 {
+		t.Errorf("expected the update path to be untouched, got %v", out)
+	}
+}
+
+func TestInertVersionWaivesStaleConflicts(t *testing.T) {
+	// a takeover conflict caused entirely by tombstones at a dead version
+	// must not fail the apply once those entries are inert
+	recognizes := func(apiVersion string) bool { return apiVersion != "extensions/v0" }
+	tombstone := validEntry(TombstoneManager, 0)
+	tombstone.APIVersion = "extensions/v0"
+	managed := []api.ManagedFieldsEntry{tombstone}
+
+	live := podWithManagedFields(managed...)
+	wrapped := NewInertVersionManager(NewStrictTakeoverManager(NewStampManager(runtime.APIVersionInternal)), recognizes)
+	_, out, err := wrapped.Apply(live, &api.Pod{}, managed, "deploy-tool", false)
+	if err != nil {
+		t.Fatalf("expected the unconvertible tombstone to not conflict, got %v", err)
+	}
+	found := false
+	for _, entry := range out {
+		if entry.Manager == TombstoneManager {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected the inert tombstone to survive the apply, got %v", out)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// AuditListRules walks the struct type of the given object and reports every
+// list field whose strategic-merge patch tags disagree with the registered
+// merge schema. kubectl's strategic merge follows the patchMergeKey tags
+// while apply follows the registered rules; a mismatch means the two produce
+// different results for the same request, so mismatches should be treated as
+// bugs in whichever side is wrong.
+//
+// basePath is the json path prefix of the object, e.g. "metadata" for
+// ObjectMeta. Only registered rules are checked against tags and vice versa;
+// list fields with neither are skipped.
+func AuditListRules(obj interface{}, basePath string) []error {
+	t := reflect.TypeOf(obj)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return []error{fmt.Errorf("expected a struct, got %v", t)}
+	}
+	var errs []error
+	auditStruct(t, basePath, &errs, map[reflect.Type]bool{})
+	return errs
+}
+
+func auditStruct(t reflect.Type, path string, errs *[]error, visited map[reflect.Type]bool) {
+	if visited[t] {
+		return
+	}
+	visited[t] = true
+	defer delete(visited, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldPath := joinFieldPath(path, jsonName(field))
+		if field.Type.Kind() == reflect.Slice {
+			auditListField(field, fieldPath, errs)
+			elem := field.Type.Elem()
+			for elem.Kind() == reflect.Ptr {
+				elem = elem.Elem()
+			}
+			if elem.Kind() == reflect.Struct {
+				auditStruct(elem, fieldPath, errs, visited)
+			}
+			continue
+		}
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct {
+			if field.Anonymous {
+				fieldPath = path
+			}
+			auditStruct(fieldType, fieldPath, errs, visited)
+		}
+	}
+}
+
+func auditListField(field reflect.StructField, path string, errs *[]error) {
+	mergeKey := field.Tag.Get("patchMergeKey")
+	strategy := field.Tag.Get("patchStrategy")
+	rule, registered := registeredListRule(path)
+	if !registered {
+		return
+	}
+	switch rule.Type {
+	case ListTypeMap:
+		if len(mergeKey) == 0 {
+			*errs = append(*errs, fmt.Errorf("%s: registered as a map keyed by %v but has no patchMergeKey tag", path, rule.Keys))
+			return
+		}
+		if len(rule.Keys) != 1 || rule.Keys[0] != mergeKey {
+			*errs = append(*errs, fmt.Errorf("%s: registered keys %v disagree with patchMergeKey %q", path, rule.Keys, mergeKey))
+		}
+	case ListTypeSet:
+		if strategy != "merge" {
+			*errs = append(*errs, fmt.Errorf("%s: registered as a set but patchStrategy is %q", path, strategy))
+		}
+		if len(mergeKey) != 0 {
+			*errs = append(*errs, fmt.Errorf("%s: registered as a set but has patchMergeKey %q", path, mergeKey))
+		}
+	case ListTypeAtomic:
+		if strategy == "merge" {
+			*errs = append(*errs, fmt.Errorf("%s: registered as atomic but patchStrategy is merge", path))
+		}
+	}
+}
+
+// registeredListRule is LookupListRule without the atomic default, so audits
+// can distinguish unregistered lists from explicitly atomic ones.
+func registeredListRule(path string) (ListRule, bool) {
+	listRulesLock.RLock()
+	defer listRulesLock.RUnlock()
+	rule, ok := listRules[path]
+	return rule, ok
+}
+
+func jsonName(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	if tag == "" {
+		return field.Name
+	}
+	name := strings.Split(tag, ",")[0]
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+func joinFieldPath(base, name string) string {
+	if base == "" {
+		return name
+	}
+	return base + "." + name
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+)
+
+type auditedOwner struct {
+	UID string `json:"uid"`
+}
+
+type auditedMeta struct {
+	OwnerReferences []auditedOwner `json:"ownerReferences,omitempty" patchStrategy:"merge" patchMergeKey:"uid"`
+	Finalizers      []string       `json:"finalizers,omitempty" patchStrategy:"merge"`
+}
+
+type auditedObject struct {
+	Metadata auditedMeta `json:"metadata,omitempty"`
+}
+
+func TestAuditListRulesConsistent(t *testing.T) {
+	// tags matching the registered metadata rules produce no findings
+	if errs := AuditListRules(&auditedObject{}, ""); len(errs) != 0 {
+		t.Errorf("expected no findings for consistent tags, got %v", errs)
+	}
+}
+
+type mismatchedMeta struct {
+	// registered as keyed by uid, tagged with a different key
+	OwnerReferences []auditedOwner `json:"ownerReferences,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+	// registered as a set, tagged atomic
+	Finalizers []string `json:"finalizers,omitempty"`
+}
+
+type mismatchedObject struct {
+	Metadata mismatchedMeta `json:"metadata,omitempty"`
+}
+
+func TestAuditListRulesMismatches(t *testing.T) {
+	errs := AuditListRules(&mismatchedObject{}, "")
+	if len(errs) != 2 {
+		t.Fatalf("expected findings for both mismatched fields, got %v", errs)
+	}
+}
+
+func TestAuditListRulesSkipsUnregistered(t *testing.T) {
+	type withUnregistered struct {
+		Items []string `json:"items,omitempty" patchStrategy:"merge"`
+	}
+	if errs := AuditListRules(&withUnregistered{}, "spec"); len(errs) != 0 {
+		t.Errorf("expected unregistered lists to be skipped, got %v", errs)
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// The order of a keyed list carries no meaning — entries are identified by
+// their key fields — but a merge that emits entries in whatever order the
+// inputs happened to arrive in produces spurious diffs: a re-apply or a
+// controller update looks like a change purely because the elements moved.
+// Merging keyed lists therefore emits entries in canonical key order, so the
+// same set of entries always serializes the same way.
+
+// mergeKeyedLists merges the configuration's entries over the live list,
+// pairing entries by their key fields. Paired entries merge granularly like
+// maps; entries only the configuration has are added; entries only the live
+// list has are kept. The result is sorted into canonical key order.
+func mergeKeyedLists(live, config []interface{}, keys []string, path string) []interface{} {
+	merged := append([]interface{}(nil), live...)
+	for _, element := range config {
+		configEntry, ok := element.(map[string]interface{})
+		if !ok {
+			// malformed entries merge by value, like a set
+			if !listContains(merged, element) {
+				merged = append(merged, element)
+			}
+			continue
+		}
+		found := false
+		for i := range merged {
+			liveEntry, ok := merged[i].(map[string]interface{})
+			if !ok || listElementKey(liveEntry, keys) != listElementKey(configEntry, keys) {
+				continue
+			}
+			merged[i] = mergeValueMaps(liveEntry, configEntry, path)
+			found = true
+			break
+		}
+		if !found {
+			merged = append(merged, element)
+		}
+	}
+	sortKeyedList(merged, keys)
+	return merged
+}
+
+// sortKeyedList sorts a keyed list into canonical key order in place. The
+// sort is stable, so entries with equal keys (which a valid keyed list does
+// not have) keep their relative order.
+func sortKeyedList(list []interface{}, keys []string) {
+	sort.Stable(&byElementKey{list: list, keys: keys})
+}
+
+type byElementKey struct {
+	list []interface{}
+	keys []string
+}
+
+func (s *byElementKey) Len() int      { return len(s.list) }
+func (s *byElementKey) Swap(i, j int) { s.list[i], s.list[j] = s.list[j], s.list[i] }
+func (s *byElementKey) Less(i, j int) bool {
+	return elementOrderKey(s.list[i], s.keys) < elementOrderKey(s.list[j], s.keys)
+}
+
+// elementOrderKey returns the string a list element sorts and pairs by: the
+// element's key field values for a well-formed entry, its printed value
+// otherwise.
+func elementOrderKey(element interface{}, keys []string) string {
+	entry, ok := element.(map[string]interface{})
+	if !ok {
+		return fmt.Sprintf("%v", element)
+	}
+	return listElementKey(entry, keys)
+}
+
+func listElementKey(entry map[string]interface{}, keys []string) string {
+	keyValues := make([]string, 0, len(keys))
+	for _, key := range keys {
+		keyValues = append(keyValues, fmt.Sprintf("%s=%v", key, entry[key]))
+	}
+	return strings.Join(keyValues, ",")
+}
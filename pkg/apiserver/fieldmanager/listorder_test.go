@@ -0,0 +1,79 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeKeyedLists(t *testing.T) {
+	live := []interface{}{
+		map[string]interface{}{"uid": "b", "name": "second", "controller": true},
+		map[string]interface{}{"uid": "a", "name": "first"},
+	}
+	config := []interface{}{
+		map[string]interface{}{"uid": "c", "name": "third"},
+		map[string]interface{}{"uid": "b", "name": "renamed"},
+	}
+
+	merged := mergeKeyedLists(live, config, []string{"uid"}, "metadata.ownerReferences")
+	expected := []interface{}{
+		map[string]interface{}{"uid": "a", "name": "first"},
+		map[string]interface{}{"uid": "b", "name": "renamed", "controller": true},
+		map[string]interface{}{"uid": "c", "name": "third"},
+	}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("expected %v, got %v", expected, merged)
+	}
+}
+
+func TestMergeKeyedListsOrderIndependent(t *testing.T) {
+	forward := []interface{}{
+		map[string]interface{}{"uid": "a"},
+		map[string]interface{}{"uid": "b"},
+	}
+	backward := []interface{}{
+		map[string]interface{}{"uid": "b"},
+		map[string]interface{}{"uid": "a"},
+	}
+	config := []interface{}{map[string]interface{}{"uid": "a"}}
+
+	first := mergeKeyedLists(forward, config, []string{"uid"}, "metadata.ownerReferences")
+	second := mergeKeyedLists(backward, config, []string{"uid"}, "metadata.ownerReferences")
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("expected the merged order not to depend on the live order, got %v and %v", first, second)
+	}
+}
+
+func TestOfflineApplyKeyedListsCanonicalOrder(t *testing.T) {
+	live := []byte(`{"metadata":{"ownerReferences":[{"uid":"z","name":"last"},{"uid":"a","name":"first"}]}}`)
+	config := []byte(`{"metadata":{"ownerReferences":[{"uid":"m","name":"middle"}]}}`)
+
+	result, err := OfflineApply(live, config, nil, "deploy-tool", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	refs := result.Merged["metadata"].(map[string]interface{})["ownerReferences"].([]interface{})
+	uids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		uids = append(uids, ref.(map[string]interface{})["uid"].(string))
+	}
+	if !reflect.DeepEqual(uids, []string{"a", "m", "z"}) {
+		t.Errorf("expected canonical key order, got %v", uids)
+	}
+}
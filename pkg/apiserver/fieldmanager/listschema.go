@@ -0,0 +1,201 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ListType describes how a list field merges when written by several
+// managers.
+type ListType string
+
+const (
+	// ListTypeAtomic lists are owned and replaced as a whole.
+	ListTypeAtomic ListType = "atomic"
+	// ListTypeSet lists merge by element value; each manager owns the
+	// elements it added.
+	ListTypeSet ListType = "set"
+	// ListTypeMap lists merge associatively by key fields; each manager
+	// owns the entries it added.
+	ListTypeMap ListType = "map"
+)
+
+// ListRule is the merge semantics of one list field.
+type ListRule struct {
+	Type ListType
+	// Keys names the fields identifying an entry, for ListTypeMap.
+	Keys []string
+}
+
+// MapType describes how a map field merges when written by several
+// managers.
+type MapType string
+
+const (
+	// MapTypeAtomic maps are owned and replaced as a whole: an apply that
+	// sets the map replaces it wholesale.
+	MapTypeAtomic MapType = "atomic"
+	// MapTypeGranular maps merge per key; each manager owns the keys it
+	// set.
+	MapTypeGranular MapType = "granular"
+)
+
+var (
+	listRulesLock sync.RWMutex
+	listRules     = map[string]ListRule{}
+
+	mapRulesLock sync.RWMutex
+	mapRules     = map[string]MapType{}
+)
+
+// RegisterListRule declares the merge semantics of the list at the given
+// field path, e.g. "metadata.ownerReferences". Registering a path twice with
+// different rules is a programmer error.
+func RegisterListRule(path string, rule ListRule) error {
+	if rule.Type == ListTypeMap && len(rule.Keys) == 0 {
+		return fmt.Errorf("list rule for %q: map semantics require key fields", path)
+	}
+	listRulesLock.Lock()
+	defer listRulesLock.Unlock()
+	if existing, ok := listRules[path]; ok {
+		if existing.Type != rule.Type || fmt.Sprintf("%v", existing.Keys) != fmt.Sprintf("%v", rule.Keys) {
+			return fmt.Errorf("list rule for %q already registered as %+v", path, existing)
+		}
+		return nil
+	}
+	listRules[path] = rule
+	return nil
+}
+
+// LookupListRule returns the registered semantics for the list at the given
+// field path. Unregistered lists default to atomic, the conservative
+// behavior.
+func LookupListRule(path string) ListRule {
+	rule, _ := LookupListRuleOK(path)
+	return rule
+}
+
+// LookupListRuleOK is LookupListRule distinguishing a registered rule from
+// the deduced atomic default, for callers that report on deduced typing.
+func LookupListRuleOK(path string) (ListRule, bool) {
+	listRulesLock.RLock()
+	defer listRulesLock.RUnlock()
+	if rule, ok := listRules[path]; ok {
+		return rule, true
+	}
+	return ListRule{Type: ListTypeAtomic}, false
+}
+
+// RegisterMapRule declares the merge semantics of the map at the given field
+// path. Registering a path twice with different semantics is a programmer
+// error.
+func RegisterMapRule(path string, mapType MapType) error {
+	mapRulesLock.Lock()
+	defer mapRulesLock.Unlock()
+	if existing, ok := mapRules[path]; ok {
+		if existing != mapType {
+			return fmt.Errorf("map rule for %q already registered as %q", path, existing)
+		}
+		return nil
+	}
+	mapRules[path] = mapType
+	return nil
+}
+
+// LookupMapRule returns the registered semantics for the map at the given
+// field path. Unregistered maps default to granular, matching how labels and
+// annotations have always merged.
+func LookupMapRule(path string) MapType {
+	mapRulesLock.RLock()
+	defer mapRulesLock.RUnlock()
+	if mapType, ok := mapRules[path]; ok {
+		return mapType
+	}
+	return MapTypeGranular
+}
+
+func init() {
+	// ownerReferences are added independently by multiple controllers;
+	// keying them by uid lets each controller own its entry instead of
+	// stomping the whole list. This matches the patchMergeKey on the
+	// versioned types.
+	if err := RegisterListRule("metadata.ownerReferences", ListRule{Type: ListTypeMap, Keys: []string{"uid"}}); err != nil {
+		panic(err)
+	}
+	// finalizers are added independently by the managers that will act on
+	// them; set semantics let each manager own the finalizers it added, so
+	// omitting a finalizer from an apply removes only that manager's own
+	// entry rather than the whole list.
+	if err := RegisterListRule("metadata.finalizers", ListRule{Type: ListTypeSet}); err != nil {
+		panic(err)
+	}
+
+	// selectors and resource requirement maps are validated and reasoned
+	// about as a unit; merging them key by key would let an apply leave
+	// behind stale keys that no single writer intended, so they replace
+	// wholesale. These registrations mirror the +mapType=atomic markers on
+	// the type definitions.
+	for _, path := range []string{
+		"spec.selector",
+		"spec.selector.matchLabels",
+		"spec.nodeSelector",
+		"spec.template.spec.nodeSelector",
+		"spec.containers.resources.limits",
+		"spec.containers.resources.requests",
+		"spec.initContainers.resources.limits",
+		"spec.initContainers.resources.requests",
+	} {
+		if err := RegisterMapRule(path, MapTypeAtomic); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// ReconcileFinalizers merges an applier's desired finalizers into the live
+// list with set semantics: finalizers the manager owns but no longer lists
+// are removed, desired finalizers missing from the live list are appended,
+// and finalizers owned by other managers are never touched.
+func ReconcileFinalizers(live, desired, owned []string) []string {
+	desiredSet := map[string]bool{}
+	for _, finalizer := range desired {
+		desiredSet[finalizer] = true
+	}
+	ownedSet := map[string]bool{}
+	for _, finalizer := range owned {
+		ownedSet[finalizer] = true
+	}
+
+	var result []string
+	seen := map[string]bool{}
+	for _, finalizer := range live {
+		if ownedSet[finalizer] && !desiredSet[finalizer] {
+			// removal by omission of the manager's own finalizer
+			continue
+		}
+		result = append(result, finalizer)
+		seen[finalizer] = true
+	}
+	for _, finalizer := range desired {
+		if !seen[finalizer] {
+			result = append(result, finalizer)
+			seen[finalizer] = true
+		}
+	}
+	return result
+}
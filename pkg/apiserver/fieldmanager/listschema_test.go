@@ -0,0 +1,137 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+)
+
+func TestOwnerReferencesKeyedByUID(t *testing.T) {
+	rule := LookupListRule("metadata.ownerReferences")
+	if rule.Type != ListTypeMap {
+		t.Fatalf("expected ownerReferences to merge as a map, got %q", rule.Type)
+	}
+	if len(rule.Keys) != 1 || rule.Keys[0] != "uid" {
+		t.Errorf("expected ownerReferences to be keyed by uid, got %v", rule.Keys)
+	}
+}
+
+func TestFinalizersAreASet(t *testing.T) {
+	if rule := LookupListRule("metadata.finalizers"); rule.Type != ListTypeSet {
+		t.Errorf("expected finalizers to merge as a set, got %q", rule.Type)
+	}
+}
+
+func TestReconcileFinalizers(t *testing.T) {
+	tests := []struct {
+		name                 string
+		live, desired, owned []string
+		expected             []string
+	}{
+		{
+			name:     "adds missing finalizers",
+			live:     []string{"other.io/protect"},
+			desired:  []string{"example.com/cleanup"},
+			expected: []string{"other.io/protect", "example.com/cleanup"},
+		},
+		{
+			name:     "removes only the manager's own omitted finalizer",
+			live:     []string{"other.io/protect", "example.com/cleanup"},
+			desired:  nil,
+			owned:    []string{"example.com/cleanup"},
+			expected: []string{"other.io/protect"},
+		},
+		{
+			name:     "never removes another manager's finalizer",
+			live:     []string{"other.io/protect"},
+			desired:  nil,
+			owned:    nil,
+			expected: []string{"other.io/protect"},
+		},
+		{
+			name:     "idempotent when desired already present",
+			live:     []string{"example.com/cleanup"},
+			desired:  []string{"example.com/cleanup"},
+			owned:    []string{"example.com/cleanup"},
+			expected: []string{"example.com/cleanup"},
+		},
+	}
+	for _, test := range tests {
+		result := ReconcileFinalizers(test.live, test.desired, test.owned)
+		if len(result) != len(test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, result)
+			continue
+		}
+		for i := range result {
+			if result[i] != test.expected[i] {
+				t.Errorf("%s: expected %v, got %v", test.name, test.expected, result)
+				break
+			}
+		}
+	}
+}
+
+func TestAtomicMapRules(t *testing.T) {
+	for _, path := range []string{
+		"spec.selector",
+		"spec.selector.matchLabels",
+		"spec.containers.resources.limits",
+		"spec.containers.resources.requests",
+	} {
+		if mapType := LookupMapRule(path); mapType != MapTypeAtomic {
+			t.Errorf("expected %s to merge atomically, got %q", path, mapType)
+		}
+	}
+	if mapType := LookupMapRule("metadata.labels"); mapType != MapTypeGranular {
+		t.Errorf("expected labels to merge per key, got %q", mapType)
+	}
+}
+
+func TestRegisterMapRule(t *testing.T) {
+	if err := RegisterMapRule("test.map", MapTypeAtomic); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := RegisterMapRule("test.map", MapTypeAtomic); err != nil {
+		t.Errorf("unexpected error re-registering the same rule: %v", err)
+	}
+	if err := RegisterMapRule("test.map", MapTypeGranular); err == nil {
+		t.Errorf("expected a conflicting registration to be rejected")
+	}
+}
+
+func TestLookupListRuleDefaultsToAtomic(t *testing.T) {
+	if rule := LookupListRule("spec.unregistered"); rule.Type != ListTypeAtomic {
+		t.Errorf("expected unregistered lists to default to atomic, got %q", rule.Type)
+	}
+}
+
+func TestRegisterListRule(t *testing.T) {
+	if err := RegisterListRule("test.mapWithoutKeys", ListRule{Type: ListTypeMap}); err == nil {
+		t.Errorf("expected map semantics without keys to be rejected")
+	}
+	if err := RegisterListRule("test.set", ListRule{Type: ListTypeSet}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// re-registering the identical rule is allowed
+	if err := RegisterListRule("test.set", ListRule{Type: ListTypeSet}); err != nil {
+		t.Errorf("unexpected error re-registering the same rule: %v", err)
+	}
+	// conflicting registration is not
+	if err := RegisterListRule("test.set", ListRule{Type: ListTypeAtomic}); err == nil {
+		t.Errorf("expected a conflicting registration to be rejected")
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// Manager updates the managed fields of an object as part of a write
+// request. Managers are stacked as decorators: each one adjusts the object
+// or the managed field state and delegates to the next one in the chain.
+type Manager interface {
+	// Update is used when the object is changed through a regular update
+	// or patch by the given manager. It returns the new object and the
+	// updated managed field state.
+	Update(liveObj, newObj runtime.Object, managed []api.ManagedFieldsEntry, manager string) (runtime.Object, []api.ManagedFieldsEntry, error)
+
+	// Apply is used when the object is changed through an apply request
+	// by the given manager. Force indicates that conflicts with other
+	// managers should be overridden rather than reported.
+	Apply(liveObj, appliedObj runtime.Object, managed []api.ManagedFieldsEntry, manager string, force bool) (runtime.Object, []api.ManagedFieldsEntry, error)
+}
+
+// timingManager records the latency of the wrapped Manager's calls, keyed by
+// the wrapped Manager's name. Each decorator in the chain is wrapped
+// separately so that a regression introduced by a new decorator is visible
+// independently of total request latency.
+type timingManager struct {
+	delegate Manager
+	name     string
+}
+
+var _ Manager = &timingManager{}
+
+// NewTimingManager wraps a Manager so that the latency of its Update and
+// Apply calls is recorded under the given name.
+func NewTimingManager(delegate Manager, name string) Manager {
+	return &timingManager{delegate: delegate, name: name}
+}
+
+// Update implements Manager.
+func (f *timingManager) Update(liveObj, newObj runtime.Object, managed []api.ManagedFieldsEntry, manager string) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	startTime := time.Now()
+	defer RecordManagerOperationLatency(f.name, "update", startTime)
+	return f.delegate.Update(liveObj, newObj, managed, manager)
+}
+
+// Apply implements Manager.
+func (f *timingManager) Apply(liveObj, appliedObj runtime.Object, managed []api.ManagedFieldsEntry, manager string, force bool) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	startTime := time.Now()
+	defer RecordManagerOperationLatency(f.name, "apply", startTime)
+	return f.delegate.Apply(liveObj, appliedObj, managed, manager, force)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// fakeManager records which of its methods were called and echoes its
+// arguments back.
+type fakeManager struct {
+	updates int
+	applies int
+}
+
+var _ Manager = &fakeManager{}
+
+func (f *fakeManager) Update(liveObj, newObj runtime.Object, managed []api.ManagedFieldsEntry, manager string) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	f.updates++
+	return newObj, managed, nil
+}
+
+func (f *fakeManager) Apply(liveObj, appliedObj runtime.Object, managed []api.ManagedFieldsEntry, manager string, force bool) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	f.applies++
+	return appliedObj, managed, nil
+}
+
+func TestTimingManagerDelegates(t *testing.T) {
+	fake := &fakeManager{}
+	wrapped := NewTimingManager(fake, "FakeManager")
+
+	newObj := &api.Pod{}
+	managed := []api.ManagedFieldsEntry{validEntry("test", 0)}
+
+	obj, out, err := wrapped.Update(nil, newObj, managed, "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if obj != newObj || len(out) != len(managed) || fake.updates != 1 {
+		t.Errorf("expected Update to delegate, got obj=%v managed=%v updates=%d", obj, out, fake.updates)
+	}
+
+	obj, out, err = wrapped.Apply(nil, newObj, managed, "test", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if obj != newObj || len(out) != len(managed) || fake.applies != 1 {
+		t.Errorf("expected Apply to delegate, got obj=%v managed=%v applies=%d", obj, out, fake.applies)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	managerOperationLatencies = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "apiserver_field_manager_operation_latencies_summary",
+			Help: "Latency in microseconds of each Manager in the field manager chain, per manager name and operation.",
+		},
+		[]string{"manager", "operation"},
+	)
+	managedFieldsObjectShare = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "apiserver_managed_fields_object_bytes_summary",
+			Help: "Serialized bytes of managedFields per stored object, by resource.",
+		},
+		[]string{"resource"},
+	)
+	mergeBudgetExceeded = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "apiserver_field_manager_merge_budget_exceeded_total",
+			Help: "Number of merges abandoned because they ran past their time budget, by phase.",
+		},
+		[]string{"phase"},
+	)
+	deducedListTypes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "apiserver_field_manager_deduced_list_types_total",
+			Help: "Number of applies that merged a list with deduced atomic semantics because no rule is registered, by field path.",
+		},
+		[]string{"path"},
+	)
+	inertVersionEntries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "apiserver_managed_fields_inert_version_entries_total",
+			Help: "Number of managedFields entries kept inert during an apply because their recorded apiVersion could not be converted, by apiVersion.",
+		},
+		[]string{"api_version"},
+	)
+)
+
+var registerMetrics sync.Once
+
+// RegisterMetrics registers the field manager metrics.
+func RegisterMetrics() {
+	registerMetrics.Do(func() {
+		prometheus.MustRegister(managerOperationLatencies)
+		prometheus.MustRegister(managedFieldsObjectShare)
+		prometheus.MustRegister(mergeBudgetExceeded)
+		prometheus.MustRegister(deducedListTypes)
+		prometheus.MustRegister(inertVersionEntries)
+	})
+}
+
+// RecordDeducedListType counts an apply that merged the list at the given
+// field path with deduced atomic semantics.
+func RecordDeducedListType(path string) {
+	deducedListTypes.WithLabelValues(path).Inc()
+}
+
+// RecordMergeBudgetExceeded counts a merge that was abandoned because it ran
+// past its time budget.
+func RecordMergeBudgetExceeded(phase string) {
+	mergeBudgetExceeded.WithLabelValues(phase).Inc()
+}
+
+// RecordInertVersionEntry counts a managedFields entry that was kept inert
+// because its recorded apiVersion could not be converted.
+func RecordInertVersionEntry(apiVersion string) {
+	inertVersionEntries.WithLabelValues(apiVersion).Inc()
+}
+
+// RecordManagedFieldsShare records the managedFields part of an object's size
+// breakdown for the given resource.
+func RecordManagedFieldsShare(resource string, breakdown SizeBreakdown) {
+	managedFieldsObjectShare.WithLabelValues(resource).Observe(float64(breakdown.ManagedFields))
+}
+
+// RecordManagerOperationLatency records the latency of a single Manager call
+// in the chain. The operation is either "update" or "apply".
+func RecordManagerOperationLatency(manager, operation string, startTime time.Time) {
+	managerOperationLatencies.WithLabelValues(manager, operation).Observe(float64(time.Since(startTime) / time.Microsecond))
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+// APIVersionMigrator rewrites the APIVersion recorded in managedFields
+// entries when a resource's storage version moves. Entries left at the old
+// version force a conversion on every subsequent apply; rewriting them while
+// the storage migrator is already touching every object makes that cost a
+// one-time event.
+type APIVersionMigrator struct {
+	// rules maps an old apiVersion to the apiVersion that replaces it.
+	rules map[string]string
+}
+
+func NewAPIVersionMigrator() *APIVersionMigrator {
+	return &APIVersionMigrator{rules: map[string]string{}}
+}
+
+// AddRule arranges for entries recorded at oldVersion to be rewritten to
+// newVersion. Chained rules are not followed; migrating v1beta1 through
+// v1beta2 to v1 requires a rule for each old version pointing at v1.
+func (m *APIVersionMigrator) AddRule(oldVersion, newVersion string) error {
+	if oldVersion == newVersion {
+		return fmt.Errorf("cannot migrate %q to itself", oldVersion)
+	}
+	if target, ok := m.rules[oldVersion]; ok && target != newVersion {
+		return fmt.Errorf("conflicting rule for %q: already migrating to %q", oldVersion, target)
+	}
+	if _, ok := m.rules[newVersion]; ok {
+		return fmt.Errorf("cannot migrate to %q: it is itself migrated", newVersion)
+	}
+	m.rules[oldVersion] = newVersion
+	return nil
+}
+
+// Migrate returns the entries with any old API versions rewritten, and true
+// if anything changed. When rewriting makes two entries identical in manager,
+// operation and version, their field sets are merged into one entry so the
+// same manager does not appear twice.
+func (m *APIVersionMigrator) Migrate(managed []api.ManagedFieldsEntry) ([]api.ManagedFieldsEntry, bool, error) {
+	changed := false
+	for i := range managed {
+		if target, ok := m.rules[managed[i].APIVersion]; ok {
+			managed[i].APIVersion = target
+			changed = true
+		}
+	}
+	if !changed {
+		return managed, false, nil
+	}
+
+	out := make([]api.ManagedFieldsEntry, 0, len(managed))
+	for _, entry := range managed {
+		merged := false
+		for i := range out {
+			if !sameOwner(out[i], entry) {
+				continue
+			}
+			combined, err := mergeFieldsV1(out[i].FieldsV1, entry.FieldsV1)
+			if err != nil {
+				return nil, false, err
+			}
+			out[i].FieldsV1 = combined
+			if entry.Time != nil && (out[i].Time == nil || entry.Time.After(out[i].Time.Time)) {
+				out[i].Time = entry.Time
+			}
+			merged = true
+			break
+		}
+		if !merged {
+			out = append(out, entry)
+		}
+	}
+	return out, true, nil
+}
+
+func sameOwner(a, b api.ManagedFieldsEntry) bool {
+	return a.Manager == b.Manager &&
+		a.Operation == b.Operation &&
+		a.APIVersion == b.APIVersion &&
+		a.FieldsType == b.FieldsType
+}
+
+// mergeFieldsV1 computes the union of two managed fields structures. Both
+// structures are trees of nested objects whose leaves are empty objects, so a
+// recursive key union is the correct merge.
+func mergeFieldsV1(a, b *api.FieldsV1) (*api.FieldsV1, error) {
+	if a == nil {
+		return b, nil
+	}
+	if b == nil {
+		return a, nil
+	}
+	var av, bv map[string]interface{}
+	if err := json.Unmarshal(a.Raw, &av); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b.Raw, &bv); err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(unionMaps(av, bv))
+	if err != nil {
+		return nil, err
+	}
+	return &api.FieldsV1{Raw: raw}, nil
+}
+
+func unionMaps(a, b map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for k, v := range a {
+		out[k] = v
+	}
+	for k, v := range b {
+		existing, ok := out[k].(map[string]interface{})
+		if !ok {
+			out[k] = v
+			continue
+		}
+		if addition, ok := v.(map[string]interface{}); ok {
+			out[k] = unionMaps(existing, addition)
+		}
+	}
+	return out
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func TestAPIVersionMigratorRules(t *testing.T) {
+	m := NewAPIVersionMigrator()
+	if err := m.AddRule("apps/v1beta2", "apps/v1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.AddRule("apps/v1beta2", "apps/v1beta1"); err == nil {
+		t.Errorf("expected an error for a conflicting rule")
+	}
+	if err := m.AddRule("apps/v1", "apps/v1"); err == nil {
+		t.Errorf("expected an error for a self migration")
+	}
+	if err := m.AddRule("extensions/v1beta1", "apps/v1beta2"); err == nil {
+		t.Errorf("expected an error for a chained rule")
+	}
+}
+
+func TestMigrateRewritesVersions(t *testing.T) {
+	m := NewAPIVersionMigrator()
+	if err := m.AddRule("apps/v1beta2", "apps/v1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	managed := []api.ManagedFieldsEntry{
+		{Manager: "controller", Operation: api.ManagedFieldsOperationUpdate, APIVersion: "apps/v1beta2"},
+		{Manager: "kubectl", Operation: api.ManagedFieldsOperationApply, APIVersion: "v1"},
+	}
+	out, changed, err := m.Migrate(managed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !changed {
+		t.Fatalf("expected the entries to change")
+	}
+	if out[0].APIVersion != "apps/v1" || out[1].APIVersion != "v1" {
+		t.Errorf("unexpected versions: %#v", out)
+	}
+
+	// already migrated entries are untouched
+	if _, changed, err := m.Migrate(out); err != nil || changed {
+		t.Errorf("expected a second migration to be a no-op, got changed=%v, err=%v", changed, err)
+	}
+}
+
+func TestMigrateMergesCollidingEntries(t *testing.T) {
+	m := NewAPIVersionMigrator()
+	if err := m.AddRule("apps/v1beta2", "apps/v1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	managed := []api.ManagedFieldsEntry{
+		{
+			Manager:    "controller",
+			Operation:  api.ManagedFieldsOperationUpdate,
+			APIVersion: "apps/v1beta2",
+			FieldsType: "FieldsV1",
+			FieldsV1:   &api.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+		},
+		{
+			Manager:    "controller",
+			Operation:  api.ManagedFieldsOperationUpdate,
+			APIVersion: "apps/v1",
+			FieldsType: "FieldsV1",
+			FieldsV1:   &api.FieldsV1{Raw: []byte(`{"f:spec":{"f:paused":{}}}`)},
+		},
+	}
+	out, changed, err := m.Migrate(managed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !changed {
+		t.Fatalf("expected the entries to change")
+	}
+	if len(out) != 1 {
+		t.Fatalf("expected the colliding entries to merge, got %#v", out)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(out[0].FieldsV1.Raw, &fields); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]interface{}{
+		"f:spec": map[string]interface{}{
+			"f:replicas": map[string]interface{}{},
+			"f:paused":   map[string]interface{}{},
+		},
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("unexpected merged fields:\n%#v\n%#v", fields, expected)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Typed decoding erases the difference between a field set to null, set to
+// an empty collection, and omitted, so the distinction has to be extracted
+// from the raw apply configuration before decoding. This file defines the
+// one documented interpretation:
+//
+//   - `field: null` always means "clear the field and give up ownership".
+//   - `field: {}` and `field: []` mean "clear the field" by default; under
+//     the strict interpretation they mean "I own this field and want it
+//     empty", so another manager adding entries later conflicts.
+//   - an omitted field is never touched.
+//
+// The strict interpretation is opt-in through ApplyOptions because existing
+// clients send empty collections interchangeably with null.
+
+// ApplyOptions carries per-request apply behavior knobs.
+type ApplyOptions struct {
+	// StrictEmptyCollections distinguishes empty collections from null:
+	// empty means owned-and-empty rather than clear.
+	StrictEmptyCollections bool
+}
+
+// CollectionDirective is the interpreted intent for one field of an apply
+// configuration.
+type CollectionDirective string
+
+const (
+	// DirectiveClear removes the field and any ownership of it.
+	DirectiveClear CollectionDirective = "clear"
+	// DirectiveOwnEmpty keeps the field as an owned, empty collection.
+	DirectiveOwnEmpty CollectionDirective = "ownEmpty"
+)
+
+// CollectionDirectives walks the raw apply configuration and returns the
+// directive for every field explicitly set to null or to an empty
+// collection, keyed by dot-separated field path. Populated and omitted
+// fields do not appear in the result.
+func CollectionDirectives(config []byte, options ApplyOptions) (map[string]CollectionDirective, error) {
+	var parsed map[string]interface{}
+	if err := json.Unmarshal(config, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse the apply configuration: %v", err)
+	}
+	directives := map[string]CollectionDirective{}
+	collectDirectives(parsed, "", options, directives)
+	return directives, nil
+}
+
+func collectDirectives(value map[string]interface{}, path string, options ApplyOptions, directives map[string]CollectionDirective) {
+	for key, entry := range value {
+		entryPath := joinFieldPath(path, key)
+		switch typed := entry.(type) {
+		case nil:
+			directives[entryPath] = DirectiveClear
+		case map[string]interface{}:
+			if len(typed) == 0 {
+				directives[entryPath] = emptyDirective(options)
+				continue
+			}
+			collectDirectives(typed, entryPath, options, directives)
+		case []interface{}:
+			if len(typed) == 0 {
+				directives[entryPath] = emptyDirective(options)
+			}
+		}
+	}
+}
+
+func emptyDirective(options ApplyOptions) CollectionDirective {
+	if options.StrictEmptyCollections {
+		return DirectiveOwnEmpty
+	}
+	return DirectiveClear
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+)
+
+func TestCollectionDirectives(t *testing.T) {
+	config := []byte(`{
+		"metadata": {"labels": null, "annotations": {}},
+		"spec": {"containers": [], "nodeName": "node-1"}
+	}`)
+
+	// default interpretation: null and empty both clear
+	directives, err := CollectionDirectives(config, ApplyOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]CollectionDirective{
+		"metadata.labels":      DirectiveClear,
+		"metadata.annotations": DirectiveClear,
+		"spec.containers":      DirectiveClear,
+	}
+	if len(directives) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, directives)
+	}
+	for path, directive := range expected {
+		if directives[path] != directive {
+			t.Errorf("%s: expected %q, got %q", path, directive, directives[path])
+		}
+	}
+
+	// strict interpretation: empty collections are owned, null still clears
+	directives, err = CollectionDirectives(config, ApplyOptions{StrictEmptyCollections: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if directives["metadata.labels"] != DirectiveClear {
+		t.Errorf("expected null to clear under strict interpretation, got %q", directives["metadata.labels"])
+	}
+	if directives["metadata.annotations"] != DirectiveOwnEmpty {
+		t.Errorf("expected an empty map to be owned under strict interpretation, got %q", directives["metadata.annotations"])
+	}
+	if directives["spec.containers"] != DirectiveOwnEmpty {
+		t.Errorf("expected an empty list to be owned under strict interpretation, got %q", directives["spec.containers"])
+	}
+
+	// populated and omitted fields never appear
+	if _, ok := directives["spec.nodeName"]; ok {
+		t.Errorf("expected populated fields to be absent, got %v", directives)
+	}
+}
+
+func TestCollectionDirectivesInvalidConfig(t *testing.T) {
+	if _, err := CollectionDirectives([]byte(`not json`), ApplyOptions{}); err == nil {
+		t.Errorf("expected invalid configurations to be rejected")
+	}
+}
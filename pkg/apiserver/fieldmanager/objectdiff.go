@@ -0,0 +1,193 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// FieldDiff is one field that differs between two objects. Old is nil for a
+// field only the modified object has, New is nil for a field only the
+// original has.
+type FieldDiff struct {
+	Path string
+	Old  interface{}
+	New  interface{}
+}
+
+// ObjectDiff is the structured difference between two objects of the same
+// kind, produced by DiffObjects. Each slice is sorted by path.
+type ObjectDiff struct {
+	// Added are fields present only in the modified object.
+	Added []FieldDiff
+	// Removed are fields present only in the original object.
+	Removed []FieldDiff
+	// Modified are fields present in both with different values.
+	Modified []FieldDiff
+}
+
+// Empty returns true when the two objects did not differ.
+func (d *ObjectDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// DiffObjects diffs two objects of the same kind using the registered merge
+// semantics, so the granularity of the result matches how the fields merge:
+// granular maps and keyed lists are compared element by element, while
+// atomic maps and lists surface as a single difference. Controllers, diff
+// tooling and audit enrichment get one schema-aware comparison instead of
+// each re-walking raw JSON.
+func DiffObjects(original, modified runtime.Object) (*ObjectDiff, error) {
+	originalMap, err := objectToMap(original)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert original object: %v", err)
+	}
+	modifiedMap, err := objectToMap(modified)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert modified object: %v", err)
+	}
+	diff := &ObjectDiff{}
+	diffObjectMaps("", originalMap, modifiedMap, diff)
+	sortFieldDiffs(diff.Added)
+	sortFieldDiffs(diff.Removed)
+	sortFieldDiffs(diff.Modified)
+	return diff, nil
+}
+
+func sortFieldDiffs(diffs []FieldDiff) {
+	sort.Stable(byDiffPath(diffs))
+}
+
+type byDiffPath []FieldDiff
+
+func (d byDiffPath) Len() int      { return len(d) }
+func (d byDiffPath) Swap(i, j int) { d[i], d[j] = d[j], d[i] }
+func (d byDiffPath) Less(i, j int) bool {
+	if d[i].Path != d[j].Path {
+		return d[i].Path < d[j].Path
+	}
+	return fmt.Sprintf("%v", d[i].Old)+fmt.Sprintf("%v", d[i].New) < fmt.Sprintf("%v", d[j].Old)+fmt.Sprintf("%v", d[j].New)
+}
+
+// diffObjectMaps compares two granular maps key by key, dispatching each
+// value pair on its registered merge semantics.
+func diffObjectMaps(path string, original, modified map[string]interface{}, diff *ObjectDiff) {
+	for key, originalValue := range original {
+		fieldPath := joinFieldPath(path, key)
+		modifiedValue, ok := modified[key]
+		if !ok {
+			diff.Removed = append(diff.Removed, FieldDiff{Path: fieldPath, Old: originalValue})
+			continue
+		}
+		diffValues(fieldPath, originalValue, modifiedValue, diff)
+	}
+	for key, modifiedValue := range modified {
+		if _, ok := original[key]; !ok {
+			diff.Added = append(diff.Added, FieldDiff{Path: joinFieldPath(path, key), New: modifiedValue})
+		}
+	}
+}
+
+func diffValues(path string, original, modified interface{}, diff *ObjectDiff) {
+	originalMap, originalIsMap := original.(map[string]interface{})
+	modifiedMap, modifiedIsMap := modified.(map[string]interface{})
+	if originalIsMap && modifiedIsMap {
+		if LookupMapRule(path) == MapTypeAtomic {
+			if !reflect.DeepEqual(original, modified) {
+				diff.Modified = append(diff.Modified, FieldDiff{Path: path, Old: original, New: modified})
+			}
+			return
+		}
+		diffObjectMaps(path, originalMap, modifiedMap, diff)
+		return
+	}
+
+	originalList, originalIsList := original.([]interface{})
+	modifiedList, modifiedIsList := modified.([]interface{})
+	if originalIsList && modifiedIsList {
+		switch rule := LookupListRule(path); rule.Type {
+		case ListTypeSet:
+			diffSetLists(path, originalList, modifiedList, diff)
+			return
+		case ListTypeMap:
+			diffKeyedLists(path, originalList, modifiedList, rule.Keys, diff)
+			return
+		}
+		// atomic lists differ or not as a whole
+	}
+
+	if !reflect.DeepEqual(original, modified) {
+		diff.Modified = append(diff.Modified, FieldDiff{Path: path, Old: original, New: modified})
+	}
+}
+
+// diffSetLists compares two set lists by membership: each element appearing
+// on only one side is reported individually at the list's path.
+func diffSetLists(path string, original, modified []interface{}, diff *ObjectDiff) {
+	for _, element := range original {
+		if !listContains(modified, element) {
+			diff.Removed = append(diff.Removed, FieldDiff{Path: path, Old: element})
+		}
+	}
+	for _, element := range modified {
+		if !listContains(original, element) {
+			diff.Added = append(diff.Added, FieldDiff{Path: path, New: element})
+		}
+	}
+}
+
+// diffKeyedLists pairs the entries of two map lists by their key fields and
+// recurses into paired entries, so a change inside one entry does not report
+// the whole list as modified.
+func diffKeyedLists(path string, original, modified []interface{}, keys []string, diff *ObjectDiff) {
+	originalByKey := indexListByKeys(original, keys)
+	modifiedByKey := indexListByKeys(modified, keys)
+	for key, originalEntry := range originalByKey {
+		modifiedEntry, ok := modifiedByKey[key]
+		if !ok {
+			diff.Removed = append(diff.Removed, FieldDiff{Path: path, Old: originalEntry})
+			continue
+		}
+		diffValues(path, originalEntry, modifiedEntry, diff)
+	}
+	for key, modifiedEntry := range modifiedByKey {
+		if _, ok := originalByKey[key]; !ok {
+			diff.Added = append(diff.Added, FieldDiff{Path: path, New: modifiedEntry})
+		}
+	}
+}
+
+func indexListByKeys(list []interface{}, keys []string) map[string]interface{} {
+	index := map[string]interface{}{}
+	for _, element := range list {
+		index[elementOrderKey(element, keys)] = element
+	}
+	return index
+}
+
+func listContains(list []interface{}, element interface{}) bool {
+	for i := range list {
+		if reflect.DeepEqual(list[i], element) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func TestDiffObjectsGranularMaps(t *testing.T) {
+	original := &api.Pod{ObjectMeta: api.ObjectMeta{
+		Name:   "pod",
+		Labels: map[string]string{"app": "web", "tier": "frontend"},
+	}}
+	modified := &api.Pod{ObjectMeta: api.ObjectMeta{
+		Name:   "pod",
+		Labels: map[string]string{"app": "api", "env": "prod"},
+	}}
+
+	diff, err := DiffObjects(original, modified)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedAdded := []FieldDiff{{Path: "metadata.labels.env", New: "prod"}}
+	if !reflect.DeepEqual(diff.Added, expectedAdded) {
+		t.Errorf("expected added %v, got %v", expectedAdded, diff.Added)
+	}
+	expectedRemoved := []FieldDiff{{Path: "metadata.labels.tier", Old: "frontend"}}
+	if !reflect.DeepEqual(diff.Removed, expectedRemoved) {
+		t.Errorf("expected removed %v, got %v", expectedRemoved, diff.Removed)
+	}
+	expectedModified := []FieldDiff{{Path: "metadata.labels.app", Old: "web", New: "api"}}
+	if !reflect.DeepEqual(diff.Modified, expectedModified) {
+		t.Errorf("expected modified %v, got %v", expectedModified, diff.Modified)
+	}
+}
+
+func TestDiffObjectsSetLists(t *testing.T) {
+	original := &api.Pod{ObjectMeta: api.ObjectMeta{
+		Name:       "pod",
+		Finalizers: []string{"example.com/cleanup", "example.com/audit"},
+	}}
+	modified := &api.Pod{ObjectMeta: api.ObjectMeta{
+		Name:       "pod",
+		Finalizers: []string{"example.com/cleanup", "example.com/archive"},
+	}}
+
+	diff, err := DiffObjects(original, modified)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedAdded := []FieldDiff{{Path: "metadata.finalizers", New: "example.com/archive"}}
+	if !reflect.DeepEqual(diff.Added, expectedAdded) {
+		t.Errorf("expected added %v, got %v", expectedAdded, diff.Added)
+	}
+	expectedRemoved := []FieldDiff{{Path: "metadata.finalizers", Old: "example.com/audit"}}
+	if !reflect.DeepEqual(diff.Removed, expectedRemoved) {
+		t.Errorf("expected removed %v, got %v", expectedRemoved, diff.Removed)
+	}
+	if len(diff.Modified) != 0 {
+		t.Errorf("expected the shared element to not be reported, got %v", diff.Modified)
+	}
+}
+
+func TestDiffObjectsKeyedLists(t *testing.T) {
+	controller := true
+	original := &api.Pod{ObjectMeta: api.ObjectMeta{
+		Name: "pod",
+		OwnerReferences: []api.OwnerReference{
+			{UID: "rs-1", Name: "web-1", Kind: "ReplicaSet"},
+			{UID: "rs-2", Name: "web-2", Kind: "ReplicaSet"},
+		},
+	}}
+	modified := &api.Pod{ObjectMeta: api.ObjectMeta{
+		Name: "pod",
+		OwnerReferences: []api.OwnerReference{
+			{UID: "rs-1", Name: "web-1", Kind: "ReplicaSet", Controller: &controller},
+			{UID: "rs-3", Name: "web-3", Kind: "ReplicaSet"},
+		},
+	}}
+
+	diff, err := DiffObjects(original, modified)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the rs-1 entry is paired by uid, so only its changed field is reported
+	if len(diff.Modified) != 0 {
+		t.Errorf("expected the controller flag to be reported as added, got modified %v", diff.Modified)
+	}
+	foundController := false
+	for _, added := range diff.Added {
+		switch added.Path {
+		case "metadata.ownerReferences.controller":
+			foundController = true
+		case "metadata.ownerReferences":
+			entry, ok := added.New.(map[string]interface{})
+			if !ok || entry["uid"] != "rs-3" {
+				t.Errorf("expected only the rs-3 entry to be added whole, got %v", added.New)
+			}
+		default:
+			t.Errorf("unexpected added field %v", added)
+		}
+	}
+	if !foundController {
+		t.Errorf("expected the controller flag inside the paired entry to be reported, got %v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Path != "metadata.ownerReferences" {
+		t.Errorf("expected only the rs-2 entry to be removed whole, got %v", diff.Removed)
+	}
+}
+
+func TestDiffValuesAtomicMap(t *testing.T) {
+	diff := &ObjectDiff{}
+	// spec.selector is registered atomic: a key-level change reports the
+	// whole map
+	diffValues("spec.selector",
+		map[string]interface{}{"app": "web", "tier": "frontend"},
+		map[string]interface{}{"app": "web", "tier": "backend"},
+		diff)
+	if len(diff.Added) != 0 || len(diff.Removed) != 0 || len(diff.Modified) != 1 {
+		t.Fatalf("expected one modified field, got %+v", diff)
+	}
+	if diff.Modified[0].Path != "spec.selector" {
+		t.Errorf("expected the whole map at spec.selector, got %v", diff.Modified)
+	}
+}
+
+func TestDiffObjectsEmpty(t *testing.T) {
+	pod := &api.Pod{ObjectMeta: api.ObjectMeta{Name: "pod", Labels: map[string]string{"app": "web"}}}
+	diff, err := DiffObjects(pod, pod)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !diff.Empty() {
+		t.Errorf("expected identical objects to produce an empty diff, got %+v", diff)
+	}
+}
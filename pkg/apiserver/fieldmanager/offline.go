@@ -0,0 +1,259 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// GitOps controllers and CI pipelines want to know what an apply would do
+// before doing it. OfflineApply computes the merged object, the conflicts,
+// and the resulting managedFields from a live object, an apply
+// configuration, and the current entries, without contacting an apiserver.
+// It operates on raw JSON so it needs no registered types; merge semantics
+// for lists and maps come from the registered schema rules.
+
+// OfflineMergeResult is the outcome of an offline apply.
+type OfflineMergeResult struct {
+	// Merged is the object as it would look after the apply.
+	Merged map[string]interface{}
+	// Managed is the managedFields as they would look after the apply.
+	Managed []api.ManagedFieldsEntry
+	// Conflicts lists fields owned by other appliers that the
+	// configuration would change. Non-empty only when force is false; a
+	// forced apply takes the fields instead.
+	Conflicts []string
+}
+
+// OfflineApply merges the apply configuration over the live object and
+// reports conflicts against the given managedFields, as an apiserver
+// running this package's manager chain would.
+func OfflineApply(live, config []byte, managed []api.ManagedFieldsEntry, manager string, force bool) (*OfflineMergeResult, error) {
+	liveMap := map[string]interface{}{}
+	if len(live) > 0 {
+		if err := json.Unmarshal(live, &liveMap); err != nil {
+			return nil, fmt.Errorf("failed to parse the live object: %v", err)
+		}
+	}
+	configMap := map[string]interface{}{}
+	if err := json.Unmarshal(config, &configMap); err != nil {
+		return nil, fmt.Errorf("failed to parse the apply configuration: %v", err)
+	}
+
+	applied := leafPaths(configMap, "")
+	conflicts := findConflicts(applied, managed, manager)
+	if len(conflicts) > 0 && !force {
+		return &OfflineMergeResult{Merged: liveMap, Managed: managed, Conflicts: conflicts}, nil
+	}
+
+	merged := mergeValueMaps(liveMap, configMap, "")
+	now := unversioned.NewTime(time.Now())
+	fields, err := fieldsV1FromPaths(applied)
+	if err != nil {
+		return nil, err
+	}
+	out := updateApplyEntry(managed, manager, fields, &now)
+	return &OfflineMergeResult{Merged: merged, Managed: out}, nil
+}
+
+// leafPaths returns the dot-separated paths of every leaf in the
+// configuration, sorted for stable output.
+func leafPaths(value map[string]interface{}, path string) []string {
+	var paths []string
+	for key, entry := range value {
+		entryPath := joinFieldPath(path, key)
+		if nested, ok := entry.(map[string]interface{}); ok && len(nested) > 0 {
+			paths = append(paths, leafPaths(nested, entryPath)...)
+			continue
+		}
+		paths = append(paths, entryPath)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// findConflicts returns the applied paths owned by a different applier.
+// Update entries do not conflict: apply takes over fields last written by
+// ordinary updates.
+func findConflicts(applied []string, managed []api.ManagedFieldsEntry, manager string) []string {
+	var conflicts []string
+	for _, entry := range managed {
+		if entry.Manager == manager || entry.Operation != api.ManagedFieldsOperationApply {
+			continue
+		}
+		owned, err := entryLeafPaths(entry)
+		if err != nil {
+			// unparseable field sets conservatively own nothing here; the
+			// server resets them on the next write
+			continue
+		}
+		for _, path := range applied {
+			if owned[path] {
+				conflicts = append(conflicts, fmt.Sprintf("%s (owned by %q)", path, entry.Manager))
+			}
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+// entryLeafPaths parses an entry's field set into a set of dot-separated
+// paths, stripping the "f:" key prefixes of the stored form.
+func entryLeafPaths(entry api.ManagedFieldsEntry) (map[string]bool, error) {
+	owned := map[string]bool{}
+	if entry.FieldsV1 == nil || len(entry.FieldsV1.Raw) == 0 {
+		return owned, nil
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+		return nil, err
+	}
+	collectFieldPaths(fields, "", owned)
+	return owned, nil
+}
+
+func collectFieldPaths(fields map[string]interface{}, path string, owned map[string]bool) {
+	for key, entry := range fields {
+		entryPath := joinFieldPath(path, strings.TrimPrefix(key, "f:"))
+		if nested, ok := entry.(map[string]interface{}); ok && len(nested) > 0 {
+			collectFieldPaths(nested, entryPath, owned)
+			continue
+		}
+		owned[entryPath] = true
+	}
+}
+
+// fieldsV1FromPaths builds the stored field set form from leaf paths.
+func fieldsV1FromPaths(paths []string) (*api.FieldsV1, error) {
+	fields := map[string]interface{}{}
+	for _, path := range paths {
+		node := fields
+		segments := strings.Split(path, ".")
+		for i, segment := range segments {
+			key := "f:" + segment
+			if i == len(segments)-1 {
+				if _, ok := node[key]; !ok {
+					node[key] = map[string]interface{}{}
+				}
+				break
+			}
+			nested, ok := node[key].(map[string]interface{})
+			if !ok {
+				nested = map[string]interface{}{}
+				node[key] = nested
+			}
+			node = nested
+		}
+	}
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	return &api.FieldsV1{Raw: raw}, nil
+}
+
+// updateApplyEntry refreshes or appends the manager's Apply entry.
+func updateApplyEntry(managed []api.ManagedFieldsEntry, manager string, fields *api.FieldsV1, now *unversioned.Time) []api.ManagedFieldsEntry {
+	out := append([]api.ManagedFieldsEntry(nil), managed...)
+	for i := range out {
+		if out[i].Manager == manager && out[i].Operation == api.ManagedFieldsOperationApply {
+			out[i].Time = now
+			out[i].FieldsType = "FieldsV1"
+			out[i].FieldsV1 = fields
+			return out
+		}
+	}
+	return append(out, api.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  api.ManagedFieldsOperationApply,
+		APIVersion: runtime.APIVersionInternal,
+		Time:       now,
+		FieldsType: "FieldsV1",
+		FieldsV1:   fields,
+	})
+}
+
+// mergeValueMaps merges the configuration over the live map. Maps merge
+// per key unless registered atomic; lists follow the registered list rules
+// (atomic by default, union for sets, per-entry in canonical key order for
+// keyed lists); null in the configuration removes the field.
+func mergeValueMaps(live, config map[string]interface{}, path string) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for key, value := range live {
+		merged[key] = value
+	}
+	for key, value := range config {
+		entryPath := joinFieldPath(path, key)
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		if configMap, ok := value.(map[string]interface{}); ok {
+			if liveMap, ok := merged[key].(map[string]interface{}); ok && LookupMapRule(entryPath) != MapTypeAtomic {
+				merged[key] = mergeValueMaps(liveMap, configMap, entryPath)
+				continue
+			}
+			merged[key] = configMap
+			continue
+		}
+		if configList, ok := value.([]interface{}); ok {
+			liveList, hasLive := merged[key].([]interface{})
+			switch rule := LookupListRule(entryPath); rule.Type {
+			case ListTypeSet:
+				if hasLive {
+					merged[key] = unionLists(liveList, configList)
+					continue
+				}
+			case ListTypeMap:
+				if !hasLive {
+					liveList = nil
+				}
+				merged[key] = mergeKeyedLists(liveList, configList, rule.Keys, entryPath)
+				continue
+			}
+			merged[key] = configList
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+func unionLists(live, config []interface{}) []interface{} {
+	out := append([]interface{}(nil), live...)
+	for _, value := range config {
+		found := false
+		for _, existing := range out {
+			if existing == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, value)
+		}
+	}
+	return out
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func TestOfflineApplyMerges(t *testing.T) {
+	live := []byte(`{"metadata":{"name":"foo","labels":{"app":"web"}},"spec":{"replicas":3}}`)
+	config := []byte(`{"metadata":{"labels":{"tier":"frontend"}},"spec":{"replicas":5}}`)
+
+	result, err := OfflineApply(live, config, nil, "deploy-tool", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Conflicts) != 0 {
+		t.Fatalf("expected no conflicts, got %v", result.Conflicts)
+	}
+	metadata := result.Merged["metadata"].(map[string]interface{})
+	labels := metadata["labels"].(map[string]interface{})
+	if labels["app"] != "web" || labels["tier"] != "frontend" {
+		t.Errorf("expected labels to merge per key, got %v", labels)
+	}
+	spec := result.Merged["spec"].(map[string]interface{})
+	if spec["replicas"] != float64(5) {
+		t.Errorf("expected the configured replicas to win, got %v", spec["replicas"])
+	}
+	if len(result.Managed) != 1 || result.Managed[0].Manager != "deploy-tool" || result.Managed[0].Operation != api.ManagedFieldsOperationApply {
+		t.Errorf("expected an apply entry for the manager, got %v", result.Managed)
+	}
+}
+
+func TestOfflineApplyNullRemoves(t *testing.T) {
+	live := []byte(`{"metadata":{"labels":{"app":"web","tier":"frontend"}}}`)
+	config := []byte(`{"metadata":{"labels":{"tier":null}}}`)
+
+	result, err := OfflineApply(live, config, nil, "deploy-tool", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	labels := result.Merged["metadata"].(map[string]interface{})["labels"].(map[string]interface{})
+	if _, present := labels["tier"]; present {
+		t.Errorf("expected null to remove the field, got %v", labels)
+	}
+	if labels["app"] != "web" {
+		t.Errorf("expected unrelated fields to survive, got %v", labels)
+	}
+}
+
+func TestOfflineApplySetListsUnion(t *testing.T) {
+	live := []byte(`{"metadata":{"finalizers":["a","b"]}}`)
+	config := []byte(`{"metadata":{"finalizers":["b","c"]}}`)
+
+	result, err := OfflineApply(live, config, nil, "deploy-tool", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	finalizers := result.Merged["metadata"].(map[string]interface{})["finalizers"].([]interface{})
+	if !reflect.DeepEqual(finalizers, []interface{}{"a", "b", "c"}) {
+		t.Errorf("expected set lists to union, got %v", finalizers)
+	}
+}
+
+func TestOfflineApplyAtomicListsReplace(t *testing.T) {
+	live := []byte(`{"spec":{"containers":[{"name":"a"},{"name":"b"}]}}`)
+	config := []byte(`{"spec":{"containers":[{"name":"c"}]}}`)
+
+	result, err := OfflineApply(live, config, nil, "deploy-tool", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	containers := result.Merged["spec"].(map[string]interface{})["containers"].([]interface{})
+	if len(containers) != 1 {
+		t.Errorf("expected unregistered lists to replace atomically, got %v", containers)
+	}
+}
+
+func TestOfflineApplyReportsConflicts(t *testing.T) {
+	live := []byte(`{"spec":{"replicas":3}}`)
+	config := []byte(`{"spec":{"replicas":5}}`)
+	owner := validEntry("other-tool", 0)
+	owner.Operation = api.ManagedFieldsOperationApply
+	owner.FieldsV1 = &api.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)}
+	managed := []api.ManagedFieldsEntry{owner}
+
+	result, err := OfflineApply(live, config, managed, "deploy-tool", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Conflicts) != 1 {
+		t.Fatalf("expected one conflict, got %v", result.Conflicts)
+	}
+	spec := result.Merged["spec"].(map[string]interface{})
+	if spec["replicas"] != float64(3) {
+		t.Errorf("expected a refused apply to leave the live object alone, got %v", spec["replicas"])
+	}
+
+	result, err = OfflineApply(live, config, managed, "deploy-tool", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Conflicts) != 0 {
+		t.Errorf("expected force to suppress conflicts, got %v", result.Conflicts)
+	}
+	spec = result.Merged["spec"].(map[string]interface{})
+	if spec["replicas"] != float64(5) {
+		t.Errorf("expected force to take the field, got %v", spec["replicas"])
+	}
+}
+
+func TestOfflineApplyRefreshesEntries(t *testing.T) {
+	entry := validEntry("deploy-tool", 0)
+	entry.Operation = api.ManagedFieldsOperationApply
+
+	result, err := OfflineApply(nil, []byte(`{"spec":{"replicas":1}}`), []api.ManagedFieldsEntry{entry}, "deploy-tool", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Managed) != 1 {
+		t.Fatalf("expected the entry to be refreshed, not duplicated, got %v", result.Managed)
+	}
+	if string(result.Managed[0].FieldsV1.Raw) != `{"f:spec":{"f:replicas":{}}}` {
+		t.Errorf("expected the field set to be rebuilt from the configuration, got %s", result.Managed[0].FieldsV1.Raw)
+	}
+}
+
+func TestOfflineApplyRejectsBadInput(t *testing.T) {
+	if _, err := OfflineApply([]byte(`not json`), []byte(`{}`), nil, "m", false); err == nil {
+		t.Errorf("expected an unparseable live object to error")
+	}
+	if _, err := OfflineApply(nil, []byte(`not json`), nil, "m", false); err == nil {
+		t.Errorf("expected an unparseable configuration to error")
+	}
+}
+
+func TestOfflineApplyAtomicMapsReplace(t *testing.T) {
+	live := []byte(`{"spec":{"nodeSelector":{"zone":"a","disk":"ssd"}}}`)
+	config := []byte(`{"spec":{"nodeSelector":{"zone":"b"}}}`)
+
+	result, err := OfflineApply(live, config, nil, "deploy-tool", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	selector := result.Merged["spec"].(map[string]interface{})["nodeSelector"].(map[string]interface{})
+	if !reflect.DeepEqual(selector, map[string]interface{}{"zone": "b"}) {
+		t.Errorf("expected the atomic map to replace wholesale, got %v", selector)
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/types"
+)
+
+// FieldsStore persists managedFields entries separately from the object they
+// describe, keyed by the object's UID. Most reads of an object do not need
+// ownership data; storing it out-of-band keeps the main object small and
+// pays the join cost only on the requests that ask for it. Implementations
+// typically write under a dedicated etcd key alongside the resource.
+type FieldsStore interface {
+	// Save replaces the stored entries for uid.
+	Save(uid types.UID, managed []api.ManagedFieldsEntry) error
+	// Load returns the stored entries for uid, or nil if none are stored.
+	Load(uid types.UID) ([]api.ManagedFieldsEntry, error)
+	// Delete removes the stored entries for uid. Deleting entries that do
+	// not exist is not an error.
+	Delete(uid types.UID) error
+}
+
+// DetachManagedFields moves the object's managedFields into the store before
+// the object is written. The object must already have a UID.
+func DetachManagedFields(obj runtime.Object, store FieldsStore) error {
+	objectMeta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		return err
+	}
+	if len(objectMeta.ManagedFields) == 0 {
+		return nil
+	}
+	if len(objectMeta.UID) == 0 {
+		return fmt.Errorf("cannot detach managedFields from an object without a UID")
+	}
+	if err := store.Save(objectMeta.UID, objectMeta.ManagedFields); err != nil {
+		return err
+	}
+	objectMeta.ManagedFields = nil
+	return nil
+}
+
+// JoinManagedFields restores the object's managedFields from the store, for
+// the reads that asked for ownership data. Objects that were never detached
+// (no stored entries) are returned unchanged.
+func JoinManagedFields(obj runtime.Object, store FieldsStore) error {
+	objectMeta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		return err
+	}
+	if len(objectMeta.UID) == 0 {
+		return nil
+	}
+	managed, err := store.Load(objectMeta.UID)
+	if err != nil {
+		return err
+	}
+	if managed != nil {
+		objectMeta.ManagedFields = managed
+	}
+	return nil
+}
+
+// memoryFieldsStore is an in-process FieldsStore. It serves as the reference
+// implementation for tests; production configurations provide one backed by
+// the resource's storage.
+type memoryFieldsStore struct {
+	lock    sync.Mutex
+	entries map[types.UID][]api.ManagedFieldsEntry
+}
+
+// NewMemoryFieldsStore returns a FieldsStore that keeps entries in memory.
+func NewMemoryFieldsStore() FieldsStore {
+	return &memoryFieldsStore{entries: map[types.UID][]api.ManagedFieldsEntry{}}
+}
+
+func (s *memoryFieldsStore) Save(uid types.UID, managed []api.ManagedFieldsEntry) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	stored := make([]api.ManagedFieldsEntry, len(managed))
+	copy(stored, managed)
+	s.entries[uid] = stored
+	return nil
+}
+
+func (s *memoryFieldsStore) Load(uid types.UID) ([]api.ManagedFieldsEntry, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	stored, ok := s.entries[uid]
+	if !ok {
+		return nil, nil
+	}
+	managed := make([]api.ManagedFieldsEntry, len(stored))
+	copy(managed, stored)
+	return managed, nil
+}
+
+func (s *memoryFieldsStore) Delete(uid types.UID) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.entries, uid)
+	return nil
+}
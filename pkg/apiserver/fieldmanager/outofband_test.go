@@ -0,0 +1,95 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func TestDetachAndJoinManagedFields(t *testing.T) {
+	store := NewMemoryFieldsStore()
+	managed := []api.ManagedFieldsEntry{
+		{Manager: "kubectl", Operation: api.ManagedFieldsOperationApply, APIVersion: "v1", FieldsV1: &api.FieldsV1{Raw: []byte(`{"f:spec":{}}`)}},
+	}
+	pod := &api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo", UID: "uid-1", ManagedFields: managed}}
+
+	if err := DetachManagedFields(pod, store); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pod.ManagedFields != nil {
+		t.Errorf("expected the entries to be moved off the object, got %#v", pod.ManagedFields)
+	}
+
+	if err := JoinManagedFields(pod, store); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(pod.ManagedFields, managed) {
+		t.Errorf("unexpected entries after join:\n%#v\n%#v", pod.ManagedFields, managed)
+	}
+}
+
+func TestDetachManagedFieldsRequiresUID(t *testing.T) {
+	store := NewMemoryFieldsStore()
+	pod := &api.Pod{ObjectMeta: api.ObjectMeta{
+		Name:          "foo",
+		ManagedFields: []api.ManagedFieldsEntry{{Manager: "kubectl"}},
+	}}
+	if err := DetachManagedFields(pod, store); err == nil {
+		t.Errorf("expected an error detaching from an object without a UID")
+	}
+}
+
+func TestJoinManagedFieldsWithoutStoredEntries(t *testing.T) {
+	store := NewMemoryFieldsStore()
+	managed := []api.ManagedFieldsEntry{{Manager: "kubectl"}}
+	pod := &api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo", UID: "uid-1", ManagedFields: managed}}
+
+	// nothing stored for the UID: the object keeps what it carries
+	if err := JoinManagedFields(pod, store); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(pod.ManagedFields, managed) {
+		t.Errorf("unexpected entries: %#v", pod.ManagedFields)
+	}
+}
+
+func TestMemoryFieldsStoreCopies(t *testing.T) {
+	store := NewMemoryFieldsStore()
+	managed := []api.ManagedFieldsEntry{{Manager: "kubectl"}}
+	if err := store.Save("uid-1", managed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	managed[0].Manager = "mutated"
+
+	loaded, err := store.Load("uid-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded[0].Manager != "kubectl" {
+		t.Errorf("expected the store to hold a copy, got %q", loaded[0].Manager)
+	}
+
+	if err := store.Delete("uid-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded, err := store.Load("uid-1"); err != nil || loaded != nil {
+		t.Errorf("expected no entries after delete, got %#v, err=%v", loaded, err)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// PruneFieldsTransform returns a transformation that removes the given field
+// paths (dot-separated json names, e.g. "spec.deprecatedField") from applied
+// configurations. Administrators point it at fields slated for removal: the
+// apply still succeeds, warn is invoked once per pruned path so the caller
+// can surface a Warning to the user, and the deprecated value never reaches
+// the merge.
+func PruneFieldsTransform(paths []string, warn func(message string)) TransformFunc {
+	parsed := make([][]string, 0, len(paths))
+	for _, path := range paths {
+		parsed = append(parsed, strings.Split(path, "."))
+	}
+	return func(obj runtime.Object) (string, error) {
+		var pruned []string
+		for i, path := range parsed {
+			removed, err := pruneFieldPath(reflect.ValueOf(obj), path)
+			if err != nil {
+				return "", fmt.Errorf("failed to prune %q: %v", paths[i], err)
+			}
+			if removed {
+				pruned = append(pruned, paths[i])
+				if warn != nil {
+					warn(fmt.Sprintf("field %q is deprecated and was removed from the apply configuration", paths[i]))
+				}
+			}
+		}
+		if len(pruned) == 0 {
+			return "", nil
+		}
+		return fmt.Sprintf("pruned deprecated fields %v", pruned), nil
+	}
+}
+
+// pruneFieldPath zeroes the field at the json path below value, returning
+// whether a non-zero value was removed. Paths that do not resolve are not an
+// error: the applier simply did not send the field.
+func pruneFieldPath(value reflect.Value, path []string) (bool, error) {
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return false, nil
+		}
+		value = value.Elem()
+	}
+
+	name := path[0]
+	switch value.Kind() {
+	case reflect.Struct:
+		field, ok := fieldByJSONName(value, name)
+		if !ok {
+			return false, nil
+		}
+		if len(path) > 1 {
+			return pruneFieldPath(field, path[1:])
+		}
+		if !field.CanSet() {
+			return false, fmt.Errorf("field %q cannot be set", name)
+		}
+		if isZeroValue(field) {
+			return false, nil
+		}
+		field.Set(reflect.Zero(field.Type()))
+		return true, nil
+	case reflect.Map:
+		key := reflect.ValueOf(name)
+		if !key.Type().AssignableTo(value.Type().Key()) {
+			return false, nil
+		}
+		entry := value.MapIndex(key)
+		if !entry.IsValid() {
+			return false, nil
+		}
+		if len(path) > 1 {
+			return false, fmt.Errorf("cannot prune below map entry %q", name)
+		}
+		value.SetMapIndex(key, reflect.Value{})
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+func fieldByJSONName(value reflect.Value, name string) (reflect.Value, bool) {
+	t := value.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if jsonName(field) == name {
+			return value.Field(i), true
+		}
+		// embedded fields without their own json name are flattened
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			if inner, ok := fieldByJSONName(value.Field(i), name); ok {
+				return inner, true
+			}
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func isZeroValue(value reflect.Value) bool {
+	return reflect.DeepEqual(value.Interface(), reflect.Zero(value.Type()).Interface())
+}
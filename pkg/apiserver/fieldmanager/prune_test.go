@@ -0,0 +1,90 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func TestPruneFieldsTransform(t *testing.T) {
+	var warnings []string
+	transform := PruneFieldsTransform(
+		[]string{"spec.activeDeadlineSeconds", "metadata.labels.deprecated", "spec.nodeName"},
+		func(message string) { warnings = append(warnings, message) },
+	)
+
+	deadline := int64(30)
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			Name:   "foo",
+			Labels: map[string]string{"deprecated": "true", "app": "web"},
+		},
+		Spec: api.PodSpec{ActiveDeadlineSeconds: &deadline},
+	}
+	description, err := transform(pod)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pod.Spec.ActiveDeadlineSeconds != nil {
+		t.Errorf("expected the deprecated field to be pruned")
+	}
+	if _, ok := pod.Labels["deprecated"]; ok {
+		t.Errorf("expected the deprecated label to be pruned, got %#v", pod.Labels)
+	}
+	if pod.Labels["app"] != "web" {
+		t.Errorf("expected other labels to survive, got %#v", pod.Labels)
+	}
+	// nodeName was never set, so it is neither pruned nor warned about
+	if len(warnings) != 2 {
+		t.Errorf("expected a warning per pruned field, got %v", warnings)
+	}
+	if len(description) == 0 {
+		t.Errorf("expected the transformation to describe what it pruned")
+	}
+
+	// a second pass prunes nothing
+	description, err = transform(pod)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(description) != 0 {
+		t.Errorf("expected nothing left to prune, got %q", description)
+	}
+}
+
+func TestPruneFieldsTransformInChain(t *testing.T) {
+	manager := NewTransformManager(
+		NewStampManager("v1"),
+		PruneFieldsTransform([]string{"spec.nodeName"}, nil),
+	)
+	applied := &api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: "foo"},
+		Spec:       api.PodSpec{NodeName: "node-1"},
+	}
+	obj, _, err := manager.Apply(&api.Pod{}, applied, nil, "kubectl", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pod := obj.(*api.Pod); pod.Spec.NodeName != "" {
+		t.Errorf("expected the pruned field not to reach the merge, got %q", pod.Spec.NodeName)
+	}
+	if applied.Spec.NodeName != "node-1" {
+		t.Errorf("expected the incoming object to be left untouched")
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"k8s.io/kubernetes/pkg/api/unversioned"
+)
+
+// Conflict details name the field being fought over and, to be actionable,
+// its current value. For Secrets and token-bearing fields that value is the
+// thing being protected, so it must never appear in a Status or a Warning
+// header that any conflicting writer gets to read. Redaction rules are
+// per-resource and on by default for secrets and common credential field
+// names.
+
+// CauseTypeFieldManagerConflict is the cause type used on conflict
+// StatusDetails produced for apply conflicts.
+const CauseTypeFieldManagerConflict unversioned.CauseType = "FieldManagerConflict"
+
+// redactedValue replaces sensitive current values in conflict details.
+const redactedValue = "<redacted>"
+
+var (
+	redactionLock sync.RWMutex
+	// sensitiveResources lists resources whose field values never appear
+	// in conflict details.
+	sensitiveResources = map[string]bool{}
+	// sensitiveFieldSuffixes flags fields on any resource whose last path
+	// segment carries one of these suffixes.
+	sensitiveFieldSuffixes = []string{}
+)
+
+// RegisterSensitiveResource hides every field value of the given resource
+// from conflict details.
+func RegisterSensitiveResource(resource string) {
+	redactionLock.Lock()
+	defer redactionLock.Unlock()
+	sensitiveResources[resource] = true
+}
+
+// RegisterSensitiveFieldSuffix hides values of fields whose name ends with
+// the given suffix, on every resource.
+func RegisterSensitiveFieldSuffix(suffix string) {
+	redactionLock.Lock()
+	defer redactionLock.Unlock()
+	sensitiveFieldSuffixes = append(sensitiveFieldSuffixes, strings.ToLower(suffix))
+}
+
+// SensitiveValue returns true when the current value of the given field may
+// not appear in conflict details for the given resource.
+func SensitiveValue(resource, fieldPath string) bool {
+	redactionLock.RLock()
+	defer redactionLock.RUnlock()
+	if sensitiveResources[resource] {
+		return true
+	}
+	segment := fieldPath
+	if dot := strings.LastIndex(fieldPath, "."); dot >= 0 {
+		segment = fieldPath[dot+1:]
+	}
+	segment = strings.ToLower(segment)
+	for _, suffix := range sensitiveFieldSuffixes {
+		if strings.HasSuffix(segment, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ConflictCause builds the StatusCause for one conflicting field, redacting
+// the current value when the redaction rules require it.
+func ConflictCause(resource, fieldPath, currentValue, owner string) unversioned.StatusCause {
+	if SensitiveValue(resource, fieldPath) {
+		currentValue = redactedValue
+	}
+	return unversioned.StatusCause{
+		Type:    CauseTypeFieldManagerConflict,
+		Field:   fieldPath,
+		Message: fmt.Sprintf("conflict with %q: the current value is %s", owner, currentValue),
+	}
+}
+
+// ConflictWarningHeader builds the Warning header text for one conflicting
+// field, applying the same redaction rules as ConflictCause.
+func ConflictWarningHeader(resource, fieldPath, currentValue, owner string) string {
+	if SensitiveValue(resource, fieldPath) {
+		currentValue = redactedValue
+	}
+	return fmt.Sprintf("field %s is owned by %q (current value %s)", fieldPath, owner, currentValue)
+}
+
+func init() {
+	// secret data must never leak through a conflict, and token-bearing
+	// fields show up on other resources too
+	RegisterSensitiveResource("secrets")
+	RegisterSensitiveFieldSuffix("token")
+	RegisterSensitiveFieldSuffix("password")
+}
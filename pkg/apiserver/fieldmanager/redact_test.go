@@ -0,0 +1,74 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSensitiveValue(t *testing.T) {
+	tests := []struct {
+		resource  string
+		fieldPath string
+		sensitive bool
+	}{
+		// every secret field is sensitive, name notwithstanding
+		{"secrets", "data.username", true},
+		{"secrets", "metadata.labels.app", true},
+		// token-bearing fields are sensitive on any resource
+		{"serviceaccounts", "secrets.token", true},
+		{"configmaps", "data.registryPassword", true},
+		// ordinary fields are not
+		{"pods", "spec.nodeName", false},
+		{"configmaps", "data.config", false},
+	}
+	for _, test := range tests {
+		if got := SensitiveValue(test.resource, test.fieldPath); got != test.sensitive {
+			t.Errorf("%s %s: expected sensitive=%v, got %v", test.resource, test.fieldPath, test.sensitive, got)
+		}
+	}
+}
+
+func TestConflictCauseRedaction(t *testing.T) {
+	cause := ConflictCause("secrets", "data.password", "hunter2", "deploy-tool")
+	if cause.Type != CauseTypeFieldManagerConflict || cause.Field != "data.password" {
+		t.Errorf("unexpected cause: %#v", cause)
+	}
+	if strings.Contains(cause.Message, "hunter2") {
+		t.Errorf("expected the secret value to be redacted, got %q", cause.Message)
+	}
+	if !strings.Contains(cause.Message, "deploy-tool") {
+		t.Errorf("expected the owner to be named, got %q", cause.Message)
+	}
+
+	cause = ConflictCause("pods", "spec.nodeName", "node-1", "scheduler")
+	if !strings.Contains(cause.Message, "node-1") {
+		t.Errorf("expected ordinary values to be shown, got %q", cause.Message)
+	}
+}
+
+func TestConflictWarningHeaderRedaction(t *testing.T) {
+	warning := ConflictWarningHeader("serviceaccounts", "secrets.token", "abc123", "token-controller")
+	if strings.Contains(warning, "abc123") {
+		t.Errorf("expected the token to be redacted, got %q", warning)
+	}
+	warning = ConflictWarningHeader("pods", "spec.nodeName", "node-1", "scheduler")
+	if !strings.Contains(warning, "node-1") {
+		t.Errorf("expected ordinary values to be shown, got %q", warning)
+	}
+}
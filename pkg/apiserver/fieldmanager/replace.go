@@ -0,0 +1,114 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Some subtrees are only meaningful as a whole: half of one manager's
+// liveness probe merged with half of another's is a probe nobody asked for.
+// A replace rule forces replace semantics for a subtree during apply even
+// when its members would otherwise merge granularly.
+
+var (
+	replaceRulesLock sync.RWMutex
+	replaceRules     = map[string]bool{}
+)
+
+// RegisterReplaceRule declares that the subtree at the given field path is
+// replaced wholesale on apply. Registration is idempotent.
+func RegisterReplaceRule(path string) {
+	replaceRulesLock.Lock()
+	defer replaceRulesLock.Unlock()
+	replaceRules[path] = true
+}
+
+// IsReplaceSubtree returns true when the given field path, or one of its
+// ancestors, is registered for replace semantics.
+func IsReplaceSubtree(path string) bool {
+	replaceRulesLock.RLock()
+	defer replaceRulesLock.RUnlock()
+	for path != "" {
+		if replaceRules[path] {
+			return true
+		}
+		dot := strings.LastIndex(path, ".")
+		if dot < 0 {
+			break
+		}
+		path = path[:dot]
+	}
+	return false
+}
+
+// ReplaceSubtreesFromTags walks the struct type of obj and returns the field
+// paths carrying a `patchStrategy:"replace"` tag, so types can declare
+// replace semantics next to the field instead of in a registration call.
+// The discovered paths are also registered.
+func ReplaceSubtreesFromTags(obj interface{}, basePath string) []string {
+	t := reflect.TypeOf(obj)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var paths []string
+	collectReplaceTags(t, basePath, &paths, map[reflect.Type]bool{})
+	for _, path := range paths {
+		RegisterReplaceRule(path)
+	}
+	return paths
+}
+
+func collectReplaceTags(t reflect.Type, path string, paths *[]string, visited map[reflect.Type]bool) {
+	if visited[t] {
+		return
+	}
+	visited[t] = true
+	defer delete(visited, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldPath := joinFieldPath(path, jsonName(field))
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			fieldPath = path
+		}
+		if field.Tag.Get("patchStrategy") == "replace" {
+			*paths = append(*paths, fieldPath)
+			continue
+		}
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr || fieldType.Kind() == reflect.Slice {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct {
+			collectReplaceTags(fieldType, fieldPath, paths, visited)
+		}
+	}
+}
+
+func init() {
+	// probes are complete definitions: merging one manager's handler with
+	// another's thresholds produces a probe neither of them wrote
+	RegisterReplaceRule("spec.containers.livenessProbe")
+	RegisterReplaceRule("spec.containers.readinessProbe")
+	RegisterReplaceRule("spec.containers.lifecycle")
+}
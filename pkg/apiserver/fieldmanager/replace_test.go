@@ -0,0 +1,57 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+)
+
+func TestIsReplaceSubtree(t *testing.T) {
+	if !IsReplaceSubtree("spec.containers.livenessProbe") {
+		t.Errorf("expected probes to be replace subtrees")
+	}
+	// fields below a replace subtree inherit its semantics
+	if !IsReplaceSubtree("spec.containers.livenessProbe.httpGet.path") {
+		t.Errorf("expected fields below a replace subtree to be covered")
+	}
+	if IsReplaceSubtree("spec.containers.image") {
+		t.Errorf("expected unregistered fields to merge normally")
+	}
+}
+
+type probeShaped struct {
+	Handler   string `json:"handler"`
+	Threshold int    `json:"threshold"`
+}
+
+type taggedSpec struct {
+	Probe    *probeShaped `json:"probe,omitempty" patchStrategy:"replace"`
+	Replicas int          `json:"replicas,omitempty"`
+}
+
+func TestReplaceSubtreesFromTags(t *testing.T) {
+	paths := ReplaceSubtreesFromTags(&taggedSpec{}, "spec")
+	if len(paths) != 1 || paths[0] != "spec.probe" {
+		t.Fatalf("expected the tagged field to be discovered, got %v", paths)
+	}
+	if !IsReplaceSubtree("spec.probe.handler") {
+		t.Errorf("expected the discovered subtree to be registered")
+	}
+	if IsReplaceSubtree("spec.replicas") {
+		t.Errorf("expected untagged fields to merge normally")
+	}
+}
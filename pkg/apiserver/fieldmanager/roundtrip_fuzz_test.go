@@ -0,0 +1,150 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+)
+
+// randomFieldsKey produces the key shapes used by the managed fields format:
+// field members (f:), list keys (k:), values (v:), indices (i:) and the
+// member marker (.), including keys with characters that require JSON
+// escaping.
+func randomFieldsKey(r *rand.Rand) string {
+	names := []string{
+		"name", "image", "spec", "metadata", "a b", `quo"ted`, "uniécode",
+		"dotted.name", "dash-name", "under_score", "\t", "日本語",
+	}
+	name := names[r.Intn(len(names))]
+	switch r.Intn(5) {
+	case 0:
+		return "f:" + name
+	case 1:
+		return fmt.Sprintf(`k:{"port":%d}`, r.Intn(65536))
+	case 2:
+		return fmt.Sprintf("v:%q", name)
+	case 3:
+		return fmt.Sprintf("i:%d", r.Intn(100))
+	default:
+		return "."
+	}
+}
+
+// randomFieldsValue builds a randomly nested managed fields structure. Leaves
+// are empty objects, as produced by the apply encoder.
+func randomFieldsValue(r *rand.Rand, depth int) map[string]interface{} {
+	out := map[string]interface{}{}
+	if depth <= 0 {
+		return out
+	}
+	for i := r.Intn(4); i > 0; i-- {
+		out[randomFieldsKey(r)] = randomFieldsValue(r, depth-r.Intn(3)-1)
+	}
+	return out
+}
+
+func randomManagedFieldsEntry(r *rand.Rand) api.ManagedFieldsEntry {
+	fields := randomFieldsValue(r, 5)
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		panic(err)
+	}
+	operations := []api.ManagedFieldsOperationType{api.ManagedFieldsOperationApply, api.ManagedFieldsOperationUpdate}
+	entry := api.ManagedFieldsEntry{
+		Manager:    fmt.Sprintf("manager-%d", r.Intn(10)),
+		Operation:  operations[r.Intn(len(operations))],
+		APIVersion: "v1",
+		FieldsType: "FieldsV1",
+		FieldsV1:   &api.FieldsV1{Raw: raw},
+	}
+	if r.Intn(2) == 0 {
+		// the wire format carries second granularity only
+		stamp := unversioned.NewTime(time.Unix(r.Int63n(1e9), 0))
+		entry.Time = &stamp
+	}
+	return entry
+}
+
+// TestManagedFieldsRoundTripFuzzed asserts that randomly generated managed
+// fields entries survive an encode/decode cycle exactly, including fields
+// structures with deep nesting and keys the hand-written tables do not cover.
+func TestManagedFieldsRoundTripFuzzed(t *testing.T) {
+	// the seed is fixed so failures are reproducible
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 1000; i++ {
+		entries := make([]api.ManagedFieldsEntry, 0, r.Intn(4)+1)
+		for j := r.Intn(4) + 1; j > 0; j-- {
+			entries = append(entries, randomManagedFieldsEntry(r))
+		}
+
+		data, err := json.Marshal(entries)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		decoded := []api.ManagedFieldsEntry{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(entries) != len(decoded) {
+			t.Fatalf("iteration %d: expected %d entries, got %d", i, len(entries), len(decoded))
+		}
+		for j := range entries {
+			if !managedFieldsEntryEqual(entries[j], decoded[j]) {
+				t.Errorf("iteration %d: entry %d did not round trip:\n%#v\n%#v", i, j, entries[j], decoded[j])
+			}
+		}
+	}
+}
+
+// managedFieldsEntryEqual compares entries semantically: the FieldsV1 raw
+// bytes are compared as parsed JSON because map key order is not preserved by
+// a marshal/unmarshal cycle.
+func managedFieldsEntryEqual(a, b api.ManagedFieldsEntry) bool {
+	if a.Manager != b.Manager || a.Operation != b.Operation || a.APIVersion != b.APIVersion || a.FieldsType != b.FieldsType {
+		return false
+	}
+	if (a.Time == nil) != (b.Time == nil) {
+		return false
+	}
+	if a.Time != nil && !a.Time.Equal(*b.Time) {
+		return false
+	}
+	if (a.FieldsV1 == nil) != (b.FieldsV1 == nil) {
+		return false
+	}
+	if a.FieldsV1 == nil {
+		return true
+	}
+	var av, bv interface{}
+	if err := json.Unmarshal(a.FieldsV1.Raw, &av); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(b.FieldsV1.Raw, &bv); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"bytes"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// SizeBreakdown splits the serialized size of an object into the part the
+// user wrote and the part the server added as managedFields. Size accounting
+// and request limits can use the split to avoid penalizing users for
+// server-added metadata.
+type SizeBreakdown struct {
+	// Total is the full serialized size of the object in bytes.
+	Total int
+	// ManagedFields is the number of bytes attributable to managedFields.
+	ManagedFields int
+}
+
+// UserBytes is the size with the managedFields share excluded.
+func (s SizeBreakdown) UserBytes() int {
+	return s.Total - s.ManagedFields
+}
+
+// MeasureObject returns the size breakdown of obj under the provided encoder:
+// the object is encoded once as-is and once with managedFields stripped, and
+// the difference is the managedFields share.
+func MeasureObject(obj runtime.Object, encoder runtime.Encoder) (SizeBreakdown, error) {
+	var buf bytes.Buffer
+	if err := encoder.Encode(obj, &buf); err != nil {
+		return SizeBreakdown{}, err
+	}
+	breakdown := SizeBreakdown{Total: buf.Len()}
+
+	objectMeta, err := api.ObjectMetaFor(obj)
+	if err != nil || len(objectMeta.ManagedFields) == 0 {
+		return breakdown, nil
+	}
+	stripped, err := api.Scheme.Copy(obj)
+	if err != nil {
+		return SizeBreakdown{}, err
+	}
+	if objectMeta, err := api.ObjectMetaFor(stripped); err == nil {
+		objectMeta.ManagedFields = nil
+	}
+	buf.Reset()
+	if err := encoder.Encode(stripped, &buf); err != nil {
+		return SizeBreakdown{}, err
+	}
+	breakdown.ManagedFields = breakdown.Total - buf.Len()
+	return breakdown, nil
+}
+
+// ExceedsSizeLimit reports whether obj is larger than limit bytes under the
+// provided encoder. With excludeManagedFields set, only the user-written part
+// of the object counts against the limit.
+func ExceedsSizeLimit(obj runtime.Object, encoder runtime.Encoder, limit int, excludeManagedFields bool) (bool, error) {
+	breakdown, err := MeasureObject(obj, encoder)
+	if err != nil {
+		return false, err
+	}
+	size := breakdown.Total
+	if excludeManagedFields {
+		size = breakdown.UserBytes()
+	}
+	return size > limit, nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// jsonEncoder is a minimal runtime.Encoder for sizing tests.
+type jsonEncoder struct{}
+
+func (jsonEncoder) Encode(obj runtime.Object, w io.Writer) error {
+	return json.NewEncoder(w).Encode(obj)
+}
+
+func TestMeasureObject(t *testing.T) {
+	pod := &api.Pod{ObjectMeta: api.ObjectMeta{
+		Name: "foo",
+		ManagedFields: []api.ManagedFieldsEntry{{
+			Manager:    "kubectl",
+			Operation:  api.ManagedFieldsOperationApply,
+			APIVersion: "v1",
+			FieldsType: "FieldsV1",
+			FieldsV1:   &api.FieldsV1{Raw: []byte(`{"f:metadata":{"f:name":{}}}`)},
+		}},
+	}}
+
+	breakdown, err := MeasureObject(pod, jsonEncoder{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if breakdown.ManagedFields <= 0 {
+		t.Errorf("expected a positive managedFields share, got %d", breakdown.ManagedFields)
+	}
+	if breakdown.UserBytes() >= breakdown.Total {
+		t.Errorf("expected the user share %d to be smaller than the total %d", breakdown.UserBytes(), breakdown.Total)
+	}
+	if len(pod.ManagedFields) != 1 {
+		t.Errorf("expected the measured object to be unchanged, got %#v", pod.ManagedFields)
+	}
+
+	// objects without managedFields are encoded only once
+	plain := &api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo"}}
+	breakdown, err = MeasureObject(plain, jsonEncoder{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if breakdown.ManagedFields != 0 {
+		t.Errorf("expected no managedFields share, got %d", breakdown.ManagedFields)
+	}
+	if breakdown.UserBytes() != breakdown.Total {
+		t.Errorf("expected the user share to equal the total, got %d != %d", breakdown.UserBytes(), breakdown.Total)
+	}
+}
+
+func TestExceedsSizeLimit(t *testing.T) {
+	pod := &api.Pod{ObjectMeta: api.ObjectMeta{
+		Name: "foo",
+		ManagedFields: []api.ManagedFieldsEntry{{
+			Manager:    "kubectl",
+			Operation:  api.ManagedFieldsOperationApply,
+			APIVersion: "v1",
+			FieldsType: "FieldsV1",
+			FieldsV1:   &api.FieldsV1{Raw: []byte(`{"f:metadata":{"f:name":{}}}`)},
+		}},
+	}}
+	breakdown, err := MeasureObject(pod, jsonEncoder{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// a limit between the user share and the total is only exceeded when
+	// managedFields count against it
+	limit := breakdown.UserBytes()
+	exceeds, err := ExceedsSizeLimit(pod, jsonEncoder{}, limit, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !exceeds {
+		t.Errorf("expected the full object to exceed the limit %d", limit)
+	}
+	exceeds, err = ExceedsSizeLimit(pod, jsonEncoder{}, limit, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exceeds {
+		t.Errorf("expected the user-written part to fit within the limit %d", limit)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// stampManager is the bottom of the default manager chain. It records which
+// manager performed a write and when, refreshing the manager's existing entry
+// or appending a new one. It does not compute per-field ownership; decorators
+// layered on top can refine the entries it produces.
+type stampManager struct {
+	apiVersion string
+}
+
+var _ Manager = &stampManager{}
+
+// NewStampManager returns a Manager that stamps the acting manager's entry
+// with the operation and the current time on every write. The apiVersion is
+// recorded on entries the stamp creates.
+func NewStampManager(apiVersion string) Manager {
+	return &stampManager{apiVersion: apiVersion}
+}
+
+// NewDefaultManager returns the manager chain the generic registry installs
+// when no per-resource chain is configured: a timed stamp manager operating
+// on internal objects.
+func NewDefaultManager() Manager {
+	return NewTimingManager(NewStampManager(runtime.APIVersionInternal), "Stamp")
+}
+
+// Update implements Manager.
+func (s *stampManager) Update(liveObj, newObj runtime.Object, managed []api.ManagedFieldsEntry, manager string) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	return newObj, s.stamp(managed, manager, api.ManagedFieldsOperationUpdate), nil
+}
+
+// Apply implements Manager.
+func (s *stampManager) Apply(liveObj, appliedObj runtime.Object, managed []api.ManagedFieldsEntry, manager string, force bool) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	return appliedObj, s.stamp(managed, manager, api.ManagedFieldsOperationApply), nil
+}
+
+func (s *stampManager) stamp(managed []api.ManagedFieldsEntry, manager string, operation api.ManagedFieldsOperationType) []api.ManagedFieldsEntry {
+	now := unversioned.NewTime(time.Now())
+	probe := api.ManagedFieldsEntry{Manager: manager, Operation: operation}
+	for i := range managed {
+		if SameIdentity(managed[i], probe) {
+			managed[i].Time = &now
+			return managed
+		}
+	}
+	return append(managed, api.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  operation,
+		APIVersion: s.apiVersion,
+		Time:       &now,
+	})
+}
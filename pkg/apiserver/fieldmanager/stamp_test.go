@@ -0,0 +1,61 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func TestStampManager(t *testing.T) {
+	manager := NewStampManager("v1")
+	live := &api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo"}}
+	updated := &api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo", Labels: map[string]string{"a": "b"}}}
+
+	obj, managed, err := manager.Update(live, updated, nil, "kubelet")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if obj != updated {
+		t.Errorf("expected the updated object to be passed through")
+	}
+	if len(managed) != 1 || managed[0].Manager != "kubelet" || managed[0].Operation != api.ManagedFieldsOperationUpdate {
+		t.Fatalf("expected a single update entry for kubelet, got %#v", managed)
+	}
+	if managed[0].APIVersion != "v1" || managed[0].Time == nil {
+		t.Errorf("expected the entry to be stamped with version and time, got %#v", managed[0])
+	}
+
+	// a second update by the same manager refreshes the entry in place
+	_, managed, err = manager.Update(live, updated, managed, "kubelet")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(managed) != 1 {
+		t.Errorf("expected the existing entry to be refreshed, got %#v", managed)
+	}
+
+	// an apply by the same manager is tracked as a separate entry
+	_, managed, err = manager.Apply(live, updated, managed, "kubelet", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(managed) != 2 || managed[1].Operation != api.ManagedFieldsOperationApply {
+		t.Errorf("expected a separate apply entry, got %#v", managed)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// The merge machinery works on field value maps, but the object it merges
+// usually starts as stored bytes: apply decodes the bytes into a typed
+// object, marshals the object back to JSON, and parses the JSON into a map.
+// For JSON-stored content the first two steps are pure overhead. This fast
+// path goes from stored bytes to the value map directly when the stored
+// form permits it, and falls back to the typed decode when it does not.
+
+// protoEncodingPrefix marks bytes holding a protobuf runtime.Unknown
+// envelope, matching the serializer's prefix.
+var protoEncodingPrefix = []byte{0x6b, 0x38, 0x73, 0x00}
+
+// ValueFromStored returns the field value map for stored bytes. Bare JSON
+// parses directly; a protobuf envelope whose nested content is JSON parses
+// the nested bytes directly; protobuf-encoded nested content still needs
+// the typed round trip, so it is handed to decode. A schema-guided decode
+// of protobuf wire bytes would remove that last fallback, but needs a
+// runtime source of field numbers and names this tree does not have.
+func ValueFromStored(data []byte, decode func([]byte) (runtime.Object, error)) (map[string]interface{}, error) {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		out := map[string]interface{}{}
+		if err := json.Unmarshal(trimmed, &out); err != nil {
+			return nil, fmt.Errorf("failed to parse stored JSON: %v", err)
+		}
+		return out, nil
+	}
+
+	if bytes.HasPrefix(data, protoEncodingPrefix) {
+		unk := &runtime.Unknown{}
+		if err := unk.Unmarshal(data[len(protoEncodingPrefix):]); err != nil {
+			return nil, fmt.Errorf("failed to parse the stored envelope: %v", err)
+		}
+		nested := bytes.TrimLeft(unk.Raw, " \t\r\n")
+		jsonContent := len(unk.ContentType) == 0 || unk.ContentType == runtime.ContentTypeJSON
+		if jsonContent && len(nested) > 0 && nested[0] == '{' {
+			out := map[string]interface{}{}
+			if err := json.Unmarshal(nested, &out); err != nil {
+				return nil, fmt.Errorf("failed to parse the nested JSON: %v", err)
+			}
+			return out, nil
+		}
+	}
+
+	obj, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return objectToMap(obj)
+}
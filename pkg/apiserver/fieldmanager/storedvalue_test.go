@@ -0,0 +1,97 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+func countingDecode(obj runtime.Object, calls *int) func([]byte) (runtime.Object, error) {
+	return func([]byte) (runtime.Object, error) {
+		*calls++
+		return obj, nil
+	}
+}
+
+func TestValueFromStoredJSON(t *testing.T) {
+	calls := 0
+	value, err := ValueFromStored([]byte(`{"metadata":{"name":"foo"}}`), countingDecode(nil, &calls))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected bare JSON to skip the typed decode")
+	}
+	metadata, ok := value["metadata"].(map[string]interface{})
+	if !ok || metadata["name"] != "foo" {
+		t.Errorf("expected the parsed value, got %v", value)
+	}
+}
+
+func TestValueFromStoredEnvelopedJSON(t *testing.T) {
+	unk := &runtime.Unknown{
+		Raw:         []byte(`{"metadata":{"name":"foo"}}`),
+		ContentType: runtime.ContentTypeJSON,
+	}
+	nested, err := unk.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := append(append([]byte(nil), protoEncodingPrefix...), nested...)
+
+	calls := 0
+	value, err := ValueFromStored(data, countingDecode(nil, &calls))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected enveloped JSON to skip the typed decode")
+	}
+	metadata, ok := value["metadata"].(map[string]interface{})
+	if !ok || metadata["name"] != "foo" {
+		t.Errorf("expected the parsed value, got %v", value)
+	}
+}
+
+func TestValueFromStoredProtobufFallsBack(t *testing.T) {
+	unk := &runtime.Unknown{
+		// protobuf-encoded nested content: not parseable without the type
+		Raw: []byte{0x0a, 0x02, 0x08, 0x01},
+	}
+	nested, err := unk.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := append(append([]byte(nil), protoEncodingPrefix...), nested...)
+
+	calls := 0
+	pod := &api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo"}}
+	value, err := ValueFromStored(data, countingDecode(pod, &calls))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected exactly one typed decode, got %d", calls)
+	}
+	metadata, ok := value["metadata"].(map[string]interface{})
+	if !ok || metadata["name"] != "foo" {
+		t.Errorf("expected the decoded object's value, got %v", value)
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// Fields whose previous manager entry was removed or expired are normally
+// free to take without force: nobody is left to conflict with. In clusters
+// where accidental ownership transfer is considered a security problem that
+// is too permissive, so the strict takeover manager turns those silent
+// takeovers into conflicts that still require force=true.
+
+// TakeoverConflictError reports an apply that would silently take over
+// orphaned fields without force.
+type TakeoverConflictError struct {
+	// Manager is the applier that was refused.
+	Manager string
+	// Orphans names the ownership records that would be taken over;
+	// "<none>" stands for an object with no ownership records at all.
+	Orphans []string
+}
+
+func (e *TakeoverConflictError) Error() string {
+	return fmt.Sprintf("apply by manager %q would take over orphaned fields (%v): retry with force=true to acknowledge the takeover", e.Manager, e.Orphans)
+}
+
+// IsTakeoverConflict returns true when the error reports a refused takeover
+// of orphaned fields.
+func IsTakeoverConflict(err error) bool {
+	_, ok := err.(*TakeoverConflictError)
+	return ok
+}
+
+type strictTakeoverManager struct {
+	delegate Manager
+}
+
+var _ Manager = &strictTakeoverManager{}
+
+// NewStrictTakeoverManager wraps a manager so that applies which take over
+// orphaned fields require force. Two situations count as a takeover: the
+// object carries tombstone entries left behind by expiration, or the object
+// pre-exists in storage with no ownership records at all.
+func NewStrictTakeoverManager(delegate Manager) Manager {
+	return &strictTakeoverManager{delegate: delegate}
+}
+
+// Update implements Manager. Ordinary updates never take ownership away
+// from anybody, so they pass through.
+func (s *strictTakeoverManager) Update(liveObj, newObj runtime.Object, managed []api.ManagedFieldsEntry, manager string) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	return s.delegate.Update(liveObj, newObj, managed, manager)
+}
+
+// Apply implements Manager.
+func (s *strictTakeoverManager) Apply(liveObj, appliedObj runtime.Object, managed []api.ManagedFieldsEntry, manager string, force bool) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	orphans := orphanedOwners(liveObj, managed)
+	if len(orphans) > 0 && !force {
+		return nil, nil, &TakeoverConflictError{Manager: manager, Orphans: orphans}
+	}
+	if len(orphans) > 0 {
+		// the takeover was acknowledged: the tombstones' fields now belong
+		// to the applier, so the tombstones themselves are dropped
+		kept := make([]api.ManagedFieldsEntry, 0, len(managed))
+		for _, entry := range managed {
+			if entry.Manager == TombstoneManager {
+				continue
+			}
+			kept = append(kept, entry)
+		}
+		managed = kept
+	}
+	return s.delegate.Apply(liveObj, appliedObj, managed, manager, force)
+}
+
+// orphanedOwners returns the ownership records an apply would silently take
+// over: tombstone entries, or "<none>" when the object pre-exists without
+// any ownership records.
+func orphanedOwners(liveObj runtime.Object, managed []api.ManagedFieldsEntry) []string {
+	var orphans []string
+	for _, entry := range managed {
+		if entry.Manager == TombstoneManager {
+			orphans = append(orphans, fmt.Sprintf("%s/%s", entry.Manager, entry.APIVersion))
+		}
+	}
+	if len(managed) == 0 && preExists(liveObj) {
+		orphans = append(orphans, "<none>")
+	}
+	return orphans
+}
+
+// preExists reports whether the live object has been through storage: a
+// fresh object about to be created has no UID, resource version, or
+// creation timestamp.
+func preExists(liveObj runtime.Object) bool {
+	if liveObj == nil {
+		return false
+	}
+	meta, err := api.ObjectMetaFor(liveObj)
+	if err != nil {
+		return false
+	}
+	return len(meta.UID) > 0 || len(meta.ResourceVersion) > 0 || !meta.CreationTimestamp.IsZero()
+}
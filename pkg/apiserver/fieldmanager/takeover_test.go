@@ -0,0 +1,95 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+func TestStrictTakeoverFreshObject(t *testing.T) {
+	manager := NewStrictTakeoverManager(NewStampManager(runtime.APIVersionInternal))
+
+	// a first apply against an object that never existed is not a takeover
+	_, managed, err := manager.Apply(&api.Pod{}, &api.Pod{}, nil, "deploy-tool", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(managed) != 1 || managed[0].Manager != "deploy-tool" {
+		t.Errorf("expected a fresh apply to be recorded, got %v", managed)
+	}
+}
+
+func TestStrictTakeoverUnmanagedObject(t *testing.T) {
+	manager := NewStrictTakeoverManager(NewStampManager(runtime.APIVersionInternal))
+	live := &api.Pod{ObjectMeta: api.ObjectMeta{UID: "uid-1", ResourceVersion: "7"}}
+
+	// the object pre-exists with no ownership records: force is required
+	_, _, err := manager.Apply(live, &api.Pod{}, nil, "deploy-tool", false)
+	if !IsTakeoverConflict(err) {
+		t.Fatalf("expected a takeover conflict, got %v", err)
+	}
+	if _, _, err := manager.Apply(live, &api.Pod{}, nil, "deploy-tool", true); err != nil {
+		t.Errorf("expected force to acknowledge the takeover, got %v", err)
+	}
+}
+
+func TestStrictTakeoverTombstones(t *testing.T) {
+	manager := NewStrictTakeoverManager(NewStampManager(runtime.APIVersionInternal))
+	managed := []api.ManagedFieldsEntry{
+		validEntry("kubelet", 0),
+		validEntry(TombstoneManager, 0),
+	}
+
+	_, _, err := manager.Apply(&api.Pod{}, &api.Pod{}, managed, "deploy-tool", false)
+	if !IsTakeoverConflict(err) {
+		t.Fatalf("expected a takeover conflict, got %v", err)
+	}
+
+	_, result, err := manager.Apply(&api.Pod{}, &api.Pod{}, managed, "deploy-tool", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, entry := range result {
+		if entry.Manager == TombstoneManager {
+			t.Errorf("expected the acknowledged takeover to drop the tombstone, got %v", result)
+		}
+	}
+	found := map[string]bool{}
+	for _, entry := range result {
+		found[entry.Manager] = true
+	}
+	if !found["kubelet"] || !found["deploy-tool"] {
+		t.Errorf("expected live owners to be kept and the applier recorded, got %v", result)
+	}
+}
+
+func TestStrictTakeoverUpdatePassesThrough(t *testing.T) {
+	manager := NewStrictTakeoverManager(NewStampManager(runtime.APIVersionInternal))
+	live := &api.Pod{ObjectMeta: api.ObjectMeta{UID: "uid-1"}}
+
+	// updates never require force, orphaned fields or not
+	_, managed, err := manager.Update(live, &api.Pod{}, nil, "kubelet")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(managed) != 1 || managed[0].Operation != api.ManagedFieldsOperationUpdate {
+		t.Errorf("expected the update to be recorded, got %v", managed)
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/apiserver/fieldmanager"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// TB is the subset of testing.T the conformance suite reports through, so
+// the suite can be driven from tests in other repositories.
+type TB interface {
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+}
+
+// ConformanceOptions selects which optional apply behaviors the chain under
+// test claims to implement. The base checks always run; optional checks are
+// skipped unless enabled, so a chain is only held to the semantics it
+// advertises.
+type ConformanceOptions struct {
+	// Conflicts indicates that an apply over fields owned by another
+	// manager fails without force and succeeds with it.
+	Conflicts bool
+}
+
+// RunApplyConformance exercises a manager chain against the behaviors every
+// apply implementation must provide: an apply creates an Apply entry for the
+// calling manager, an update creates a separate Update entry, repeated
+// writes by the same manager refresh rather than duplicate entries, and
+// entries carry a timestamp. Distributions and aggregated apiservers can run
+// it against their own chains to verify managedFields behavior.
+func RunApplyConformance(t TB, newManager func() fieldmanager.Manager, options ConformanceOptions) {
+	checkApplyCreates(t, newManager())
+	checkUpdateTracksSeparately(t, newManager())
+	checkRepeatedWritesRefresh(t, newManager())
+	if options.Conflicts {
+		checkConflicts(t, newManager())
+	}
+}
+
+func conformancePod() runtime.Object {
+	return &api.Pod{ObjectMeta: api.ObjectMeta{Namespace: "default", Name: "conformance"}}
+}
+
+func checkApplyCreates(t TB, manager fieldmanager.Manager) {
+	harness := NewTestFieldManager(manager, conformancePod)
+	if err := harness.Apply(conformancePod(), "applier", false); err != nil {
+		t.Fatalf("apply of a new object failed: %v", err)
+	}
+	managed := harness.ManagedFields()
+	if len(managed) != 1 || managed[0].Manager != "applier" || managed[0].Operation != api.ManagedFieldsOperationApply {
+		t.Errorf("expected a single apply entry for the applier, got %#v", managed)
+	}
+	if len(managed) == 1 && managed[0].Time == nil {
+		t.Errorf("expected the apply entry to carry a timestamp")
+	}
+}
+
+func checkUpdateTracksSeparately(t TB, manager fieldmanager.Manager) {
+	harness := NewTestFieldManager(manager, conformancePod)
+	if err := harness.Apply(conformancePod(), "applier", false); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if err := harness.Update(conformancePod(), "updater"); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	managed := harness.ManagedFields()
+	if len(managed) != 2 {
+		t.Fatalf("expected separate entries for the applier and the updater, got %#v", managed)
+	}
+	if managed[1].Manager != "updater" || managed[1].Operation != api.ManagedFieldsOperationUpdate {
+		t.Errorf("expected an update entry for the updater, got %#v", managed[1])
+	}
+}
+
+func checkRepeatedWritesRefresh(t TB, manager fieldmanager.Manager) {
+	harness := NewTestFieldManager(manager, conformancePod)
+	for i := 0; i < 3; i++ {
+		if err := harness.Apply(conformancePod(), "applier", false); err != nil {
+			t.Fatalf("apply %d failed: %v", i, err)
+		}
+	}
+	if managed := harness.ManagedFields(); len(managed) != 1 {
+		t.Errorf("expected repeated applies to refresh a single entry, got %#v", managed)
+	}
+}
+
+func checkConflicts(t TB, manager fieldmanager.Manager) {
+	harness := NewTestFieldManager(manager, conformancePod)
+	contested := &api.Pod{ObjectMeta: api.ObjectMeta{
+		Namespace: "default",
+		Name:      "conformance",
+		Labels:    map[string]string{"owner": "a"},
+	}}
+	if err := harness.Apply(contested, "first", false); err != nil {
+		t.Fatalf("initial apply failed: %v", err)
+	}
+	if err := harness.Apply(contested, "second", false); err == nil {
+		t.Errorf("expected an apply over another manager's fields to conflict")
+	}
+	if err := harness.Apply(contested, "second", true); err != nil {
+		t.Errorf("expected a forced apply to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+var updateGolden = flag.Bool("update-golden", false, "rewrite golden managedFields files with the current output")
+
+// Step is one write in a scripted sequence against a TestFieldManager.
+type Step struct {
+	// Operation is "apply" or "update".
+	Operation string
+	Manager   string
+	Force     bool
+	Object    runtime.Object
+}
+
+// RunScript executes the steps in order against the harness.
+func RunScript(harness *TestFieldManager, steps []Step) error {
+	for i, step := range steps {
+		var err error
+		switch step.Operation {
+		case "apply":
+			err = harness.Apply(step.Object, step.Manager, step.Force)
+		case "update":
+			err = harness.Update(step.Object, step.Manager)
+		default:
+			err = fmt.Errorf("unknown operation %q", step.Operation)
+		}
+		if err != nil {
+			return fmt.Errorf("step %d (%s by %s): %v", i, step.Operation, step.Manager, err)
+		}
+	}
+	return nil
+}
+
+// CanonicalManagedFields renders managedFields in a stable, reviewable form:
+// one line per entry, sorted, with timestamps elided so reruns are
+// comparable.
+func CanonicalManagedFields(managed []api.ManagedFieldsEntry) string {
+	lines := make([]string, 0, len(managed))
+	for _, entry := range managed {
+		fields := "-"
+		if entry.FieldsV1 != nil {
+			fields = string(entry.FieldsV1.Raw)
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s %s", entry.Manager, entry.Operation, entry.APIVersion, fields))
+	}
+	sort.Strings(lines)
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
+// CompareGolden checks the harness's managedFields against the golden file,
+// rewriting it instead when -update-golden is set.
+func CompareGolden(t TB, goldenPath string, harness *TestFieldManager) {
+	actual := CanonicalManagedFields(harness.ManagedFields())
+	if *updateGolden {
+		if err := ioutil.WriteFile(goldenPath, []byte(actual), 0644); err != nil {
+			t.Fatalf("failed to update golden file %s: %v", goldenPath, err)
+		}
+		return
+	}
+	expected, err := ioutil.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("failed to read golden file %s (rerun with -update-golden to create it): %v", goldenPath, err)
+	}
+	if string(expected) != actual {
+		t.Errorf("managedFields differ from %s:\nexpected:\n%s\nactual:\n%s", goldenPath, expected, actual)
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+func TestGoldenManagedFields(t *testing.T) {
+	harness := NewTestFieldManager(nil, func() runtime.Object { return &api.Pod{} })
+	pod := &api.Pod{ObjectMeta: api.ObjectMeta{Namespace: "default", Name: "golden"}}
+	steps := []Step{
+		{Operation: "apply", Manager: "deploy-tool", Object: pod},
+		{Operation: "update", Manager: "kubelet", Object: pod},
+		{Operation: "apply", Manager: "deploy-tool", Object: pod},
+	}
+	if err := RunScript(harness, steps); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	CompareGolden(t, "testdata/apply_update_apply.golden", harness)
+}
+
+func TestCanonicalManagedFieldsIsStable(t *testing.T) {
+	entries := []api.ManagedFieldsEntry{
+		{Manager: "b", Operation: api.ManagedFieldsOperationUpdate, APIVersion: "v1"},
+		{Manager: "a", Operation: api.ManagedFieldsOperationApply, APIVersion: "v1"},
+	}
+	reversed := []api.ManagedFieldsEntry{entries[1], entries[0]}
+	if CanonicalManagedFields(entries) != CanonicalManagedFields(reversed) {
+		t.Errorf("expected entry order not to affect the canonical form")
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"encoding/json"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/annotations"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// These tests cover transitions between client-side apply (an update by
+// kubectl carrying the last-applied-configuration annotation) and
+// server-side apply, the migration path where users hit field loss and
+// ownership surprises. Each sequence asserts that user-written fields
+// survive and that kubectl still owns fields at the end.
+
+func csaPod(labels map[string]string) *api.Pod {
+	pod := &api.Pod{ObjectMeta: api.ObjectMeta{
+		Namespace: "default",
+		Name:      "interop",
+		Labels:    labels,
+	}}
+	config, err := json.Marshal(pod)
+	if err != nil {
+		panic(err)
+	}
+	pod.Annotations = map[string]string{annotations.LastAppliedConfigAnnotation: string(config)}
+	return pod
+}
+
+func ssaPod(labels map[string]string) *api.Pod {
+	return &api.Pod{ObjectMeta: api.ObjectMeta{
+		Namespace: "default",
+		Name:      "interop",
+		Labels:    labels,
+	}}
+}
+
+func editPod(base *api.Pod) *api.Pod {
+	edited := *base
+	edited.Annotations = map[string]string{}
+	for k, v := range base.Annotations {
+		edited.Annotations[k] = v
+	}
+	edited.Annotations["edited"] = "true"
+	return &edited
+}
+
+type interopStep func(harness *TestFieldManager) error
+
+func TestCSAToSSAAndBack(t *testing.T) {
+	labels := map[string]string{"app": "interop"}
+	sequences := map[string][]interopStep{
+		"csa-ssa-csa": {
+			func(h *TestFieldManager) error { return h.Update(csaPod(labels), "kubectl") },
+			func(h *TestFieldManager) error { return h.Apply(ssaPod(labels), "kubectl", false) },
+			func(h *TestFieldManager) error { return h.Update(csaPod(labels), "kubectl") },
+		},
+		"csa-ssa-edit-csa": {
+			func(h *TestFieldManager) error { return h.Update(csaPod(labels), "kubectl") },
+			func(h *TestFieldManager) error { return h.Apply(ssaPod(labels), "kubectl", false) },
+			func(h *TestFieldManager) error { return h.Update(editPod(csaPod(labels)), "kubectl-edit") },
+			func(h *TestFieldManager) error { return h.Update(csaPod(labels), "kubectl") },
+		},
+		"ssa-csa-ssa": {
+			func(h *TestFieldManager) error { return h.Apply(ssaPod(labels), "kubectl", false) },
+			func(h *TestFieldManager) error { return h.Update(csaPod(labels), "kubectl") },
+			func(h *TestFieldManager) error { return h.Apply(ssaPod(labels), "kubectl", false) },
+		},
+	}
+	for name, steps := range sequences {
+		harness := NewTestFieldManager(nil, func() runtime.Object { return &api.Pod{} })
+		failed := false
+		for i, step := range steps {
+			if err := step(harness); err != nil {
+				t.Errorf("%s: step %d failed: %v", name, i, err)
+				failed = true
+				break
+			}
+		}
+		if failed {
+			continue
+		}
+
+		live, ok := harness.Live().(*api.Pod)
+		if !ok {
+			t.Errorf("%s: unexpected live object %T", name, harness.Live())
+			continue
+		}
+		// no field loss: the user's labels survive every transition
+		if live.Labels["app"] != "interop" {
+			t.Errorf("%s: expected the applied labels to survive, got %#v", name, live.Labels)
+		}
+		// correct ownership: kubectl still owns fields at the end
+		kubectlOwns := false
+		for _, entry := range harness.ManagedFields() {
+			if entry.Manager == "kubectl" {
+				kubectlOwns = true
+			}
+		}
+		if !kubectlOwns {
+			t.Errorf("%s: expected kubectl to own fields, got %#v", name, harness.ManagedFields())
+		}
+	}
+}
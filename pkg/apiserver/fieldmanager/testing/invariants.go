@@ -0,0 +1,138 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// ApplyClient is the surface the invariant checks drive. TestFieldManager
+// implements it in-process; an adapter issuing dry-run requests against a
+// live cluster can implement it as well, so the same invariants run in CI
+// and against running apiservers.
+type ApplyClient interface {
+	Apply(obj runtime.Object, manager string, force bool) error
+	Update(obj runtime.Object, manager string) error
+	Live() runtime.Object
+	ManagedFields() []api.ManagedFieldsEntry
+}
+
+var _ ApplyClient = &TestFieldManager{}
+
+// Invariant is a named property of apply that must hold for any object. The
+// check is handed a fresh client and a generator producing objects to write;
+// generators are typically fuzzers so each run covers different shapes.
+type Invariant struct {
+	Name  string
+	Check func(client ApplyClient, generate func() runtime.Object) error
+}
+
+// DefaultInvariants returns the invariants every apply implementation is
+// expected to satisfy.
+func DefaultInvariants() []Invariant {
+	return []Invariant{
+		{Name: "ApplyTwiceIsIdentical", Check: checkApplyTwiceIsIdentical},
+		{Name: "UpdateAfterApplyKeepsOwnership", Check: checkUpdateAfterApplyKeepsOwnership},
+		{Name: "ApplyIsRecorded", Check: checkApplyIsRecorded},
+	}
+}
+
+// CheckInvariants runs every invariant against a fresh client, returning one
+// error per violated invariant.
+func CheckInvariants(newClient func() ApplyClient, generate func() runtime.Object, invariants []Invariant) []error {
+	var errs []error
+	for _, invariant := range invariants {
+		if err := invariant.Check(newClient(), generate); err != nil {
+			errs = append(errs, fmt.Errorf("invariant %s violated: %v", invariant.Name, err))
+		}
+	}
+	return errs
+}
+
+// checkApplyTwiceIsIdentical verifies that re-applying the same object
+// changes neither the live object nor the managedFields, up to the entry
+// timestamps.
+func checkApplyTwiceIsIdentical(client ApplyClient, generate func() runtime.Object) error {
+	obj := generate()
+	if err := client.Apply(obj, "invariant-checker", true); err != nil {
+		return fmt.Errorf("first apply failed: %v", err)
+	}
+	first, err := snapshotWithoutTimes(client.Live())
+	if err != nil {
+		return err
+	}
+	if err := client.Apply(obj, "invariant-checker", true); err != nil {
+		return fmt.Errorf("second apply failed: %v", err)
+	}
+	second, err := snapshotWithoutTimes(client.Live())
+	if err != nil {
+		return err
+	}
+	if !api.Semantic.DeepEqual(first, second) {
+		return fmt.Errorf("second apply changed the object: had %#v, got %#v", first, second)
+	}
+	return nil
+}
+
+// checkUpdateAfterApplyKeepsOwnership verifies that an update by another
+// manager does not remove the applier's entry.
+func checkUpdateAfterApplyKeepsOwnership(client ApplyClient, generate func() runtime.Object) error {
+	if err := client.Apply(generate(), "invariant-checker", true); err != nil {
+		return fmt.Errorf("apply failed: %v", err)
+	}
+	if err := client.Update(generate(), "other-writer"); err != nil {
+		return fmt.Errorf("update failed: %v", err)
+	}
+	for _, entry := range client.ManagedFields() {
+		if entry.Manager == "invariant-checker" && entry.Operation == api.ManagedFieldsOperationApply {
+			return nil
+		}
+	}
+	return fmt.Errorf("the applier's entry disappeared: %#v", client.ManagedFields())
+}
+
+// checkApplyIsRecorded verifies that an apply leaves an Apply entry for the
+// calling manager.
+func checkApplyIsRecorded(client ApplyClient, generate func() runtime.Object) error {
+	if err := client.Apply(generate(), "invariant-checker", true); err != nil {
+		return fmt.Errorf("apply failed: %v", err)
+	}
+	for _, entry := range client.ManagedFields() {
+		if entry.Manager == "invariant-checker" && entry.Operation == api.ManagedFieldsOperationApply {
+			return nil
+		}
+	}
+	return fmt.Errorf("no apply entry recorded: %#v", client.ManagedFields())
+}
+
+// snapshotWithoutTimes copies the object and elides managedFields entry
+// timestamps, which are expected to move between writes.
+func snapshotWithoutTimes(obj runtime.Object) (runtime.Object, error) {
+	copied, err := api.Scheme.Copy(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy the live object: %v", err)
+	}
+	if objectMeta, err := api.ObjectMetaFor(copied); err == nil {
+		for i := range objectMeta.ManagedFields {
+			objectMeta.ManagedFields[i].Time = nil
+		}
+	}
+	return copied, nil
+}
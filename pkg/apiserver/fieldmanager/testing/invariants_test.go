@@ -0,0 +1,71 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+func TestDefaultInvariantsHold(t *testing.T) {
+	counter := 0
+	generate := func() runtime.Object {
+		counter++
+		return &api.Pod{ObjectMeta: api.ObjectMeta{
+			Namespace: "default",
+			Name:      "invariant",
+			Labels:    map[string]string{"generation": fmt.Sprintf("%d", counter)},
+		}}
+	}
+	newClient := func() ApplyClient {
+		return NewTestFieldManager(nil, func() runtime.Object { return &api.Pod{} })
+	}
+	for _, err := range CheckInvariants(newClient, generate, DefaultInvariants()) {
+		t.Errorf("%v", err)
+	}
+}
+
+// brokenClient drops managedFields on update, violating ownership retention.
+type brokenClient struct {
+	*TestFieldManager
+}
+
+func (b *brokenClient) Update(obj runtime.Object, manager string) error {
+	if err := b.TestFieldManager.Update(obj, manager); err != nil {
+		return err
+	}
+	if objectMeta, err := api.ObjectMetaFor(b.Live()); err == nil {
+		objectMeta.ManagedFields = nil
+	}
+	return nil
+}
+
+func TestInvariantViolationIsReported(t *testing.T) {
+	generate := func() runtime.Object {
+		return &api.Pod{ObjectMeta: api.ObjectMeta{Namespace: "default", Name: "invariant"}}
+	}
+	newClient := func() ApplyClient {
+		return &brokenClient{NewTestFieldManager(nil, func() runtime.Object { return &api.Pod{} })}
+	}
+	errs := CheckInvariants(newClient, generate, DefaultInvariants())
+	if len(errs) == 0 {
+		t.Fatalf("expected the broken client to violate an invariant")
+	}
+}
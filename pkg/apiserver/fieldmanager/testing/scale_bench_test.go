@@ -0,0 +1,92 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// These benchmarks model the write load of a large cluster with field
+// tracking enabled: churning Pods written by a scheduler-like and a
+// kubelet-like manager, and Endpoints rewritten by the endpoints controller
+// on every membership change. Run them per release to track apply and update
+// cost, e.g.
+//
+//	go test -bench Churn -benchmem ./pkg/apiserver/fieldmanager/testing
+const scaleObjects = 5000
+
+func benchPod(i int) *api.Pod {
+	return &api.Pod{ObjectMeta: api.ObjectMeta{
+		Namespace: "default",
+		Name:      fmt.Sprintf("pod-%d", i),
+	}}
+}
+
+func benchEndpoints(i, generation int) *api.Endpoints {
+	return &api.Endpoints{
+		ObjectMeta: api.ObjectMeta{
+			Namespace: "default",
+			Name:      fmt.Sprintf("service-%d", i),
+		},
+		Subsets: []api.EndpointSubset{{
+			Addresses: []api.EndpointAddress{{IP: fmt.Sprintf("10.0.%d.%d", generation%256, i%256)}},
+			Ports:     []api.EndpointPort{{Port: 80}},
+		}},
+	}
+}
+
+func BenchmarkPodChurn(b *testing.B) {
+	server := NewTestServer()
+	server.AddResource("pods", nil, func() runtime.Object { return &api.Pod{} })
+	for i := 0; i < scaleObjects; i++ {
+		if err := server.Apply("pods", benchPod(i), "deploy-tool", false); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pod := benchPod(i % scaleObjects)
+		if err := server.Update("pods", pod, "scheduler"); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+		if err := server.Update("pods", pod, "kubelet"); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkEndpointsChurn(b *testing.B) {
+	server := NewTestServer()
+	server.AddResource("endpoints", nil, func() runtime.Object { return &api.Endpoints{} })
+	for i := 0; i < scaleObjects; i++ {
+		if err := server.Update("endpoints", benchEndpoints(i, 0), "endpoint-controller"); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := server.Update("endpoints", benchEndpoints(i%scaleObjects, i), "endpoint-controller"); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
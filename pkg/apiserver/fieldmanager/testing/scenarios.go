@@ -0,0 +1,115 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// Scenario is a reusable multi-writer interaction sequence. Each scenario
+// models a pattern seen on real clusters, so changes to merge and conflict
+// logic can be evaluated against realistic sequences rather than synthetic
+// two-writer cases. Run them with RunScript and assert on the resulting
+// managedFields, e.g. via CompareGolden.
+type Scenario struct {
+	Name string
+	// NewFunc produces the empty live object for the harness.
+	NewFunc func() runtime.Object
+	// Managers lists every manager expected to own fields afterwards.
+	Managers []string
+	Steps    []Step
+}
+
+// CommonScenarios returns the multi-writer patterns most often reported to
+// go wrong: a replication controller fought over by kubectl and a horizontal
+// autoscaler, a pod written by the scheduler, kubelet, and an admission
+// webhook, and a service rewritten by kubectl around a controller's updates.
+func CommonScenarios() []Scenario {
+	return []Scenario{
+		kubectlWithAutoscaler(),
+		podWriteChain(),
+		kubectlAroundController(),
+	}
+}
+
+// kubectlWithAutoscaler: kubectl applies a replication controller with a
+// replica count, the autoscaler takes over replicas, kubectl re-applies.
+func kubectlWithAutoscaler() Scenario {
+	replicas := func(n int32) *api.ReplicationController {
+		return &api.ReplicationController{
+			ObjectMeta: api.ObjectMeta{Namespace: "default", Name: "frontend"},
+			Spec:       api.ReplicationControllerSpec{Replicas: n},
+		}
+	}
+	return Scenario{
+		Name:     "kubectl-with-autoscaler",
+		NewFunc:  func() runtime.Object { return &api.ReplicationController{} },
+		Managers: []string{"kubectl", "horizontal-pod-autoscaler"},
+		Steps: []Step{
+			{Operation: "apply", Manager: "kubectl", Object: replicas(3)},
+			{Operation: "update", Manager: "horizontal-pod-autoscaler", Object: replicas(5)},
+			{Operation: "apply", Manager: "kubectl", Object: replicas(3)},
+			{Operation: "update", Manager: "horizontal-pod-autoscaler", Object: replicas(7)},
+		},
+	}
+}
+
+// podWriteChain: kubectl creates a pod, an admission webhook injects a
+// label, the scheduler binds it, and the kubelet reports status.
+func podWriteChain() Scenario {
+	pod := func(labels map[string]string, nodeName string) *api.Pod {
+		return &api.Pod{
+			ObjectMeta: api.ObjectMeta{Namespace: "default", Name: "worker", Labels: labels},
+			Spec:       api.PodSpec{NodeName: nodeName},
+		}
+	}
+	return Scenario{
+		Name:     "pod-write-chain",
+		NewFunc:  func() runtime.Object { return &api.Pod{} },
+		Managers: []string{"kubectl", "sidecar-webhook", "scheduler", "kubelet"},
+		Steps: []Step{
+			{Operation: "apply", Manager: "kubectl", Object: pod(nil, "")},
+			{Operation: "update", Manager: "sidecar-webhook", Object: pod(map[string]string{"injected": "true"}, "")},
+			{Operation: "update", Manager: "scheduler", Object: pod(map[string]string{"injected": "true"}, "node-1")},
+			{Operation: "update", Manager: "kubelet", Object: pod(map[string]string{"injected": "true"}, "node-1")},
+		},
+	}
+}
+
+// kubectlAroundController: kubectl repeatedly re-applies a service while the
+// service controller updates it in between.
+func kubectlAroundController() Scenario {
+	service := func() *api.Service {
+		return &api.Service{
+			ObjectMeta: api.ObjectMeta{Namespace: "default", Name: "frontend"},
+			Spec:       api.ServiceSpec{Ports: []api.ServicePort{{Port: 80}}},
+		}
+	}
+	return Scenario{
+		Name:     "kubectl-around-controller",
+		NewFunc:  func() runtime.Object { return &api.Service{} },
+		Managers: []string{"kubectl", "service-controller"},
+		Steps: []Step{
+			{Operation: "apply", Manager: "kubectl", Object: service()},
+			{Operation: "update", Manager: "service-controller", Object: service()},
+			{Operation: "apply", Manager: "kubectl", Object: service()},
+			{Operation: "update", Manager: "service-controller", Object: service()},
+			{Operation: "apply", Manager: "kubectl", Object: service()},
+		},
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"testing"
+)
+
+func TestCommonScenarios(t *testing.T) {
+	for _, scenario := range CommonScenarios() {
+		harness := NewTestFieldManager(nil, scenario.NewFunc)
+		if err := RunScript(harness, scenario.Steps); err != nil {
+			t.Errorf("%s: %v", scenario.Name, err)
+			continue
+		}
+		owners := map[string]bool{}
+		for _, entry := range harness.ManagedFields() {
+			owners[entry.Manager] = true
+		}
+		for _, manager := range scenario.Managers {
+			if !owners[manager] {
+				t.Errorf("%s: expected %s to own fields afterwards, got %#v", scenario.Name, manager, harness.ManagedFields())
+			}
+		}
+	}
+}
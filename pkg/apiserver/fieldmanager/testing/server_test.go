@@ -0,0 +1,66 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+func TestServerApplyAndUpdate(t *testing.T) {
+	server := NewTestServer()
+	server.AddResource("pods", nil, func() runtime.Object { return &api.Pod{} })
+
+	pod := &api.Pod{ObjectMeta: api.ObjectMeta{Namespace: "default", Name: "foo"}}
+	if err := server.Apply("pods", pod, "deploy-tool", false); err != nil {
+		t.Fatalf("unexpected apply error: %v", err)
+	}
+	if err := server.Update("pods", pod, "kubelet"); err != nil {
+		t.Fatalf("unexpected update error: %v", err)
+	}
+
+	managed, err := server.ManagedFields("pods", "default", "foo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(managed) != 2 {
+		t.Fatalf("expected entries for both managers, got %#v", managed)
+	}
+	if managed[0].Manager != "deploy-tool" || managed[0].Operation != api.ManagedFieldsOperationApply {
+		t.Errorf("expected an apply entry for deploy-tool, got %#v", managed[0])
+	}
+	if managed[1].Manager != "kubelet" || managed[1].Operation != api.ManagedFieldsOperationUpdate {
+		t.Errorf("expected an update entry for kubelet, got %#v", managed[1])
+	}
+
+	if err := server.Delete("pods", "default", "foo"); err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+	if _, err := server.Get("pods", "default", "foo"); err == nil {
+		t.Errorf("expected a deleted object to be gone")
+	}
+}
+
+func TestServerUnknownResource(t *testing.T) {
+	server := NewTestServer()
+	pod := &api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo"}}
+	if err := server.Update("pods", pod, "kubelet"); err == nil {
+		t.Errorf("expected an error for an unregistered resource")
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// TestConcurrentWriters fires concurrent applies and updates from many
+// managers at a shared harness and manager chain, then checks the
+// managedFields invariants: one entry per manager and operation, and no
+// manager's ownership lost. Run with the race detector to validate the
+// chain's concurrency behavior.
+func TestConcurrentWriters(t *testing.T) {
+	const writers = 8
+	const writesPerWriter = 50
+
+	harness := NewTestFieldManager(nil, func() runtime.Object { return &api.Pod{} })
+	pod := &api.Pod{ObjectMeta: api.ObjectMeta{Namespace: "default", Name: "stress"}}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			manager := fmt.Sprintf("writer-%d", w)
+			for i := 0; i < writesPerWriter; i++ {
+				var err error
+				if w%2 == 0 {
+					err = harness.Apply(pod, manager, true)
+				} else {
+					err = harness.Update(pod, manager)
+				}
+				if err != nil {
+					errs <- fmt.Errorf("%s: %v", manager, err)
+					return
+				}
+				// interleave reads with the writes
+				harness.ManagedFields()
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	seen := map[string]int{}
+	for _, entry := range harness.ManagedFields() {
+		seen[fmt.Sprintf("%s/%s", entry.Manager, entry.Operation)]++
+	}
+	for key, count := range seen {
+		if count > 1 {
+			t.Errorf("expected a single entry for %s, got %d", key, count)
+		}
+	}
+	for w := 0; w < writers; w++ {
+		operation := api.ManagedFieldsOperationApply
+		if w%2 != 0 {
+			operation = api.ManagedFieldsOperationUpdate
+		}
+		key := fmt.Sprintf("writer-%d/%s", w, operation)
+		if seen[key] == 0 {
+			t.Errorf("expected %s to keep its ownership, got %#v", key, seen)
+		}
+	}
+}
+
+// TestConcurrentServerWriters stresses the multi-resource harness with
+// concurrent writers spread over many objects.
+func TestConcurrentServerWriters(t *testing.T) {
+	const writers = 8
+	const objects = 16
+
+	server := NewTestServer()
+	server.AddResource("pods", nil, func() runtime.Object { return &api.Pod{} })
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			manager := fmt.Sprintf("writer-%d", w)
+			for i := 0; i < objects; i++ {
+				pod := &api.Pod{ObjectMeta: api.ObjectMeta{
+					Namespace: "default",
+					Name:      fmt.Sprintf("stress-%d", i),
+				}}
+				if err := server.Apply("pods", pod, manager, true); err != nil {
+					errs <- fmt.Errorf("%s: %v", manager, err)
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	for i := 0; i < objects; i++ {
+		managed, err := server.ManagedFields("pods", "default", fmt.Sprintf("stress-%d", i))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(managed) != writers {
+			t.Errorf("stress-%d: expected %d owners, got %#v", i, writers, managed)
+		}
+	}
+}
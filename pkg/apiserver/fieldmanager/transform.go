@@ -0,0 +1,138 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/golang/glog"
+)
+
+// TransformFunc rewrites an incoming apply configuration in place before it
+// is merged. It returns a short description of what it changed, or the empty
+// string if it changed nothing; descriptions are logged so transformations
+// remain attributable after the fact.
+type TransformFunc func(obj runtime.Object) (string, error)
+
+// transformManager runs the configured transformations over every apply
+// configuration before delegating, so rewrites happen consistently ahead of
+// merging and conflict detection.
+type transformManager struct {
+	delegate   Manager
+	transforms []TransformFunc
+}
+
+var _ Manager = &transformManager{}
+
+// NewTransformManager wraps a Manager with apply-configuration
+// transformation hooks, run in order. Updates pass through untouched: the
+// hooks exist to rewrite declarative apply configurations, not every write.
+func NewTransformManager(delegate Manager, transforms ...TransformFunc) Manager {
+	return &transformManager{delegate: delegate, transforms: transforms}
+}
+
+// Update implements Manager.
+func (t *transformManager) Update(liveObj, newObj runtime.Object, managed []api.ManagedFieldsEntry, manager string) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	return t.delegate.Update(liveObj, newObj, managed, manager)
+}
+
+// Apply implements Manager.
+func (t *transformManager) Apply(liveObj, appliedObj runtime.Object, managed []api.ManagedFieldsEntry, manager string, force bool) (runtime.Object, []api.ManagedFieldsEntry, error) {
+	transformed, err := api.Scheme.Copy(appliedObj)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, transform := range t.transforms {
+		description, err := transform(transformed)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to transform the apply configuration: %v", err)
+		}
+		if len(description) > 0 {
+			glog.V(2).Infof("transformed apply configuration from manager %q: %s", manager, description)
+		}
+	}
+	return t.delegate.Apply(liveObj, transformed, managed, manager, force)
+}
+
+// InjectLabelsTransform returns a transformation that sets the given labels
+// on every applied object, without overriding values the applier set itself.
+func InjectLabelsTransform(labels map[string]string) TransformFunc {
+	return func(obj runtime.Object) (string, error) {
+		objectMeta, err := api.ObjectMetaFor(obj)
+		if err != nil {
+			return "", nil
+		}
+		injected := []string{}
+		for key, value := range labels {
+			if _, ok := objectMeta.Labels[key]; ok {
+				continue
+			}
+			if objectMeta.Labels == nil {
+				objectMeta.Labels = map[string]string{}
+			}
+			objectMeta.Labels[key] = value
+			injected = append(injected, key)
+		}
+		if len(injected) == 0 {
+			return "", nil
+		}
+		return fmt.Sprintf("injected labels %v", injected), nil
+	}
+}
+
+// transformConfig is the file format for configuring transformations:
+//
+//	{"transforms": [{"injectLabels": {"env": "prod"}}]}
+type transformConfig struct {
+	Transforms []transformEntry `json:"transforms"`
+}
+
+type transformEntry struct {
+	InjectLabels map[string]string `json:"injectLabels,omitempty"`
+	PruneFields  []string          `json:"pruneFields,omitempty"`
+}
+
+// ReadTransformConfig loads transformation hooks from a config file.
+func ReadTransformConfig(path string) ([]TransformFunc, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := transformConfig{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse transform config %s: %v", path, err)
+	}
+	var transforms []TransformFunc
+	for i, entry := range config.Transforms {
+		switch {
+		case len(entry.InjectLabels) > 0:
+			transforms = append(transforms, InjectLabelsTransform(entry.InjectLabels))
+		case len(entry.PruneFields) > 0:
+			transforms = append(transforms, PruneFieldsTransform(entry.PruneFields, func(message string) {
+				glog.Warning(message)
+			}))
+		default:
+			return nil, fmt.Errorf("transform config %s: entry %d configures no known transform", path, i)
+		}
+	}
+	return transforms, nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func TestTransformManagerInjectsLabels(t *testing.T) {
+	manager := NewTransformManager(
+		NewStampManager("v1"),
+		InjectLabelsTransform(map[string]string{"env": "prod", "app": "ignored"}),
+	)
+	applied := &api.Pod{ObjectMeta: api.ObjectMeta{
+		Name:   "foo",
+		Labels: map[string]string{"app": "web"},
+	}}
+
+	obj, _, err := manager.Apply(&api.Pod{}, applied, nil, "kubectl", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pod, ok := obj.(*api.Pod)
+	if !ok {
+		t.Fatalf("unexpected object %T", obj)
+	}
+	if pod.Labels["env"] != "prod" {
+		t.Errorf("expected the env label to be injected, got %#v", pod.Labels)
+	}
+	if pod.Labels["app"] != "web" {
+		t.Errorf("expected the applier's label to win, got %#v", pod.Labels)
+	}
+	// the applier's own configuration is not mutated
+	if _, ok := applied.Labels["env"]; ok {
+		t.Errorf("expected the incoming object to be left untouched")
+	}
+
+	// updates pass through without transformation
+	obj, _, err = manager.Update(&api.Pod{}, applied, nil, "kubelet")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pod := obj.(*api.Pod); pod.Labels["env"] != "" {
+		t.Errorf("expected updates to pass through untransformed, got %#v", pod.Labels)
+	}
+}
+
+func TestReadTransformConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "transform-config")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "transforms.json")
+	config := `{"transforms": [{"injectLabels": {"env": "prod"}}]}`
+	if err := ioutil.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	transforms, err := ReadTransformConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(transforms) != 1 {
+		t.Fatalf("expected one transform, got %d", len(transforms))
+	}
+	pod := &api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo"}}
+	if _, err := transforms[0](pod); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pod.Labels["env"] != "prod" {
+		t.Errorf("expected the configured labels to be injected, got %#v", pod.Labels)
+	}
+
+	bad := filepath.Join(dir, "bad.json")
+	if err := ioutil.WriteFile(bad, []byte(`{"transforms": [{}]}`), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := ReadTransformConfig(bad); err == nil {
+		t.Errorf("expected an empty transform entry to be rejected")
+	}
+}
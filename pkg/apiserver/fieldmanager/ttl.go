@@ -0,0 +1,115 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"strings"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// Debug tooling and one-off scripts should be able to write an object
+// without leaving a permanent manager entry behind. A request can declare
+// its ownership ephemeral with a TTL; the expiry is recorded as an
+// annotation keyed by manager name, the same convention the UID and
+// impersonator records use, and expired entries are cleaned up lazily on
+// subsequent writes rather than by a background sweep.
+
+// entryExpiryAnnotationPrefix prefixes the per-manager expiry annotations.
+const entryExpiryAnnotationPrefix = "managed-fields.alpha.kubernetes.io/expires-"
+
+// RecordEntryExpiry declares the given manager's ownership ephemeral,
+// expiring at the given time. A zero expiry removes the declaration, making
+// the ownership permanent again.
+func RecordEntryExpiry(obj runtime.Object, manager string, expiry time.Time) error {
+	meta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		return err
+	}
+	key := entryExpiryAnnotationPrefix + manager
+	if expiry.IsZero() {
+		delete(meta.Annotations, key)
+		return nil
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[key] = expiry.UTC().Format(time.RFC3339)
+	return nil
+}
+
+// EntryExpiry returns the declared expiry of the given manager's ownership,
+// if any.
+func EntryExpiry(obj runtime.Object, manager string) (time.Time, bool) {
+	meta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		return time.Time{}, false
+	}
+	value, ok := meta.Annotations[entryExpiryAnnotationPrefix+manager]
+	if !ok {
+		return time.Time{}, false
+	}
+	expiry, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return expiry, true
+}
+
+// ExpireEphemeralEntries removes the entries of every manager whose
+// declared expiry has passed, along with the declaration itself, and
+// returns the remaining entries and whether anything changed. Unparseable
+// declarations are dropped without touching entries, so a corrupted
+// annotation cannot strand ownership forever.
+func ExpireEphemeralEntries(obj runtime.Object, managed []api.ManagedFieldsEntry, now time.Time) ([]api.ManagedFieldsEntry, bool, error) {
+	meta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		return managed, false, err
+	}
+	changed := false
+	for key, value := range meta.Annotations {
+		if !strings.HasPrefix(key, entryExpiryAnnotationPrefix) {
+			continue
+		}
+		manager := strings.TrimPrefix(key, entryExpiryAnnotationPrefix)
+		expiry, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			delete(meta.Annotations, key)
+			changed = true
+			continue
+		}
+		if now.Before(expiry) {
+			continue
+		}
+		delete(meta.Annotations, key)
+		changed = true
+		kept := managed[:0]
+		for i := range managed {
+			if managed[i].Manager == manager {
+				continue
+			}
+			kept = append(kept, managed[i])
+		}
+		managed = kept
+	}
+	if changed && len(managed) == 0 {
+		managed = nil
+	}
+	return managed, changed, nil
+}
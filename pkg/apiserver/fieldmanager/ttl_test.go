@@ -0,0 +1,92 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordEntryExpiry(t *testing.T) {
+	pod := podWithManagedFields(validEntry("debug-tool", 0))
+	expiry := time.Now().Add(time.Hour)
+	if err := RecordEntryExpiry(pod, "debug-tool", expiry); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	recorded, ok := EntryExpiry(pod, "debug-tool")
+	if !ok {
+		t.Fatalf("expected an expiry to be recorded")
+	}
+	if recorded.Unix() != expiry.Unix() {
+		t.Errorf("expected expiry %v, got %v", expiry, recorded)
+	}
+
+	if err := RecordEntryExpiry(pod, "debug-tool", time.Time{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := EntryExpiry(pod, "debug-tool"); ok {
+		t.Errorf("expected a zero expiry to remove the declaration")
+	}
+}
+
+func TestExpireEphemeralEntries(t *testing.T) {
+	now := time.Now()
+	pod := podWithManagedFields(validEntry("debug-tool", 0), validEntry("deploy-tool", 0))
+	if err := RecordEntryExpiry(pod, "debug-tool", now.Add(-time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	managed, changed, err := ExpireEphemeralEntries(pod, pod.ManagedFields, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !changed {
+		t.Fatalf("expected the expired manager to be cleaned up")
+	}
+	if len(managed) != 1 || managed[0].Manager != "deploy-tool" {
+		t.Errorf("expected only the permanent entry to remain, got %v", managed)
+	}
+	if _, ok := EntryExpiry(pod, "debug-tool"); ok {
+		t.Errorf("expected the declaration to be removed with the entries")
+	}
+}
+
+func TestExpireEphemeralEntriesKeepsLiveOnes(t *testing.T) {
+	now := time.Now()
+	pod := podWithManagedFields(validEntry("debug-tool", 0))
+	if err := RecordEntryExpiry(pod, "debug-tool", now.Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	managed, changed, err := ExpireEphemeralEntries(pod, pod.ManagedFields, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if changed || len(managed) != 1 {
+		t.Errorf("expected an unexpired declaration to be left alone, got changed=%v managed=%v", changed, managed)
+	}
+
+	// a corrupted declaration is dropped without touching entries
+	pod.Annotations[entryExpiryAnnotationPrefix+"debug-tool"] = "not a time"
+	managed, changed, err = ExpireEphemeralEntries(pod, pod.ManagedFields, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !changed || len(managed) != 1 {
+		t.Errorf("expected the corrupted declaration to be dropped and the entries kept, got changed=%v managed=%v", changed, managed)
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"strings"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// A manager name is self-reported and often shared: every node's kubelet
+// writes as "kubelet", and CI systems commonly share a deploy tool name.
+// For forensic attribution an entry can optionally be linked to the
+// authenticated user UID that produced it, recorded as an annotation keyed
+// by manager name so it survives round trips through clients that preserve
+// metadata.
+
+// entryUIDAnnotationPrefix prefixes the per-manager UID annotations.
+const entryUIDAnnotationPrefix = "managed-fields.alpha.kubernetes.io/uid-"
+
+// entryImpersonatorAnnotationPrefix prefixes the per-manager impersonator
+// annotations, recording who really performed a write that went through
+// impersonation.
+const entryImpersonatorAnnotationPrefix = "managed-fields.alpha.kubernetes.io/impersonator-"
+
+// RecordEntryUID links the given manager's entry to the authenticated user
+// UID that produced it. An empty uid removes the link.
+func RecordEntryUID(obj runtime.Object, manager, uid string) error {
+	meta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		return err
+	}
+	key := entryUIDAnnotationPrefix + manager
+	if uid == "" {
+		delete(meta.Annotations, key)
+		return nil
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[key] = uid
+	return nil
+}
+
+// EntryUID returns the user UID recorded for the given manager, if any.
+func EntryUID(obj runtime.Object, manager string) (string, bool) {
+	meta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		return "", false
+	}
+	uid, ok := meta.Annotations[entryUIDAnnotationPrefix+manager]
+	return uid, ok
+}
+
+// RecordEntryImpersonator records the real identity behind an impersonated
+// write by the given manager. An empty impersonator removes the record,
+// which keeps attribution accurate when a manager later writes directly.
+func RecordEntryImpersonator(obj runtime.Object, manager, impersonator string) error {
+	meta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		return err
+	}
+	key := entryImpersonatorAnnotationPrefix + manager
+	if impersonator == "" {
+		delete(meta.Annotations, key)
+		return nil
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[key] = impersonator
+	return nil
+}
+
+// EntryImpersonator returns the real identity recorded behind the given
+// manager's last impersonated write, if any.
+func EntryImpersonator(obj runtime.Object, manager string) (string, bool) {
+	meta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		return "", false
+	}
+	impersonator, ok := meta.Annotations[entryImpersonatorAnnotationPrefix+manager]
+	return impersonator, ok
+}
+
+// PruneEntryUIDs removes attribution annotations whose manager no longer
+// has a managedFields entry, so attribution does not outlive the ownership
+// record it describes.
+func PruneEntryUIDs(obj runtime.Object) error {
+	meta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		return err
+	}
+	managers := map[string]bool{}
+	for _, entry := range meta.ManagedFields {
+		managers[entry.Manager] = true
+	}
+	for key := range meta.Annotations {
+		manager := ""
+		switch {
+		case strings.HasPrefix(key, entryUIDAnnotationPrefix):
+			manager = key[len(entryUIDAnnotationPrefix):]
+		case strings.HasPrefix(key, entryImpersonatorAnnotationPrefix):
+			manager = key[len(entryImpersonatorAnnotationPrefix):]
+		default:
+			continue
+		}
+		if !managers[manager] {
+			delete(meta.Annotations, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func TestRecordEntryUID(t *testing.T) {
+	pod := &api.Pod{}
+	if err := RecordEntryUID(pod, "kubelet", "user-uid-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if uid, ok := EntryUID(pod, "kubelet"); !ok || uid != "user-uid-1" {
+		t.Errorf("expected the recorded uid, got %q, %v", uid, ok)
+	}
+	if _, ok := EntryUID(pod, "deploy-tool"); ok {
+		t.Errorf("expected no uid for an unrecorded manager")
+	}
+
+	// re-recording overwrites, an empty uid removes the link
+	if err := RecordEntryUID(pod, "kubelet", "user-uid-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if uid, _ := EntryUID(pod, "kubelet"); uid != "user-uid-2" {
+		t.Errorf("expected the newer uid, got %q", uid)
+	}
+	if err := RecordEntryUID(pod, "kubelet", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := EntryUID(pod, "kubelet"); ok {
+		t.Errorf("expected the link to be removed")
+	}
+}
+
+func TestPruneEntryUIDs(t *testing.T) {
+	pod := podWithManagedFields(validEntry("kubelet", 0))
+	if err := RecordEntryUID(pod, "kubelet", "user-uid-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := RecordEntryUID(pod, "departed-tool", "user-uid-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pod.Annotations["unrelated"] = "kept"
+
+	if err := PruneEntryUIDs(pod); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := EntryUID(pod, "kubelet"); !ok {
+		t.Errorf("expected the live manager's uid to be kept")
+	}
+	if _, ok := EntryUID(pod, "departed-tool"); ok {
+		t.Errorf("expected the departed manager's uid to be pruned")
+	}
+	if pod.Annotations["unrelated"] != "kept" {
+		t.Errorf("expected unrelated annotations to be untouched")
+	}
+}
+
+func TestRecordEntryImpersonator(t *testing.T) {
+	pod := podWithManagedFields(validEntry("deploy-tool", 0))
+	if err := RecordEntryImpersonator(pod, "deploy-tool", "admin@example.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if impersonator, ok := EntryImpersonator(pod, "deploy-tool"); !ok || impersonator != "admin@example.com" {
+		t.Errorf("expected the impersonator to be recorded, got %q, %v", impersonator, ok)
+	}
+
+	// a later direct write clears the record
+	if err := RecordEntryImpersonator(pod, "deploy-tool", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := EntryImpersonator(pod, "deploy-tool"); ok {
+		t.Errorf("expected a direct write to clear the impersonator record")
+	}
+
+	// pruning drops impersonator records for departed managers too
+	if err := RecordEntryImpersonator(pod, "departed-tool", "admin@example.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := PruneEntryUIDs(pod); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := EntryImpersonator(pod, "departed-tool"); ok {
+		t.Errorf("expected the departed manager's record to be pruned")
+	}
+}
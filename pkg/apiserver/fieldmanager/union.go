@@ -0,0 +1,104 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Union describes a discriminated union embedded in an API struct: a
+// discriminator field selecting the active member, and the member field for
+// each discriminator value. Merging a write that moves the discriminator
+// must clear the previously active member, otherwise contradictory sibling
+// fields linger after an apply.
+type Union struct {
+	// Discriminator is the name of the struct field holding the
+	// discriminator value.
+	Discriminator string
+	// Members maps each discriminator value to the name of the struct
+	// field holding that member.
+	Members map[string]string
+}
+
+// NormalizeUnion zeroes every member field of the union except the one the
+// discriminator selects. The argument must be a pointer to the struct
+// containing the union's fields. Member fields for unknown discriminator
+// values are left alone, so new members added to the API degrade gracefully.
+func NormalizeUnion(unionStruct interface{}, union Union) error {
+	value := reflect.ValueOf(unionStruct)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expected a pointer to a struct, got %T", unionStruct)
+	}
+	value = value.Elem()
+
+	discriminator := value.FieldByName(union.Discriminator)
+	if !discriminator.IsValid() {
+		return fmt.Errorf("no discriminator field %q in %v", union.Discriminator, value.Type())
+	}
+	if discriminator.Kind() != reflect.String {
+		return fmt.Errorf("discriminator field %q in %v is not a string", union.Discriminator, value.Type())
+	}
+	active, ok := union.Members[discriminator.String()]
+	if !ok && discriminator.String() != "" {
+		// unknown discriminator value: leave all members alone
+		return nil
+	}
+
+	for _, member := range union.Members {
+		if member == active {
+			continue
+		}
+		field := value.FieldByName(member)
+		if !field.IsValid() {
+			return fmt.Errorf("no member field %q in %v", member, value.Type())
+		}
+		if !field.CanSet() {
+			return fmt.Errorf("member field %q in %v cannot be set", member, value.Type())
+		}
+		field.Set(reflect.Zero(field.Type()))
+	}
+	return nil
+}
+
+// DiscriminatorChanged returns true when the union's discriminator differs
+// between the two structs, i.e. the active member moved and stale siblings
+// may need clearing. Both arguments must be pointers to the same struct
+// type.
+func DiscriminatorChanged(oldStruct, newStruct interface{}, union Union) (bool, error) {
+	oldValue, err := discriminatorValue(oldStruct, union)
+	if err != nil {
+		return false, err
+	}
+	newValue, err := discriminatorValue(newStruct, union)
+	if err != nil {
+		return false, err
+	}
+	return oldValue != newValue, nil
+}
+
+func discriminatorValue(unionStruct interface{}, union Union) (string, error) {
+	value := reflect.ValueOf(unionStruct)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return "", fmt.Errorf("expected a pointer to a struct, got %T", unionStruct)
+	}
+	discriminator := value.Elem().FieldByName(union.Discriminator)
+	if !discriminator.IsValid() || discriminator.Kind() != reflect.String {
+		return "", fmt.Errorf("no string discriminator field %q in %v", union.Discriminator, value.Elem().Type())
+	}
+	return discriminator.String(), nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"testing"
+)
+
+// metricSource is a discriminated union in the style of the autoscaler
+// metric sources.
+type metricSource struct {
+	Type     string
+	CPU      *struct{ Target int }
+	External *struct{ Name string }
+}
+
+var metricUnion = Union{
+	Discriminator: "Type",
+	Members: map[string]string{
+		"CPU":      "CPU",
+		"External": "External",
+	},
+}
+
+func TestNormalizeUnion(t *testing.T) {
+	// the discriminator moved to CPU but the stale External member remained
+	source := &metricSource{
+		Type:     "CPU",
+		CPU:      &struct{ Target int }{Target: 80},
+		External: &struct{ Name string }{Name: "stale"},
+	}
+	if err := NormalizeUnion(source, metricUnion); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if source.External != nil {
+		t.Errorf("expected the stale member to be cleared, got %#v", source.External)
+	}
+	if source.CPU == nil || source.CPU.Target != 80 {
+		t.Errorf("expected the active member to be kept, got %#v", source.CPU)
+	}
+
+	// unknown discriminator values leave all members alone
+	unknown := &metricSource{
+		Type: "FutureSource",
+		CPU:  &struct{ Target int }{Target: 80},
+	}
+	if err := NormalizeUnion(unknown, metricUnion); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if unknown.CPU == nil {
+		t.Errorf("expected members to survive an unknown discriminator")
+	}
+}
+
+func TestNormalizeUnionErrors(t *testing.T) {
+	if err := NormalizeUnion(metricSource{}, metricUnion); err == nil {
+		t.Errorf("expected an error for a non-pointer argument")
+	}
+	bad := Union{Discriminator: "Missing", Members: map[string]string{}}
+	if err := NormalizeUnion(&metricSource{}, bad); err == nil {
+		t.Errorf("expected an error for a missing discriminator field")
+	}
+}
+
+func TestDiscriminatorChanged(t *testing.T) {
+	old := &metricSource{Type: "CPU"}
+	updated := &metricSource{Type: "External"}
+	changed, err := DiscriminatorChanged(old, updated, metricUnion)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !changed {
+		t.Errorf("expected the discriminator move to be detected")
+	}
+	changed, err = DiscriminatorChanged(old, old, metricUnion)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if changed {
+		t.Errorf("expected no change for identical discriminators")
+	}
+}
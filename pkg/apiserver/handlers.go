@@ -20,6 +20,7 @@ import (
 	"bufio"
 	"encoding/json"
 	"fmt"
+	"mime"
 	"net"
 	"net/http"
 	"regexp"
@@ -452,6 +453,10 @@ func WithImpersonation(handler http.Handler, requestContextMapper api.RequestCon
 			return
 		}
 
+		// keep the real identity reachable so attribution can be recorded
+		// through the impersonation layer
+		ctx = api.WithImpersonatingUser(ctx, requestor)
+
 		switch {
 		case strings.HasPrefix(requestedSubject, serviceaccount.ServiceAccountUsernamePrefix):
 			namespace, name, err := serviceaccount.SplitUsername(requestedSubject)
@@ -543,6 +548,14 @@ type RequestInfoResolver struct {
 // /api/{version}/watch/{resource}
 // /api/{version}/watch/namespaces/{namespace}/{resource}
 //
+// isApplyContentType reports whether contentType denotes an apply
+// configuration. The media type is parsed so that parameters such as
+// charset cannot change how a request is classified.
+func isApplyContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == string(api.ApplyPatchType)
+}
+
 // NonResource paths
 // /apis/{api-group}/{version}
 // /apis/{api-group}
@@ -606,6 +619,11 @@ func (r *RequestInfoResolver) GetRequestInfo(req *http.Request) (RequestInfo, er
 			requestInfo.Verb = "update"
 		case "PATCH":
 			requestInfo.Verb = "patch"
+			// apply configurations get their own verb so that admins can
+			// grant apply without granting arbitrary patch rights
+			if isApplyContentType(req.Header.Get("Content-Type")) {
+				requestInfo.Verb = "apply"
+			}
 		case "DELETE":
 			requestInfo.Verb = "delete"
 		default:
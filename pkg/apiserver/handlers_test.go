@@ -439,6 +439,31 @@ func TestGetAPIRequestInfo(t *testing.T) {
 	}
 }
 
+func TestApplyVerbFromContentType(t *testing.T) {
+	requestInfoResolver := newTestRequestInfoResolver()
+
+	contentTypes := map[string]string{
+		string(api.ApplyPatchType):                            "apply",
+		string(api.ApplyPatchType) + "; charset=utf-8":        "apply",
+		string(api.StrategicMergePatchType):                   "patch",
+		string(api.StrategicMergePatchType) + ";charset=UTF8": "patch",
+		string(api.JSONPatchType):                             "patch",
+		string(api.MergePatchType):                            "patch",
+	}
+	for contentType, expectedVerb := range contentTypes {
+		req, _ := http.NewRequest("PATCH", "/api/v1/namespaces/other/pods/foo", nil)
+		req.Header.Set("Content-Type", contentType)
+
+		apiRequestInfo, err := requestInfoResolver.GetRequestInfo(req)
+		if err != nil {
+			t.Errorf("%s: Unexpected error %v", contentType, err)
+		}
+		if apiRequestInfo.Verb != expectedVerb {
+			t.Errorf("%s: expected verb %s, actual: %s", contentType, expectedVerb, apiRequestInfo.Verb)
+		}
+	}
+}
+
 func TestGetNonAPIRequestInfo(t *testing.T) {
 	tests := map[string]struct {
 		url      string
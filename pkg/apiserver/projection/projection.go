@@ -0,0 +1,125 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package projection reduces API objects to a requested set of field paths
+// before serialization. Controllers that watch large numbers of objects but
+// consume only a few fields (a scheduler reading spec.nodeName, a monitoring
+// agent reading status.phase) can ask the server to strip everything else and
+// avoid shipping the full object over the wire.
+package projection
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// retainedFields are always copied into a projection so the result stays
+// identifiable and usable for follow-up requests.
+var retainedFields = []string{"apiVersion", "kind", "metadata"}
+
+// Parse splits a comma separated list of dotted field paths, e.g.
+// "spec.nodeName,status.phase", into their segments.
+func Parse(value string) ([][]string, error) {
+	paths := make([][]string, 0, strings.Count(value, ",")+1)
+	for _, path := range strings.Split(value, ",") {
+		if len(path) == 0 {
+			return nil, fmt.Errorf("field paths must not be empty")
+		}
+		paths = append(paths, strings.Split(path, "."))
+	}
+	return paths, nil
+}
+
+// Project returns the JSON compatible representation of obj reduced to the
+// requested field paths. Type and object metadata are always retained. Lists
+// are projected item by item, preserving the list's own metadata.
+func Project(obj runtime.Object, paths [][]string) (map[string]interface{}, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	if !meta.IsListType(obj) {
+		return projectObject(u, paths), nil
+	}
+
+	out := retain(u)
+	items := []interface{}{}
+	if listed, ok := u["items"].([]interface{}); ok {
+		for _, item := range listed {
+			if m, ok := item.(map[string]interface{}); ok {
+				items = append(items, projectObject(m, paths))
+			}
+		}
+	}
+	out["items"] = items
+	return out, nil
+}
+
+func retain(u map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for _, field := range retainedFields {
+		if value, ok := u[field]; ok {
+			out[field] = value
+		}
+	}
+	return out
+}
+
+func projectObject(u map[string]interface{}, paths [][]string) map[string]interface{} {
+	out := retain(u)
+	for _, path := range paths {
+		copyPath(u, out, path)
+	}
+	return out
+}
+
+// copyPath copies the value at path from src into dst, creating intermediate
+// maps as needed. Paths that do not resolve to a value are skipped entirely,
+// matching how field selectors treat unset fields.
+func copyPath(src, dst map[string]interface{}, path []string) {
+	value, ok := lookup(src, path)
+	if !ok {
+		return
+	}
+	for _, field := range path[:len(path)-1] {
+		child, ok := dst[field].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			dst[field] = child
+		}
+		dst = child
+	}
+	dst[path[len(path)-1]] = value
+}
+
+func lookup(src map[string]interface{}, path []string) (interface{}, bool) {
+	for i, field := range path {
+		value, ok := src[field]
+		if !ok {
+			return nil, false
+		}
+		if i == len(path)-1 {
+			return value, true
+		}
+		if src, ok = value.(map[string]interface{}); !ok {
+			return nil, false
+		}
+	}
+	return nil, false
+}
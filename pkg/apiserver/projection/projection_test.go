@@ -0,0 +1,127 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projection
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api/unversioned"
+)
+
+type testSpec struct {
+	NodeName string `json:"nodeName,omitempty"`
+	Image    string `json:"image,omitempty"`
+}
+
+type testStatus struct {
+	Phase  string `json:"phase,omitempty"`
+	HostIP string `json:"hostIP,omitempty"`
+}
+
+type testObject struct {
+	unversioned.TypeMeta `json:",inline"`
+
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Spec     testSpec               `json:"spec,omitempty"`
+	Status   testStatus             `json:"status,omitempty"`
+}
+
+func (o *testObject) GetObjectKind() unversioned.ObjectKind { return &o.TypeMeta }
+
+type testList struct {
+	unversioned.TypeMeta `json:",inline"`
+
+	Items []testObject `json:"items"`
+}
+
+func (l *testList) GetObjectKind() unversioned.ObjectKind { return &l.TypeMeta }
+
+func TestParse(t *testing.T) {
+	paths, err := Parse("spec.nodeName,status.phase")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := [][]string{{"spec", "nodeName"}, {"status", "phase"}}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("unexpected paths: %v", paths)
+	}
+
+	if _, err := Parse("spec.nodeName,"); err == nil {
+		t.Errorf("expected an error for an empty path")
+	}
+}
+
+func TestProjectObject(t *testing.T) {
+	obj := &testObject{
+		TypeMeta: unversioned.TypeMeta{Kind: "TestObject", APIVersion: "v1"},
+		Metadata: map[string]interface{}{"name": "foo"},
+		Spec:     testSpec{NodeName: "node-1", Image: "nginx"},
+		Status:   testStatus{Phase: "Running", HostIP: "10.0.0.1"},
+	}
+
+	paths, err := Parse("spec.nodeName,status.phase,spec.missing")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	projected, err := Project(obj, paths)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "TestObject",
+		"metadata":   map[string]interface{}{"name": "foo"},
+		"spec":       map[string]interface{}{"nodeName": "node-1"},
+		"status":     map[string]interface{}{"phase": "Running"},
+	}
+	if !reflect.DeepEqual(projected, expected) {
+		t.Errorf("unexpected projection:\n%#v\n%#v", projected, expected)
+	}
+}
+
+func TestProjectList(t *testing.T) {
+	list := &testList{
+		TypeMeta: unversioned.TypeMeta{Kind: "TestList", APIVersion: "v1"},
+		Items: []testObject{
+			{Spec: testSpec{NodeName: "node-1", Image: "nginx"}},
+			{Spec: testSpec{Image: "redis"}, Status: testStatus{Phase: "Pending"}},
+		},
+	}
+
+	paths, err := Parse("spec.nodeName")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	projected, err := Project(list, paths)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "TestList",
+		"items": []interface{}{
+			map[string]interface{}{"spec": map[string]interface{}{"nodeName": "node-1"}},
+			map[string]interface{}{},
+		},
+	}
+	if !reflect.DeepEqual(projected, expected) {
+		t.Errorf("unexpected projection:\n%#v\n%#v", projected, expected)
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"testing"
 
 	"golang.org/x/net/websocket"
+	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/rest"
 	utilnet "k8s.io/kubernetes/pkg/util/net"
 )
@@ -350,6 +351,65 @@ func TestProxy(t *testing.T) {
 	}
 }
 
+func TestProxyApplyPassThrough(t *testing.T) {
+	// Apply metadata must survive proxying untouched: the content type that
+	// selects the apply verb, the fieldManager/force/dryRun parameters, and
+	// any Warning headers the backend responds with.
+	const warningText = `299 - "conflict with \"kubelet\""`
+
+	downstreamServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if e, a := string(api.ApplyPatchType), req.Header.Get("Content-Type"); e != a {
+			t.Errorf("expected the apply content type %q to be forwarded, got %q", e, a)
+		}
+		query := req.URL.Query()
+		if e, a := "deploy-tool", query.Get("fieldManager"); e != a {
+			t.Errorf("expected fieldManager to be forwarded, got %q", a)
+		}
+		if e, a := "true", query.Get("force"); e != a {
+			t.Errorf("expected force to be forwarded, got %q", a)
+		}
+		if e, a := "All", query.Get("dryRun"); e != a {
+			t.Errorf("expected dryRun to be forwarded, got %q", a)
+		}
+		w.Header().Set("Warning", warningText)
+		fmt.Fprint(w, "applied")
+	}))
+	defer downstreamServer.Close()
+
+	serverURL, _ := url.Parse(downstreamServer.URL)
+	simpleStorage := &SimpleRESTStorage{
+		errors:                    map[string]error{},
+		resourceLocation:          serverURL,
+		expectedResourceNamespace: "default",
+	}
+
+	namespaceHandler := handleNamespaced(map[string]rest.Storage{"foo": simpleStorage})
+	namespaceServer := httptest.NewServer(namespaceHandler)
+	defer namespaceServer.Close()
+
+	proxyTestPattern := "/" + prefix + "/" + newGroupVersion.Group + "/" + newGroupVersion.Version + "/proxy/namespaces/default/foo/id/some/dir?fieldManager=deploy-tool&force=true&dryRun=All"
+	req, err := http.NewRequest("PATCH", namespaceServer.URL+proxyTestPattern, strings.NewReader(`{"spec":{}}`))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	req.Header.Set("Content-Type", string(api.ApplyPatchType))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer resp.Body.Close()
+	if e, a := warningText, resp.Header.Get("Warning"); e != a {
+		t.Errorf("expected the backend's Warning header to be passed back, got %q", a)
+	}
+	gotResp, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if e, a := "applied", string(gotResp); e != a {
+		t.Errorf("expected the backend response body, got %q", a)
+	}
+}
+
 func TestProxyUpgrade(t *testing.T) {
 
 	localhostPool := x509.NewCertPool()
@@ -522,7 +582,8 @@ func TestRedirectOnMissingTrailingSlash(t *testing.T) {
 }
 
 // exampleCert was generated from crypto/tls/generate_cert.go with the following command:
-//    go run generate_cert.go  --rsa-bits 512 --host example.com --ca --start-date "Jan 1 00:00:00 1970" --duration=1000000h
+//
+//	go run generate_cert.go  --rsa-bits 512 --host example.com --ca --start-date "Jan 1 00:00:00 1970" --duration=1000000h
 var exampleCert = []byte(`-----BEGIN CERTIFICATE-----
 MIIBcjCCAR6gAwIBAgIQBOUTYowZaENkZi0faI9DgTALBgkqhkiG9w0BAQswEjEQ
 MA4GA1UEChMHQWNtZSBDbzAgFw03MDAxMDEwMDAwMDBaGA8yMDg0MDEyOTE2MDAw
@@ -545,7 +606,8 @@ j4139pf8Re1J50zBxS/JlQfgDQi9sO9pYeiHIxNs
 -----END RSA PRIVATE KEY-----`)
 
 // localhostCert was generated from crypto/tls/generate_cert.go with the following command:
-//     go run generate_cert.go  --rsa-bits 512 --host 127.0.0.1,::1,example.com --ca --start-date "Jan 1 00:00:00 1970" --duration=1000000h
+//
+//	go run generate_cert.go  --rsa-bits 512 --host 127.0.0.1,::1,example.com --ca --start-date "Jan 1 00:00:00 1970" --duration=1000000h
 var localhostCert = []byte(`-----BEGIN CERTIFICATE-----
 MIIBdzCCASOgAwIBAgIBADALBgkqhkiG9w0BAQUwEjEQMA4GA1UEChMHQWNtZSBD
 bzAeFw03MDAxMDEwMDAwMDBaFw00OTEyMzEyMzU5NTlaMBIxEDAOBgNVBAoTB0Fj
@@ -0,0 +1,90 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"net/http"
+)
+
+// Apply requests trigger a server-side merge whose cost grows with the size
+// of the submitted configuration, so treating them as cheap single-slot
+// requests lets a flood of huge applies saturate the apiserver. A cost
+// estimate lets the in-flight limiter charge them proportionally.
+
+const (
+	// applyCostBytesPerSlot is the amount of apply configuration that is
+	// charged one in-flight slot. The value is a guess at how much merge
+	// work equals one ordinary request.
+	applyCostBytesPerSlot = 64 * 1024
+	// maxRequestCost caps the estimate so a single request can never
+	// starve the server, however large its body.
+	maxRequestCost = 8
+)
+
+// RequestCostEstimate returns the number of in-flight slots the request
+// should occupy. Most requests cost one slot; apply requests cost extra
+// slots in proportion to the declared size of their configuration.
+func RequestCostEstimate(req *http.Request) int {
+	// the media type is parsed so a client cannot dodge the charge by
+	// decorating the content type with a parameter
+	if req.Method != "PATCH" || !isApplyContentType(req.Header.Get("Content-Type")) {
+		return 1
+	}
+	cost := 1 + int(req.ContentLength/applyCostBytesPerSlot)
+	if cost > maxRequestCost {
+		cost = maxRequestCost
+	}
+	return cost
+}
+
+// MaxInFlightLimitWithCost behaves like MaxInFlightLimit but charges each
+// request the number of slots returned by the estimate, rejecting the
+// request when not all slots are free.
+func MaxInFlightLimitWithCost(c chan bool, estimate func(*http.Request) int, longRunningRequestCheck LongRunningRequestCheck, handler http.Handler) http.Handler {
+	if c == nil {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if longRunningRequestCheck(r) {
+			// Skip tracking long running events.
+			handler.ServeHTTP(w, r)
+			return
+		}
+		cost := estimate(r)
+		if cost < 1 {
+			cost = 1
+		}
+		acquired := 0
+		for ; acquired < cost; acquired++ {
+			select {
+			case c <- true:
+			default:
+				for i := 0; i < acquired; i++ {
+					<-c
+				}
+				tooManyRequests(r, w)
+				return
+			}
+		}
+		defer func() {
+			for i := 0; i < cost; i++ {
+				<-c
+			}
+		}()
+		handler.ServeHTTP(w, r)
+	})
+}
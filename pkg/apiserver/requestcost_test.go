@@ -0,0 +1,101 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+)
+
+func newApplyRequest(contentLength int64) *http.Request {
+	req, _ := http.NewRequest("PATCH", "/api/v1/namespaces/other/pods/foo", nil)
+	req.Header.Set("Content-Type", string(api.ApplyPatchType))
+	req.ContentLength = contentLength
+	return req
+}
+
+func TestRequestCostEstimate(t *testing.T) {
+	get, _ := http.NewRequest("GET", "/api/v1/pods", nil)
+	if cost := RequestCostEstimate(get); cost != 1 {
+		t.Errorf("expected reads to cost one slot, got %d", cost)
+	}
+
+	patch, _ := http.NewRequest("PATCH", "/api/v1/namespaces/other/pods/foo", nil)
+	patch.Header.Set("Content-Type", string(api.StrategicMergePatchType))
+	patch.ContentLength = 10 * applyCostBytesPerSlot
+	if cost := RequestCostEstimate(patch); cost != 1 {
+		t.Errorf("expected ordinary patches to cost one slot, got %d", cost)
+	}
+
+	if cost := RequestCostEstimate(newApplyRequest(1024)); cost != 1 {
+		t.Errorf("expected a small apply to cost one slot, got %d", cost)
+	}
+	if cost := RequestCostEstimate(newApplyRequest(3 * applyCostBytesPerSlot)); cost != 4 {
+		t.Errorf("expected a large apply to cost extra slots, got %d", cost)
+	}
+	if cost := RequestCostEstimate(newApplyRequest(100 * applyCostBytesPerSlot)); cost != maxRequestCost {
+		t.Errorf("expected the estimate to be capped at %d, got %d", maxRequestCost, cost)
+	}
+
+	// a media type parameter must not let a large apply dodge the charge
+	decorated := newApplyRequest(3 * applyCostBytesPerSlot)
+	decorated.Header.Set("Content-Type", string(api.ApplyPatchType)+"; charset=utf-8")
+	if cost := RequestCostEstimate(decorated); cost != 4 {
+		t.Errorf("expected a parameterized content type to still be charged, got %d", cost)
+	}
+}
+
+func TestMaxInFlightLimitWithCost(t *testing.T) {
+	c := make(chan bool, 4)
+	entered := make(chan struct{})
+	block := make(chan struct{})
+	defer close(block)
+
+	neverLongRunning := func(*http.Request) bool { return false }
+	handler := MaxInFlightLimitWithCost(c, RequestCostEstimate, neverLongRunning, http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			entered <- struct{}{}
+			<-block
+		},
+	))
+
+	// a large apply occupies all four slots
+	go handler.ServeHTTP(httptest.NewRecorder(), newApplyRequest(3*applyCostBytesPerSlot))
+	<-entered
+
+	// an ordinary request no longer fits and is rejected, not queued
+	w := httptest.NewRecorder()
+	get, _ := http.NewRequest("GET", "/api/v1/pods", nil)
+	handler.ServeHTTP(w, get)
+	if w.Code != errors.StatusTooManyRequests {
+		t.Errorf("expected a saturated server to return %d, got %d", errors.StatusTooManyRequests, w.Code)
+	}
+
+	// a rejected apply must release the slots it partially acquired
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newApplyRequest(3*applyCostBytesPerSlot))
+	if w.Code != errors.StatusTooManyRequests {
+		t.Errorf("expected a saturated server to return %d, got %d", errors.StatusTooManyRequests, w.Code)
+	}
+	if len(c) != 4 {
+		t.Errorf("expected rejected requests to hold no slots, found %d occupied", len(c))
+	}
+}
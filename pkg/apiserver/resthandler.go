@@ -32,6 +32,8 @@ import (
 	"k8s.io/kubernetes/pkg/api/meta"
 	"k8s.io/kubernetes/pkg/api/rest"
 	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/apiserver/fieldmanager"
+	"k8s.io/kubernetes/pkg/apiserver/projection"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/util"
@@ -116,10 +118,39 @@ func getResourceHandler(scope RequestScope, getter getterFunc) restful.RouteFunc
 			scope.err(err, res.ResponseWriter, req.Request)
 			return
 		}
+		if value := req.Request.URL.Query().Get("projection"); len(value) > 0 {
+			serveProjection(value, result, scope, req, res)
+			return
+		}
 		write(http.StatusOK, scope.Kind.GroupVersion(), scope.Serializer, result, w, req.Request)
 	}
 }
 
+// serveProjection writes only the requested field paths of result. The
+// projection is evaluated before serialization, so the bytes for the pruned
+// fields are never produced. Projected responses are always JSON.
+func serveProjection(value string, result runtime.Object, scope RequestScope, req *restful.Request, res *restful.Response) {
+	paths, err := projection.Parse(value)
+	if err != nil {
+		scope.err(errors.NewBadRequest(err.Error()), res.ResponseWriter, req.Request)
+		return
+	}
+	// the getter returns the internal version; convert to the requested
+	// version before projecting, as write() would, so the response carries
+	// external field names and a populated apiVersion and kind
+	converted, err := scope.Convertor.ConvertToVersion(result, scope.Kind.GroupVersion())
+	if err != nil {
+		scope.err(err, res.ResponseWriter, req.Request)
+		return
+	}
+	projected, err := projection.Project(converted, paths)
+	if err != nil {
+		scope.err(err, res.ResponseWriter, req.Request)
+		return
+	}
+	writeRawJSON(http.StatusOK, projected, res.ResponseWriter)
+}
+
 // GetResource returns a function that handles retrieving a single resource from a rest.Storage object.
 func GetResource(r rest.Getter, e rest.Exporter, scope RequestScope) restful.RouteFunction {
 	return getResourceHandler(scope,
@@ -325,6 +356,10 @@ func ListResource(r rest.Lister, rw rest.Watcher, scope RequestScope, forceWatch
 			return
 		}
 		trace.Step("Self-linking done")
+		if value := req.Request.URL.Query().Get("projection"); len(value) > 0 {
+			serveProjection(value, result, scope, req, res)
+			return
+		}
 		write(http.StatusOK, scope.Kind.GroupVersion(), scope.Serializer, result, w, req.Request)
 		trace.Step(fmt.Sprintf("Writing http response done (%d items)", numberOfItems))
 	}
@@ -357,6 +392,17 @@ func createHandler(r rest.NamedCreater, scope RequestScope, typer runtime.Object
 
 		ctx := scope.ContextFunc(req)
 		ctx = api.WithNamespace(ctx, namespace)
+		if manager := req.Request.URL.Query().Get("fieldManager"); len(manager) > 0 {
+			ctx = api.WithFieldManager(ctx, manager)
+		}
+		if value := req.Request.URL.Query().Get("fieldManagerTTL"); len(value) > 0 {
+			ttl, err := time.ParseDuration(value)
+			if err != nil {
+				scope.err(errors.NewBadRequest(fmt.Sprintf("fieldManagerTTL %q is not a valid duration", value)), res.ResponseWriter, req.Request)
+				return
+			}
+			ctx = api.WithFieldManagerTTL(ctx, ttl)
+		}
 
 		gv := scope.Kind.GroupVersion()
 		s, err := negotiateInputSerializer(req.Request, scope.Serializer)
@@ -459,6 +505,17 @@ func PatchResource(r rest.Patcher, scope RequestScope, typer runtime.ObjectTyper
 
 		ctx := scope.ContextFunc(req)
 		ctx = api.WithNamespace(ctx, namespace)
+		if manager := req.Request.URL.Query().Get("fieldManager"); len(manager) > 0 {
+			ctx = api.WithFieldManager(ctx, manager)
+		}
+		if value := req.Request.URL.Query().Get("fieldManagerTTL"); len(value) > 0 {
+			ttl, err := time.ParseDuration(value)
+			if err != nil {
+				scope.err(errors.NewBadRequest(fmt.Sprintf("fieldManagerTTL %q is not a valid duration", value)), res.ResponseWriter, req.Request)
+				return
+			}
+			ctx = api.WithFieldManagerTTL(ctx, ttl)
+		}
 
 		versionedObj, err := converter.ConvertToVersion(r.New(), scope.Kind.GroupVersion())
 		if err != nil {
@@ -500,6 +557,16 @@ func PatchResource(r rest.Patcher, scope RequestScope, typer runtime.ObjectTyper
 			return nil
 		}
 
+		// If the client asked for a summary of the change, remember the
+		// current state of the object so the result can be compared to it.
+		var original runtime.Object
+		if req.Request.Header.Get(fieldmanager.ApplySummaryHeader) == "true" {
+			if original, err = r.Get(ctx, name); err != nil {
+				scope.err(err, res.ResponseWriter, req.Request)
+				return
+			}
+		}
+
 		result, err := patchResource(ctx, updateAdmit, timeout, versionedObj, r, name, patchType, patchJS, scope.Namer, scope.Copier, scope.Resource, codec)
 		if err != nil {
 			scope.err(err, res.ResponseWriter, req.Request)
@@ -511,6 +578,12 @@ func PatchResource(r rest.Patcher, scope RequestScope, typer runtime.ObjectTyper
 			return
 		}
 
+		if original != nil {
+			if summary, err := fieldmanager.SummarizeDiff(original, result); err == nil {
+				res.ResponseWriter.Header().Set(fieldmanager.ApplySummaryHeader, summary.String())
+			}
+		}
+
 		write(http.StatusOK, scope.Kind.GroupVersion(), scope.Serializer, result, w, req.Request)
 	}
 
@@ -670,6 +743,17 @@ func UpdateResource(r rest.Updater, scope RequestScope, typer runtime.ObjectType
 		}
 		ctx := scope.ContextFunc(req)
 		ctx = api.WithNamespace(ctx, namespace)
+		if manager := req.Request.URL.Query().Get("fieldManager"); len(manager) > 0 {
+			ctx = api.WithFieldManager(ctx, manager)
+		}
+		if value := req.Request.URL.Query().Get("fieldManagerTTL"); len(value) > 0 {
+			ttl, err := time.ParseDuration(value)
+			if err != nil {
+				scope.err(errors.NewBadRequest(fmt.Sprintf("fieldManagerTTL %q is not a valid duration", value)), res.ResponseWriter, req.Request)
+				return
+			}
+			ctx = api.WithFieldManagerTTL(ctx, ttl)
+		}
 
 		body, err := readBody(req.Request)
 		if err != nil {
@@ -1084,6 +1168,11 @@ func getPatchedJS(patchType api.PatchType, originalJS, patchJS []byte, obj runti
 		return jsonpatch.MergePatch(originalJS, patchJS)
 	case api.StrategicMergePatchType:
 		return strategicpatch.StrategicMergePatchData(originalJS, patchJS, obj)
+	case api.ApplyPatchType:
+		// an apply configuration declares desired state and merges with the
+		// same list semantics as a strategic merge patch; field ownership is
+		// recorded by the registry's manager chain when the result is stored
+		return strategicpatch.StrategicMergePatchData(originalJS, patchJS, obj)
 	default:
 		// only here as a safety net - go-restful filters content-type
 		return nil, fmt.Errorf("unknown Content-Type header for patch: %v", patchType)
@@ -26,6 +26,7 @@ import (
 	"k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/httplog"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/runtime/serializer/encodecache"
 	"k8s.io/kubernetes/pkg/runtime/serializer/streaming"
 	utilruntime "k8s.io/kubernetes/pkg/util/runtime"
 	"k8s.io/kubernetes/pkg/util/wsstream"
@@ -39,6 +40,23 @@ import (
 // nothing will ever be sent down this channel
 var neverExitWatch <-chan time.Time = make(chan time.Time)
 
+// watchEncodeBufferLimit is the largest per-watch encoding buffer retained
+// between events. Encoding an object larger than this is still possible, but
+// the buffer is released once the event has been written so that a handful of
+// giant objects cannot pin memory on every open watch for the lifetime of the
+// connection.
+const watchEncodeBufferLimit = 64 * 1024
+
+// resetBuffer prepares buf for the next event, releasing it entirely if a
+// large object grew it past the retention limit.
+func resetBuffer(buf *bytes.Buffer) *bytes.Buffer {
+	if buf.Cap() > watchEncodeBufferLimit {
+		return &bytes.Buffer{}
+	}
+	buf.Reset()
+	return buf
+}
+
 // timeoutFactory abstracts watch timeout logic for testing
 type timeoutFactory interface {
 	TimeoutCh() (<-chan time.Time, func() bool)
@@ -78,6 +96,9 @@ func serveWatch(watcher watch.Interface, scope RequestScope, req *restful.Reques
 
 	// find the embedded serializer matching the media type
 	embeddedEncoder := scope.Serializer.EncoderForVersion(serializer.Embedded.Serializer, scope.Kind.GroupVersion())
+	// events fanned out to many watchers share their encoded bytes rather than
+	// re-serializing the same object once per connection
+	embeddedEncoder = encodecache.NewCachingEncoder(embeddedEncoder, serializer.MediaType+"/"+scope.Kind.GroupVersion().String())
 
 	server := &WatchServer{
 		watching: watcher,
@@ -207,7 +228,7 @@ func (s *WatchServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 				flusher.Flush()
 			}
 
-			buf.Reset()
+			buf = resetBuffer(buf)
 		}
 	}
 }
@@ -267,8 +288,8 @@ func (s *WatchServer) HandleWS(ws *websocket.Conn) {
 					return
 				}
 			}
-			buf.Reset()
-			streamBuf.Reset()
+			buf = resetBuffer(buf)
+			streamBuf = resetBuffer(streamBuf)
 		}
 	}
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package apiserver
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -701,3 +702,19 @@ func BenchmarkWatchProtobuf(b *testing.B) {
 	wg.Wait()
 	b.StopTimer()
 }
+
+func TestResetBuffer(t *testing.T) {
+	small := &bytes.Buffer{}
+	small.WriteString("small")
+	if got := resetBuffer(small); got != small {
+		t.Errorf("small buffers should be retained between events")
+	} else if got.Len() != 0 {
+		t.Errorf("expected the buffer to be reset")
+	}
+
+	large := &bytes.Buffer{}
+	large.Write(make([]byte, watchEncodeBufferLimit+1))
+	if got := resetBuffer(large); got == large {
+		t.Errorf("buffers grown past the retention limit should be released")
+	}
+}
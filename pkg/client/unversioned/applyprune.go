@@ -0,0 +1,105 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unversioned
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+// Controllers that apply full objects claim ownership of every field they
+// send, including fields they copied from the live object and do not care
+// about. PruneApplyConfiguration trims such fields from an apply payload:
+// a field is dropped when it is unchanged from the live object and not in
+// the caller's owned field set. What remains is the minimal configuration
+// that produces the same merge result without new ownership claims.
+
+// PruneApplyConfiguration returns the desired object with fields removed
+// that are both equal to the live object and outside the owned field set.
+// Identity fields (apiVersion, kind, metadata.name, metadata.namespace)
+// are always kept. Desired and live are objects in their JSON map form;
+// owned may be nil for a caller that owns nothing yet.
+func PruneApplyConfiguration(desired, live map[string]interface{}, owned *api.FieldsV1) (map[string]interface{}, error) {
+	ownedFields := map[string]interface{}{}
+	if owned != nil && len(owned.Raw) > 0 {
+		if err := json.Unmarshal(owned.Raw, &ownedFields); err != nil {
+			return nil, fmt.Errorf("failed to parse the owned field set: %v", err)
+		}
+	}
+	return pruneMap(desired, live, ownedFields, ""), nil
+}
+
+// identityFields must survive pruning: apply cannot address an object
+// without them.
+var identityFields = map[string]bool{
+	"apiVersion":         true,
+	"kind":               true,
+	"metadata.name":      true,
+	"metadata.namespace": true,
+}
+
+func pruneMap(desired, live, owned map[string]interface{}, path string) map[string]interface{} {
+	pruned := map[string]interface{}{}
+	for key, value := range desired {
+		fieldPath := key
+		if len(path) > 0 {
+			fieldPath = path + "." + key
+		}
+		if identityFields[fieldPath] {
+			pruned[key] = value
+			continue
+		}
+		liveValue, inLive := live[key]
+		ownedValue := ownedField(owned, key)
+
+		if desiredMap, ok := value.(map[string]interface{}); ok {
+			ownedMap, _ := ownedValue.(map[string]interface{})
+			if ownedValue != nil && len(ownedMap) == 0 {
+				// an empty entry at a non-leaf marks the whole subtree as
+				// owned; keep it intact
+				pruned[key] = value
+				continue
+			}
+			liveMap, _ := liveValue.(map[string]interface{})
+			if nested := pruneMap(desiredMap, liveMap, ownedMap, fieldPath); len(nested) > 0 {
+				pruned[key] = nested
+			}
+			continue
+		}
+
+		// leaves and lists: keep when changed or already owned
+		if !inLive || !reflect.DeepEqual(value, liveValue) || ownedValue != nil {
+			pruned[key] = value
+		}
+	}
+	return pruned
+}
+
+// ownedField looks a key up in the stored field set form, whose keys carry
+// an "f:" prefix.
+func ownedField(owned map[string]interface{}, key string) interface{} {
+	if owned == nil {
+		return nil
+	}
+	if value, ok := owned["f:"+key]; ok {
+		return value
+	}
+	return nil
+}
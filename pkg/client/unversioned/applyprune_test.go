@@ -0,0 +1,114 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unversioned
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func mustParseObject(t *testing.T, data string) map[string]interface{} {
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(data), &obj); err != nil {
+		t.Fatalf("bad test fixture %q: %v", data, err)
+	}
+	return obj
+}
+
+func TestPruneApplyConfiguration(t *testing.T) {
+	tests := []struct {
+		name     string
+		desired  string
+		live     string
+		owned    string
+		expected string
+	}{
+		{
+			name:     "unchanged unowned fields are pruned",
+			desired:  `{"spec":{"replicas":3,"paused":false}}`,
+			live:     `{"spec":{"replicas":3,"paused":false}}`,
+			expected: `{}`,
+		},
+		{
+			name:     "changed fields are kept",
+			desired:  `{"spec":{"replicas":5,"paused":false}}`,
+			live:     `{"spec":{"replicas":3,"paused":false}}`,
+			expected: `{"spec":{"replicas":5}}`,
+		},
+		{
+			name:     "owned fields are kept even when unchanged",
+			desired:  `{"spec":{"replicas":3,"paused":false}}`,
+			live:     `{"spec":{"replicas":3,"paused":false}}`,
+			owned:    `{"f:spec":{"f:replicas":{}}}`,
+			expected: `{"spec":{"replicas":3}}`,
+		},
+		{
+			name:     "fields absent from the live object are kept",
+			desired:  `{"spec":{"minReadySeconds":10}}`,
+			live:     `{"spec":{}}`,
+			expected: `{"spec":{"minReadySeconds":10}}`,
+		},
+		{
+			name:     "identity fields always survive",
+			desired:  `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"foo","namespace":"ns","labels":{"app":"web"}}}`,
+			live:     `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"foo","namespace":"ns","labels":{"app":"web"}}}`,
+			expected: `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"foo","namespace":"ns"}}`,
+		},
+		{
+			name:     "an owned subtree is kept intact",
+			desired:  `{"spec":{"template":{"spec":{"hostname":"a"}}}}`,
+			live:     `{"spec":{"template":{"spec":{"hostname":"a"}}}}`,
+			owned:    `{"f:spec":{"f:template":{}}}`,
+			expected: `{"spec":{"template":{"spec":{"hostname":"a"}}}}`,
+		},
+		{
+			name:     "unchanged unowned lists are pruned",
+			desired:  `{"spec":{"containers":[{"name":"a"}]}}`,
+			live:     `{"spec":{"containers":[{"name":"a"}]}}`,
+			expected: `{}`,
+		},
+		{
+			name:     "changed lists are kept whole",
+			desired:  `{"spec":{"containers":[{"name":"b"}]}}`,
+			live:     `{"spec":{"containers":[{"name":"a"}]}}`,
+			expected: `{"spec":{"containers":[{"name":"b"}]}}`,
+		},
+	}
+	for _, test := range tests {
+		var owned *api.FieldsV1
+		if len(test.owned) > 0 {
+			owned = &api.FieldsV1{Raw: []byte(test.owned)}
+		}
+		pruned, err := PruneApplyConfiguration(mustParseObject(t, test.desired), mustParseObject(t, test.live), owned)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if expected := mustParseObject(t, test.expected); !reflect.DeepEqual(pruned, expected) {
+			t.Errorf("%s: expected %v, got %v", test.name, expected, pruned)
+		}
+	}
+}
+
+func TestPruneApplyConfigurationBadFieldSet(t *testing.T) {
+	if _, err := PruneApplyConfiguration(map[string]interface{}{}, map[string]interface{}{}, &api.FieldsV1{Raw: []byte(`not json`)}); err == nil {
+		t.Errorf("expected an unparseable field set to error")
+	}
+}
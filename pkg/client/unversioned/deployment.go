@@ -35,6 +35,7 @@ type DeploymentInterface interface {
 	Create(*extensions.Deployment) (*extensions.Deployment, error)
 	Update(*extensions.Deployment) (*extensions.Deployment, error)
 	UpdateStatus(*extensions.Deployment) (*extensions.Deployment, error)
+	Patch(name string, data []byte) (*extensions.Deployment, error)
 	Watch(opts api.ListOptions) (watch.Interface, error)
 	Rollback(*extensions.DeploymentRollback) error
 }
@@ -89,6 +90,14 @@ func (c *deployments) Update(deployment *extensions.Deployment) (result *extensi
 	return
 }
 
+// Patch applies a strategic merge patch to the named deployment. Returns the server's
+// representation of the deployment, and an error, if there is any.
+func (c *deployments) Patch(name string, data []byte) (result *extensions.Deployment, err error) {
+	result = &extensions.Deployment{}
+	err = c.client.Patch(api.StrategicMergePatchType).Namespace(c.ns).Resource("deployments").Name(name).Body(data).Do().Into(result)
+	return
+}
+
 func (c *deployments) UpdateStatus(deployment *extensions.Deployment) (result *extensions.Deployment, err error) {
 	result = &extensions.Deployment{}
 	err = c.client.Put().Namespace(c.ns).Resource("deployments").Name(deployment.Name).SubResource("status").Body(deployment).Do().Into(result)
@@ -79,6 +79,15 @@ func (c *FakeDeployments) Update(deployment *extensions.Deployment) (*extensions
 	return obj.(*extensions.Deployment), err
 }
 
+func (c *FakeDeployments) Patch(name string, data []byte) (*extensions.Deployment, error) {
+	obj, err := c.Fake.Invokes(NewPatchAction("deployments", c.Namespace, &extensions.Deployment{ObjectMeta: api.ObjectMeta{Name: name}}), &extensions.Deployment{})
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*extensions.Deployment), err
+}
+
 func (c *FakeDeployments) UpdateStatus(deployment *extensions.Deployment) (*extensions.Deployment, error) {
 	obj, err := c.Fake.Invokes(NewUpdateSubresourceAction("deployments", "status", c.Namespace, deployment), deployment)
 	if obj == nil {
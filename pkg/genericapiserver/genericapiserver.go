@@ -312,25 +312,30 @@ func setDefaults(c *Config) {
 // New returns a new instance of GenericAPIServer from the given config.
 // Certain config fields will be set to a default value if unset,
 // including:
-//   ServiceClusterIPRange
-//   ServiceNodePortRange
-//   MasterCount
-//   ReadWritePort
-//   PublicAddress
+//
+//	ServiceClusterIPRange
+//	ServiceNodePortRange
+//	MasterCount
+//	ReadWritePort
+//	PublicAddress
+//
 // Public fields:
-//   Handler -- The returned GenericAPIServer has a field TopHandler which is an
-//   http.Handler which handles all the endpoints provided by the GenericAPIServer,
-//   including the API, the UI, and miscellaneous debugging endpoints.  All
-//   these are subject to authorization and authentication.
-//   InsecureHandler -- an http.Handler which handles all the same
-//   endpoints as Handler, but no authorization and authentication is done.
+//
+//	Handler -- The returned GenericAPIServer has a field TopHandler which is an
+//	http.Handler which handles all the endpoints provided by the GenericAPIServer,
+//	including the API, the UI, and miscellaneous debugging endpoints.  All
+//	these are subject to authorization and authentication.
+//	InsecureHandler -- an http.Handler which handles all the same
+//	endpoints as Handler, but no authorization and authentication is done.
+//
 // Public methods:
-//   HandleWithAuth -- Allows caller to add an http.Handler for an endpoint
-//   that uses the same authentication and authorization (if any is configured)
-//   as the GenericAPIServer's built-in endpoints.
-//   If the caller wants to add additional endpoints not using the GenericAPIServer's
-//   auth, then the caller should create a handler for those endpoints, which delegates the
-//   any unhandled paths to "Handler".
+//
+//	HandleWithAuth -- Allows caller to add an http.Handler for an endpoint
+//	that uses the same authentication and authorization (if any is configured)
+//	as the GenericAPIServer's built-in endpoints.
+//	If the caller wants to add additional endpoints not using the GenericAPIServer's
+//	auth, then the caller should create a handler for those endpoints, which delegates the
+//	any unhandled paths to "Handler".
 func New(c *Config) (*GenericAPIServer, error) {
 	if c.Serializer == nil {
 		return nil, fmt.Errorf("Genericapiserver.New() called with config.Serializer == nil")
@@ -682,7 +687,7 @@ func (s *GenericAPIServer) Run(options *options.ServerRunOptions) {
 		handler := apiserver.TimeoutHandler(s.Handler, longRunningTimeout)
 		secureServer := &http.Server{
 			Addr:           secureLocation,
-			Handler:        apiserver.MaxInFlightLimit(sem, longRunningRequestCheck, apiserver.RecoverPanics(handler)),
+			Handler:        apiserver.MaxInFlightLimitWithCost(sem, apiserver.RequestCostEstimate, longRunningRequestCheck, apiserver.RecoverPanics(handler)),
 			MaxHeaderBytes: 1 << 20,
 			TLSConfig: &tls.Config{
 				// Can't use SSLv3 because of POODLE and BEAST
@@ -19,6 +19,8 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/jonboulle/clockwork"
@@ -38,11 +40,15 @@ import (
 // ApplyOptions stores cmd.Flag values for apply.  As new fields are added,
 // add them here instead of referencing the cmd.Flags()
 type ApplyOptions struct {
-	Filenames []string
-	Recursive bool
+	Filenames          []string
+	Recursive          bool
+	FieldManagerSuffix string
 }
 
 const (
+	// applyFieldManager is the base manager identity recorded for kubectl
+	// apply by servers that track field ownership
+	applyFieldManager = "kubectl"
 	// maxPatchRetry is the maximum number of conflicts retry for during a patch operation before returning failure
 	maxPatchRetry = 5
 	// backOffPeriod is the period to back off when apply patch resutls in error.
@@ -90,9 +96,29 @@ func NewCmdApply(f *cmdutil.Factory, out io.Writer) *cobra.Command {
 	cmdutil.AddOutputFlagsForMutation(cmd)
 	cmdutil.AddRecordFlag(cmd)
 	cmdutil.AddInclude3rdPartyFlags(cmd)
+	cmd.Flags().StringVar(&options.FieldManagerSuffix, "field-manager-suffix", "", "If non-empty, append this suffix to the field manager name recorded for these changes.")
 	return cmd
 }
 
+// applyManagerName derives the manager identity recorded for this
+// invocation. Different environments applying overlapping objects stay
+// distinguishable in managedFields and conflict messages when the manager
+// name carries the configuration's identity: an explicit suffix wins, and
+// a single directory argument contributes its basename.
+func applyManagerName(suffix string, filenames []string) string {
+	if len(suffix) > 0 {
+		return applyFieldManager + "-" + suffix
+	}
+	if len(filenames) == 1 {
+		if stat, err := os.Stat(filenames[0]); err == nil && stat.IsDir() {
+			if base := filepath.Base(filenames[0]); base != "." && base != string(filepath.Separator) {
+				return applyFieldManager + "-" + base
+			}
+		}
+	}
+	return applyFieldManager
+}
+
 func validateArgs(cmd *cobra.Command, args []string) error {
 	if len(args) != 0 {
 		return cmdutil.UsageError(cmd, "Unexpected args: %v", args)
@@ -171,6 +197,7 @@ func RunApply(f *cmdutil.Factory, cmd *cobra.Command, out io.Writer, options *Ap
 		}
 
 		helper := resource.NewHelper(info.Client, info.Mapping)
+		helper.FieldManager = applyManagerName(options.FieldManagerSuffix, options.Filenames)
 		patcher := NewPatcher(encoder, decoder, info.Mapping, helper)
 
 		patchBytes, err := patcher.patch(info.Object, modified, info.Source, info.Namespace, info.Name)
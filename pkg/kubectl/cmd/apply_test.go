@@ -23,6 +23,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/ghodss/yaml"
@@ -373,3 +374,29 @@ func testApplyMultipleObjects(t *testing.T, asList bool) {
 		t.Fatalf("unexpected output: %s\nexpected: %s OR %s", buf.String(), expectOne, expectTwo)
 	}
 }
+
+func TestApplyManagerName(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apply-manager")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		suffix    string
+		filenames []string
+		expected  string
+	}{
+		{suffix: "", filenames: []string{filenameRC}, expected: "kubectl"},
+		{suffix: "prod", filenames: []string{filenameRC}, expected: "kubectl-prod"},
+		{suffix: "prod", filenames: []string{dir}, expected: "kubectl-prod"},
+		{suffix: "", filenames: []string{dir}, expected: "kubectl-" + filepath.Base(dir)},
+		{suffix: "", filenames: []string{dir, filenameRC}, expected: "kubectl"},
+		{suffix: "", filenames: []string{"."}, expected: "kubectl"},
+	}
+	for _, test := range tests {
+		if name := applyManagerName(test.suffix, test.filenames); name != test.expected {
+			t.Errorf("suffix %q filenames %v: expected %q, got %q", test.suffix, test.filenames, test.expected, name)
+		}
+	}
+}
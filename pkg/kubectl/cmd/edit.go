@@ -88,6 +88,16 @@ type EditOptions struct {
 
 var errExit = fmt.Errorf("exit directly")
 
+const (
+	// editFieldManager is the manager identity recorded for kubectl edit by
+	// servers that track field ownership.
+	editFieldManager = "kubectl-edit"
+	// fieldManagerConflictCause is the cause type such servers attach to
+	// conflicts that name the owning manager. These conflicts are resolvable
+	// in the editor, so they reopen it instead of suggesting a blind retry.
+	fieldManagerConflictCause unversioned.CauseType = "FieldManagerConflict"
+)
+
 func NewCmdEdit(f *cmdutil.Factory, out, errOut io.Writer) *cobra.Command {
 	options := &EditOptions{}
 
@@ -395,7 +405,9 @@ func RunEdit(f *cmdutil.Factory, out, errOut io.Writer, cmd *cobra.Command, args
 					return preservedFile(nil, file, errOut)
 				}
 
-				patched, err := resource.NewHelper(info.Client, info.Mapping).Patch(info.Namespace, info.Name, api.StrategicMergePatchType, patch)
+				helper := resource.NewHelper(info.Client, info.Mapping)
+				helper.FieldManager = editFieldManager
+				patched, err := helper.Patch(info.Namespace, info.Name, api.StrategicMergePatchType, patch)
 				if err != nil {
 					fmt.Fprintln(out, results.addError(err, info))
 					return nil
@@ -501,6 +513,16 @@ func (r *editResults) addError(err error, info *resource.Info) string {
 		}
 		r.header.reasons = append(r.header.reasons, reason)
 		return fmt.Sprintf("error: %s %q is invalid", info.Mapping.Resource, info.Name)
+	case errors.IsConflict(err) && len(fieldManagerConflicts(err)) > 0:
+		r.edit = append(r.edit, info)
+		reason := editReason{
+			head: fmt.Sprintf("%s %q conflicts with fields owned by other managers; keep your value to take the field, or remove your change to leave it alone", info.Mapping.Resource, info.Name),
+		}
+		for _, cause := range fieldManagerConflicts(err) {
+			reason.other = append(reason.other, fmt.Sprintf("%s: %s", cause.Field, cause.Message))
+		}
+		r.header.reasons = append(r.header.reasons, reason)
+		return fmt.Sprintf("error: %s %q has field conflicts", info.Mapping.Resource, info.Name)
 	case errors.IsNotFound(err):
 		r.notfound++
 		return fmt.Sprintf("error: %s %q could not be found on the server", info.Mapping.Resource, info.Name)
@@ -510,6 +532,27 @@ func (r *editResults) addError(err error, info *resource.Info) string {
 	}
 }
 
+// fieldManagerConflicts extracts the per-field conflict causes from a
+// conflict error, or nil when the conflict carries none (e.g. a plain
+// resourceVersion conflict).
+func fieldManagerConflicts(err error) []unversioned.StatusCause {
+	status, ok := err.(errors.APIStatus)
+	if !ok {
+		return nil
+	}
+	details := status.Status().Details
+	if details == nil {
+		return nil
+	}
+	var causes []unversioned.StatusCause
+	for _, cause := range details.Causes {
+		if cause.Type == fieldManagerConflictCause {
+			causes = append(causes, cause)
+		}
+	}
+	return causes
+}
+
 // preservedFile writes out a message about the provided file if it exists to the
 // provided output stream when an error happens. Used to notify the user where
 // their updates were preserved.
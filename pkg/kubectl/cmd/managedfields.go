@@ -0,0 +1,156 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/renstrom/dedent"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubernetes/pkg/api"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/kubectl/managedfields"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+)
+
+var (
+	managedFields_long = dedent.Dedent(`
+		Report the storage overhead of managedFields for the specified resources.
+
+		For every namespace and resource the total serialized size of managedFields is
+		reported, along with the managers contributing the most bytes overall. Use this
+		to decide where capping or disabling field tracking is worthwhile.`)
+
+	managedFields_example = dedent.Dedent(`
+		# Report managedFields overhead for all pods in the current namespace
+		kubectl managed-fields pods
+
+		# Report overhead for deployments and pods across all namespaces
+		kubectl managed-fields deployments,pods --all-namespaces
+
+		# Show only the five largest manager contributors
+		kubectl managed-fields pods --top=5`)
+)
+
+// ManagedFieldsOptions contains the input to the managed-fields command.
+type ManagedFieldsOptions struct {
+	builder *resource.Builder
+	top     int
+	out     io.Writer
+}
+
+// NewCmdManagedFields creates the `managed-fields` command.
+func NewCmdManagedFields(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	options := &ManagedFieldsOptions{}
+	cmd := &cobra.Command{
+		Use:     "managed-fields (TYPE [(NAME | -l label)] | TYPE/NAME ...)",
+		Short:   "Report the storage overhead of managedFields",
+		Long:    managedFields_long,
+		Example: managedFields_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(options.Complete(f, out, cmd, args))
+			cmdutil.CheckErr(options.RunManagedFields())
+		},
+	}
+	cmdutil.AddInclude3rdPartyFlags(cmd)
+	cmd.Flags().StringP("selector", "l", "", "Selector (label query) to filter on")
+	cmd.Flags().Bool("all-namespaces", false, "If present, report across all namespaces.")
+	cmd.Flags().Int("top", 10, "Number of top manager contributors to report. Use 0 for all.")
+	return cmd
+}
+
+// Complete adapts from the command line args and factory to the data required.
+func (o *ManagedFieldsOptions) Complete(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return cmdutil.UsageError(cmd, "at least one resource type must be specified")
+	}
+
+	namespace, _, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	allNamespaces := cmdutil.GetFlagBool(cmd, "all-namespaces")
+
+	mapper, typer := f.Object(cmdutil.GetIncludeThirdPartyAPIs(cmd))
+	o.builder = resource.NewBuilder(mapper, typer, resource.ClientMapperFunc(f.ClientForMapping), f.Decoder(true)).
+		ContinueOnError().
+		NamespaceParam(namespace).DefaultNamespace().AllNamespaces(allNamespaces).
+		SelectorParam(cmdutil.GetFlagString(cmd, "selector")).
+		ResourceTypeOrNameArgs(true, args...).
+		Flatten().
+		Latest()
+
+	o.top = cmdutil.GetFlagInt(cmd, "top")
+	o.out = out
+	return nil
+}
+
+// RunManagedFields scans the selected resources and prints the report.
+func (o *ManagedFieldsOptions) RunManagedFields() error {
+	r := o.builder.Do()
+	if err := r.Err(); err != nil {
+		return err
+	}
+
+	usage := managedfields.NewUsage()
+	err := r.Visit(func(info *resource.Info, err error) error {
+		if err != nil {
+			return err
+		}
+		meta, err := api.ObjectMetaFor(info.Object)
+		if err != nil {
+			return fmt.Errorf("unable to read metadata of %s/%s: %v", info.Namespace, info.Name, err)
+		}
+		usage.Observe(info.Namespace, info.Mapping.Resource, meta.ManagedFields)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(o.out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tRESOURCE\tOBJECTS\tENTRIES\tBYTES")
+	for _, ru := range usage.Resources() {
+		namespace := ru.Namespace
+		if len(namespace) == 0 {
+			namespace = "<none>"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\n", namespace, ru.Resource, ru.Objects, ru.Entries, ru.Bytes)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	managers := usage.TopManagers(o.top)
+	if len(managers) == 0 {
+		return nil
+	}
+	fmt.Fprintln(o.out)
+	w = tabwriter.NewWriter(o.out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "MANAGER\tENTRIES\tBYTES")
+	for _, mu := range managers {
+		manager := mu.Manager
+		if len(manager) == 0 {
+			manager = "<unknown>"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\n", manager, mu.Entries, mu.Bytes)
+	}
+	return w.Flush()
+}
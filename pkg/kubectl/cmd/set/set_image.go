@@ -79,6 +79,12 @@ var (
 		kubectl set image -f path/to/file.yaml nginx=nginx:1.9.1 --local -o yaml`)
 )
 
+// imageFieldManager is the manager identity recorded for kubectl set image
+// by servers that track field ownership. The command patches only the image
+// fields (and the change-cause annotation when recording), so ownership of
+// everything else stays with whichever tool manages it.
+const imageFieldManager = "kubectl-set"
+
 func NewCmdImage(f *cmdutil.Factory, out io.Writer) *cobra.Command {
 	options := &ImageOptions{
 		Out: out,
@@ -203,17 +209,21 @@ func (o *ImageOptions) Run() error {
 		}
 
 		// patch the change
-		obj, err := resource.NewHelper(info.Client, info.Mapping).Patch(info.Namespace, info.Name, api.StrategicMergePatchType, patch.Patch)
+		helper := resource.NewHelper(info.Client, info.Mapping)
+		helper.FieldManager = imageFieldManager
+		obj, err := helper.Patch(info.Namespace, info.Name, api.StrategicMergePatchType, patch.Patch)
 		if err != nil {
 			allErrs = append(allErrs, fmt.Errorf("failed to patch image update to pod template: %v\n", err))
 			continue
 		}
 		info.Refresh(obj, true)
 
-		// record this change (for rollout history)
+		// record this change (for rollout history) as a patch of just the
+		// annotation, so the full object is not replaced
 		if o.Record || cmdutil.ContainsChangeCause(info) {
-			if err := cmdutil.RecordChangeCause(obj, o.ChangeCause); err == nil {
-				if obj, err = resource.NewHelper(info.Client, info.Mapping).Replace(info.Namespace, info.Name, false, obj); err != nil {
+			causePatch, err := cmdutil.ChangeResourcePatch(info, o.ChangeCause)
+			if err == nil {
+				if obj, err = helper.Patch(info.Namespace, info.Name, api.StrategicMergePatchType, causePatch); err != nil {
 					allErrs = append(allErrs, fmt.Errorf("changes to %s/%s can't be recorded: %v\n", info.Mapping.Resource, info.Name, err))
 				}
 			}
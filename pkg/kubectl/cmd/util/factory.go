@@ -575,8 +575,9 @@ func NewFactory(optionalClientConfig clientcmd.ClientConfig) *Factory {
 				if t.Spec.Paused {
 					return true, nil
 				}
-				t.Spec.Paused = true
-				_, err := c.Extensions().Deployments(t.Namespace).Update(t)
+				// patch only the paused field so ownership of the rest of
+				// the spec is not disturbed
+				_, err := c.Extensions().Deployments(t.Namespace).Patch(t.Name, []byte(`{"spec":{"paused":true}}`))
 				return false, err
 			default:
 				gvks, _, err := api.Scheme.ObjectKinds(object)
@@ -597,8 +598,9 @@ func NewFactory(optionalClientConfig clientcmd.ClientConfig) *Factory {
 				if !t.Spec.Paused {
 					return true, nil
 				}
-				t.Spec.Paused = false
-				_, err := c.Extensions().Deployments(t.Namespace).Update(t)
+				// patch only the paused field so ownership of the rest of
+				// the spec is not disturbed
+				_, err := c.Extensions().Deployments(t.Namespace).Patch(t.Name, []byte(`{"spec":{"paused":false}}`))
 				return false, err
 			default:
 				gvks, _, err := api.Scheme.ObjectKinds(object)
@@ -1119,41 +1121,63 @@ func (c *clientSwaggerSchema) ValidateBytes(data []byte) error {
 }
 
 // DefaultClientConfig creates a clientcmd.ClientConfig with the following hierarchy:
-//   1.  Use the kubeconfig builder.  The number of merges and overrides here gets a little crazy.  Stay with me.
-//       1.  Merge together the kubeconfig itself.  This is done with the following hierarchy rules:
-//           1.  CommandLineLocation - this parsed from the command line, so it must be late bound.  If you specify this,
-//               then no other kubeconfig files are merged.  This file must exist.
-//           2.  If $KUBECONFIG is set, then it is treated as a list of files that should be merged.
-//	     3.  HomeDirectoryLocation
-//           Empty filenames are ignored.  Files with non-deserializable content produced errors.
-//           The first file to set a particular value or map key wins and the value or map key is never changed.
-//           This means that the first file to set CurrentContext will have its context preserved.  It also means
-//           that if two files specify a "red-user", only values from the first file's red-user are used.  Even
-//           non-conflicting entries from the second file's "red-user" are discarded.
-//       2.  Determine the context to use based on the first hit in this chain
-//           1.  command line argument - again, parsed from the command line, so it must be late bound
-//           2.  CurrentContext from the merged kubeconfig file
-//           3.  Empty is allowed at this stage
-//       3.  Determine the cluster info and auth info to use.  At this point, we may or may not have a context.  They
-//           are built based on the first hit in this chain.  (run it twice, once for auth, once for cluster)
-//           1.  command line argument
-//           2.  If context is present, then use the context value
-//           3.  Empty is allowed
-//       4.  Determine the actual cluster info to use.  At this point, we may or may not have a cluster info.  Build
-//           each piece of the cluster info based on the chain:
-//           1.  command line argument
-//           2.  If cluster info is present and a value for the attribute is present, use it.
-//           3.  If you don't have a server location, bail.
-//       5.  Auth info is build using the same rules as cluster info, EXCEPT that you can only have one authentication
-//           technique per auth info.  The following conditions result in an error:
-//           1.  If there are two conflicting techniques specified from the command line, fail.
-//           2.  If the command line does not specify one, and the auth info has conflicting techniques, fail.
-//           3.  If the command line specifies one and the auth info specifies another, honor the command line technique.
-//   2.  Use default values and potentially prompt for auth information
 //
-//   However, if it appears that we're running in a kubernetes cluster
-//   container environment, then run with the auth info kubernetes mounted for
-//   us. Specifically:
+//  1. Use the kubeconfig builder.  The number of merges and overrides here gets a little crazy.  Stay with me.
+//
+//  1. Merge together the kubeconfig itself.  This is done with the following hierarchy rules:
+//
+//  1. CommandLineLocation - this parsed from the command line, so it must be late bound.  If you specify this,
+//     then no other kubeconfig files are merged.  This file must exist.
+//
+//  2. If $KUBECONFIG is set, then it is treated as a list of files that should be merged.
+//
+//  3. HomeDirectoryLocation
+//     Empty filenames are ignored.  Files with non-deserializable content produced errors.
+//     The first file to set a particular value or map key wins and the value or map key is never changed.
+//     This means that the first file to set CurrentContext will have its context preserved.  It also means
+//     that if two files specify a "red-user", only values from the first file's red-user are used.  Even
+//     non-conflicting entries from the second file's "red-user" are discarded.
+//
+//  2. Determine the context to use based on the first hit in this chain
+//
+//  1. command line argument - again, parsed from the command line, so it must be late bound
+//
+//  2. CurrentContext from the merged kubeconfig file
+//
+//  3. Empty is allowed at this stage
+//
+//  3. Determine the cluster info and auth info to use.  At this point, we may or may not have a context.  They
+//     are built based on the first hit in this chain.  (run it twice, once for auth, once for cluster)
+//
+//  1. command line argument
+//
+//  2. If context is present, then use the context value
+//
+//  3. Empty is allowed
+//
+//  4. Determine the actual cluster info to use.  At this point, we may or may not have a cluster info.  Build
+//     each piece of the cluster info based on the chain:
+//
+//  1. command line argument
+//
+//  2. If cluster info is present and a value for the attribute is present, use it.
+//
+//  3. If you don't have a server location, bail.
+//
+//  5. Auth info is build using the same rules as cluster info, EXCEPT that you can only have one authentication
+//     technique per auth info.  The following conditions result in an error:
+//
+//  1. If there are two conflicting techniques specified from the command line, fail.
+//
+//  2. If the command line does not specify one, and the auth info has conflicting techniques, fail.
+//
+//  3. If the command line specifies one and the auth info specifies another, honor the command line technique.
+//
+//  2. Use default values and potentially prompt for auth information
+//
+//     However, if it appears that we're running in a kubernetes cluster
+//     container environment, then run with the auth info kubernetes mounted for
+//     us. Specifically:
 //     The env vars KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT are
 //     set, and the file /var/run/secrets/kubernetes.io/serviceaccount/token
 //     exists and is not a directory.
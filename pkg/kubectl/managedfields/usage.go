@@ -0,0 +1,166 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package managedfields computes the storage overhead of managedFields
+// across a set of objects, so that operators can decide where field
+// tracking is worth capping or disabling.
+package managedfields
+
+import (
+	"encoding/json"
+	"sort"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+// ResourceUsage is the managedFields overhead accumulated for all observed
+// objects of one resource in one namespace.
+type ResourceUsage struct {
+	// Namespace of the observed objects, empty for cluster-scoped resources.
+	Namespace string
+	// Resource is the lowercase plural resource name, e.g. "pods".
+	Resource string
+	// Objects is the number of objects observed.
+	Objects int
+	// Entries is the total number of managedFields entries observed.
+	Entries int
+	// Bytes is the serialized size of all observed managedFields.
+	Bytes int64
+}
+
+// ManagerUsage is the managedFields overhead attributed to a single manager
+// across all observed objects.
+type ManagerUsage struct {
+	// Manager is the workflow identifier from the managedFields entries.
+	Manager string
+	// Entries is the number of entries recorded for this manager.
+	Entries int
+	// Bytes is the serialized size of this manager's entries.
+	Bytes int64
+}
+
+// Usage accumulates managedFields overhead per namespace, resource and
+// manager. It is not safe for concurrent use.
+type Usage struct {
+	resources map[resourceKey]*ResourceUsage
+	managers  map[string]*ManagerUsage
+}
+
+type resourceKey struct {
+	namespace string
+	resource  string
+}
+
+// NewUsage returns an empty accumulator.
+func NewUsage() *Usage {
+	return &Usage{
+		resources: map[resourceKey]*ResourceUsage{},
+		managers:  map[string]*ManagerUsage{},
+	}
+}
+
+// Observe adds the managedFields of a single object to the accumulated
+// totals. Objects without managedFields still count towards the object
+// total so that averages remain meaningful.
+func (u *Usage) Observe(namespace, resource string, entries []api.ManagedFieldsEntry) {
+	key := resourceKey{namespace: namespace, resource: resource}
+	ru := u.resources[key]
+	if ru == nil {
+		ru = &ResourceUsage{Namespace: namespace, Resource: resource}
+		u.resources[key] = ru
+	}
+	ru.Objects++
+	for _, entry := range entries {
+		size := entrySize(entry)
+		ru.Entries++
+		ru.Bytes += size
+
+		mu := u.managers[entry.Manager]
+		if mu == nil {
+			mu = &ManagerUsage{Manager: entry.Manager}
+			u.managers[entry.Manager] = mu
+		}
+		mu.Entries++
+		mu.Bytes += size
+	}
+}
+
+// Resources returns the accumulated per-namespace, per-resource usage,
+// sorted by descending size.
+func (u *Usage) Resources() []ResourceUsage {
+	result := make([]ResourceUsage, 0, len(u.resources))
+	for _, ru := range u.resources {
+		result = append(result, *ru)
+	}
+	sort.Sort(resourcesBySize(result))
+	return result
+}
+
+// TopManagers returns the n managers contributing the most bytes, sorted by
+// descending size. If n is non-positive or exceeds the number of observed
+// managers, all managers are returned.
+func (u *Usage) TopManagers(n int) []ManagerUsage {
+	result := make([]ManagerUsage, 0, len(u.managers))
+	for _, mu := range u.managers {
+		result = append(result, *mu)
+	}
+	sort.Sort(managersBySize(result))
+	if n > 0 && n < len(result) {
+		result = result[:n]
+	}
+	return result
+}
+
+// entrySize returns the serialized JSON size of a managedFields entry,
+// which is what the entry costs in etcd for resources stored as JSON.
+func entrySize(entry api.ManagedFieldsEntry) int64 {
+	size := int64(0)
+	if entry.FieldsV1 != nil {
+		size += int64(len(entry.FieldsV1.Raw))
+	}
+	// The fields payload dominates, but the fixed parts of the entry are
+	// not free either.
+	entry.FieldsV1 = nil
+	if data, err := json.Marshal(entry); err == nil {
+		size += int64(len(data))
+	}
+	return size
+}
+
+type resourcesBySize []ResourceUsage
+
+func (s resourcesBySize) Len() int      { return len(s) }
+func (s resourcesBySize) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s resourcesBySize) Less(i, j int) bool {
+	if s[i].Bytes != s[j].Bytes {
+		return s[i].Bytes > s[j].Bytes
+	}
+	if s[i].Namespace != s[j].Namespace {
+		return s[i].Namespace < s[j].Namespace
+	}
+	return s[i].Resource < s[j].Resource
+}
+
+type managersBySize []ManagerUsage
+
+func (s managersBySize) Len() int      { return len(s) }
+func (s managersBySize) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s managersBySize) Less(i, j int) bool {
+	if s[i].Bytes != s[j].Bytes {
+		return s[i].Bytes > s[j].Bytes
+	}
+	return s[i].Manager < s[j].Manager
+}
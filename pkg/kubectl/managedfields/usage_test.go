@@ -0,0 +1,87 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managedfields
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func entry(manager string, fields string) api.ManagedFieldsEntry {
+	return api.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  api.ManagedFieldsOperationUpdate,
+		APIVersion: "v1",
+		FieldsType: "FieldsV1",
+		FieldsV1:   &api.FieldsV1{Raw: []byte(fields)},
+	}
+}
+
+func TestUsageResources(t *testing.T) {
+	usage := NewUsage()
+	usage.Observe("default", "pods", []api.ManagedFieldsEntry{
+		entry("kubelet", `{"f:status":{}}`),
+		entry("controller", `{"f:spec":{}}`),
+	})
+	usage.Observe("default", "pods", nil)
+	usage.Observe("kube-system", "configmaps", []api.ManagedFieldsEntry{
+		entry("controller", `{"f:data":{"f:a":{},"f:b":{},"f:c":{}}}`),
+	})
+
+	resources := usage.Resources()
+	if len(resources) != 2 {
+		t.Fatalf("expected 2 resource entries, got %v", resources)
+	}
+	pods := resources[1]
+	if pods.Bytes > resources[0].Bytes {
+		t.Errorf("expected resources sorted by descending size, got %v", resources)
+	}
+	for _, ru := range resources {
+		if ru.Resource == "pods" {
+			pods = ru
+		}
+	}
+	if pods.Namespace != "default" || pods.Objects != 2 || pods.Entries != 2 {
+		t.Errorf("unexpected pod usage: %+v", pods)
+	}
+	if pods.Bytes <= int64(len(`{"f:status":{}}`)+len(`{"f:spec":{}}`)) {
+		t.Errorf("expected entry overhead to be accounted for, got %d bytes", pods.Bytes)
+	}
+}
+
+func TestUsageTopManagers(t *testing.T) {
+	usage := NewUsage()
+	usage.Observe("default", "pods", []api.ManagedFieldsEntry{
+		entry("kubelet", `{"f:status":{}}`),
+		entry("kubelet", `{"f:status":{"f:phase":{}}}`),
+		entry("controller", `{"f:spec":{}}`),
+	})
+
+	managers := usage.TopManagers(0)
+	if len(managers) != 2 {
+		t.Fatalf("expected 2 managers, got %v", managers)
+	}
+	if managers[0].Manager != "kubelet" || managers[0].Entries != 2 {
+		t.Errorf("expected kubelet to be the top manager, got %v", managers)
+	}
+
+	managers = usage.TopManagers(1)
+	if len(managers) != 1 || managers[0].Manager != "kubelet" {
+		t.Errorf("expected only kubelet, got %v", managers)
+	}
+}
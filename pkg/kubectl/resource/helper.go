@@ -38,6 +38,9 @@ type Helper struct {
 	Versioner runtime.ResourceVersioner
 	// True if the resource type is scoped to namespaces
 	NamespaceScoped bool
+	// An optional manager identity recorded in the object's managedFields
+	// by servers that track field ownership. Sent with patches when set.
+	FieldManager string
 }
 
 // NewHelper creates a Helper from a ResourceMapping
@@ -124,11 +127,14 @@ func (m *Helper) createResource(c RESTClient, resource, namespace string, obj ru
 	return c.Post().NamespaceIfScoped(namespace, m.NamespaceScoped).Resource(resource).Body(obj).Do().Get()
 }
 func (m *Helper) Patch(namespace, name string, pt api.PatchType, data []byte) (runtime.Object, error) {
-	return m.RESTClient.Patch(pt).
+	req := m.RESTClient.Patch(pt).
 		NamespaceIfScoped(namespace, m.NamespaceScoped).
 		Resource(m.Resource).
-		Name(name).
-		Body(data).
+		Name(name)
+	if m.FieldManager != "" {
+		req.Param("fieldManager", m.FieldManager)
+	}
+	return req.Body(data).
 		Do().
 		Get()
 }
@@ -68,9 +68,32 @@ func (s *SelectionPredicate) Matches(obj runtime.Object) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	if manager, ok := s.Field.RequiresExactMatch(storage.ManagedFieldsManagerIndex); ok {
+		// An object can have several managers, so the value cannot come from
+		// GetAttrs: a fields.Set holds a single value per key. Satisfy the
+		// term here when any managedFields entry carries the manager.
+		if hasManager(obj, manager) {
+			fields = MergeFieldsSets(map[string]string{storage.ManagedFieldsManagerIndex: manager}, fields)
+		}
+	}
 	return s.Label.Matches(labels) && s.Field.Matches(fields), nil
 }
 
+// hasManager returns true if any of the object's managedFields entries was
+// recorded by the provided manager.
+func hasManager(obj runtime.Object, manager string) bool {
+	objectMeta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		return false
+	}
+	for _, entry := range objectMeta.ManagedFields {
+		if entry.Manager == manager {
+			return true
+		}
+	}
+	return false
+}
+
 // MatchesSingle will return (name, true) if and only if s.Field matches on the object's
 // name.
 func (s *SelectionPredicate) MatchesSingle() (string, bool) {
@@ -92,6 +115,11 @@ func (s *SelectionPredicate) MatcherIndex() []storage.MatchValue {
 			result = append(result, storage.MatchValue{IndexName: field, Value: value})
 		}
 	}
+	// The watch cache maintains the managedFields manager index for every
+	// resource, so it does not require per-resource opt in via IndexFields.
+	if value, ok := s.Field.RequiresExactMatch(storage.ManagedFieldsManagerIndex); ok {
+		result = append(result, storage.MatchValue{IndexName: storage.ManagedFieldsManagerIndex, Value: value})
+	}
 	return result
 }
 
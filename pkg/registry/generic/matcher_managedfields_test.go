@@ -0,0 +1,86 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generic
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/storage"
+)
+
+func TestSelectionPredicateManagedFieldsManager(t *testing.T) {
+	table := map[string]struct {
+		fieldSelector string
+		managers      []string
+		shouldMatch   bool
+	}{
+		"matching manager": {
+			fieldSelector: "metadata.managedFields.manager=my-operator",
+			managers:      []string{"kubectl", "my-operator"},
+			shouldMatch:   true,
+		},
+		"no matching manager": {
+			fieldSelector: "metadata.managedFields.manager=my-operator",
+			managers:      []string{"kubectl"},
+			shouldMatch:   false,
+		},
+		"no managed fields": {
+			fieldSelector: "metadata.managedFields.manager=my-operator",
+			shouldMatch:   false,
+		},
+	}
+
+	for name, item := range table {
+		parsedField, err := fields.ParseSelector(item.fieldSelector)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", name, err)
+		}
+		obj := &api.Pod{}
+		for _, manager := range item.managers {
+			obj.ManagedFields = append(obj.ManagedFields, api.ManagedFieldsEntry{Manager: manager})
+		}
+		sp := &SelectionPredicate{
+			Label: labels.Everything(),
+			Field: parsedField,
+			GetAttrs: func(runtime.Object) (labels.Set, fields.Set, error) {
+				return labels.Set{}, fields.Set{}, nil
+			},
+		}
+		got, err := sp.Matches(obj)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+			continue
+		}
+		if e, a := item.shouldMatch, got; e != a {
+			t.Errorf("%v: expected %v, got %v", name, e, a)
+		}
+
+		matchValues := sp.MatcherIndex()
+		if len(matchValues) != 1 {
+			t.Errorf("%v: expected one match value, got %v", name, matchValues)
+			continue
+		}
+		expected := storage.MatchValue{IndexName: storage.ManagedFieldsManagerIndex, Value: "my-operator"}
+		if matchValues[0] != expected {
+			t.Errorf("%v: expected %v, got %v", name, expected, matchValues[0])
+		}
+	}
+}
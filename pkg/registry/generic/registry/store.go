@@ -30,6 +30,7 @@ import (
 	"k8s.io/kubernetes/pkg/api/rest"
 	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/apiserver/fieldmanager"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/generic"
@@ -46,6 +47,26 @@ import (
 // must be synced with the corresponding flag in kube-controller-manager.
 var EnableGarbageCollector bool
 
+// EnableFieldManagement turns on managedFields maintenance in every generic
+// registry. When set, each write updates metadata.managedFields through the
+// store's FieldManager, or through a shared default chain when none is
+// configured for the resource.
+var EnableFieldManagement bool
+
+var defaultFieldManager = fieldmanager.NewBudgetManager(fieldmanager.NewDefaultManager(), fieldmanager.DefaultMergeBudget)
+
+// RejectClientManagedFields opts in to rejecting writes that carry
+// client-supplied managedFields entries with a 400 instead of letting them
+// through, so automation bugs that round-trip objects incorrectly fail
+// loudly. Only consulted when EnableFieldManagement is set.
+var RejectClientManagedFields bool
+
+// RecordManagerUIDs opts in to linking each managedFields entry to the
+// authenticated user UID that produced it, for forensic attribution when
+// many identities share a manager name. Only consulted when
+// EnableFieldManagement is set.
+var RecordManagerUIDs bool
+
 // Store implements generic.Registry.
 // It's intended to be embeddable, so that you can implement any
 // non-generic functions if needed.
@@ -118,6 +139,17 @@ type Store struct {
 	// Allows extended behavior during export, optional
 	ExportStrategy rest.RESTExportStrategy
 
+	// If true, updates whose only effect is bumping managedFields entry
+	// timestamps are not persisted: the live object is kept as-is, so
+	// heartbeat-style writers do not create etcd revisions or watch events.
+	ElideTimestampOnlyUpdates bool
+
+	// FieldManager overrides the manager chain used to maintain
+	// metadata.managedFields for this resource. It is only consulted when
+	// EnableFieldManagement is set; when nil, the shared default chain is
+	// used.
+	FieldManager fieldmanager.Manager
+
 	// Used for all storage access functions
 	Storage storage.Interface
 }
@@ -206,11 +238,107 @@ func (e *Store) ListPredicate(ctx api.Context, m generic.Matcher, options *api.L
 	return list, storeerr.InterpretListError(err, e.QualifiedResource)
 }
 
+// fieldManager returns the manager chain maintaining managedFields for this
+// resource, or nil when field management is disabled.
+func (e *Store) fieldManager() fieldmanager.Manager {
+	if !EnableFieldManagement {
+		return nil
+	}
+	if e.FieldManager != nil {
+		return e.FieldManager
+	}
+	return defaultFieldManager
+}
+
+// managerNameFrom derives the acting manager's name from the request
+// context: an explicit fieldManager supplied on the request wins over the
+// authenticated username, so components that cannot yet apply still record
+// meaningful attribution on their creates and updates.
+func managerNameFrom(ctx api.Context) string {
+	if manager, ok := api.FieldManagerFrom(ctx); ok && len(manager) > 0 {
+		return manager
+	}
+	if user, ok := api.UserFrom(ctx); ok && len(user.GetName()) > 0 {
+		return user.GetName()
+	}
+	return "unknown"
+}
+
+// updateManagedFields runs the manager chain for a write of obj over existing
+// and stores the resulting entries back on the object.
+func (e *Store) updateManagedFields(ctx api.Context, existing, obj runtime.Object) (runtime.Object, error) {
+	manager := e.fieldManager()
+	if manager == nil {
+		return obj, nil
+	}
+	if !fieldmanager.ResourceEnabled(e.QualifiedResource) {
+		return obj, nil
+	}
+	if namespace, ok := api.NamespaceFrom(ctx); ok && fieldmanager.NamespaceDisabled(namespace) {
+		return obj, nil
+	}
+	if _, err := api.ObjectMetaFor(obj); err != nil {
+		// objects without ObjectMeta have no managedFields to maintain
+		return obj, nil
+	}
+	if RejectClientManagedFields && fieldmanager.ClientSuppliedManagedFields(existing, obj) {
+		return nil, kubeerr.NewBadRequest(fieldmanager.ClientManagedFieldsMessage)
+	}
+	// the managed state lives on the stored object: request bodies do not
+	// usually echo managedFields back, and an update that omits them must not
+	// wipe the recorded history
+	var managed []api.ManagedFieldsEntry
+	if existingMeta, err := api.ObjectMetaFor(existing); err == nil {
+		managed = existingMeta.ManagedFields
+	}
+	obj, managed, err := manager.Update(existing, obj, managed, managerNameFrom(ctx))
+	if err != nil {
+		if fieldmanager.IsBudgetExceeded(err) {
+			return nil, kubeerr.NewTimeoutError(err.Error(), 0)
+		}
+		return nil, err
+	}
+	now := time.Now()
+	if objectMeta, err := api.ObjectMetaFor(obj); err == nil {
+		objectMeta.ManagedFields = managed
+		if remaining, changed, err := fieldmanager.ExpireEphemeralEntries(obj, objectMeta.ManagedFields, now); err == nil && changed {
+			objectMeta.ManagedFields = remaining
+		}
+	}
+	if ttl, ok := api.FieldManagerTTLFrom(ctx); ok && ttl > 0 {
+		if err := fieldmanager.RecordEntryExpiry(obj, managerNameFrom(ctx), now.Add(ttl)); err != nil {
+			return nil, err
+		}
+	}
+	if RecordManagerUIDs {
+		if user, ok := api.UserFrom(ctx); ok && len(user.GetUID()) > 0 {
+			if err := fieldmanager.RecordEntryUID(obj, managerNameFrom(ctx), user.GetUID()); err != nil {
+				return nil, err
+			}
+		}
+		impersonator := ""
+		if realUser, ok := api.ImpersonatingUserFrom(ctx); ok {
+			impersonator = realUser.GetName()
+		}
+		if err := fieldmanager.RecordEntryImpersonator(obj, managerNameFrom(ctx), impersonator); err != nil {
+			return nil, err
+		}
+		if err := fieldmanager.PruneEntryUIDs(obj); err != nil {
+			return nil, err
+		}
+	}
+	return obj, nil
+}
+
 // Create inserts a new item according to the unique key from the object.
 func (e *Store) Create(ctx api.Context, obj runtime.Object) (runtime.Object, error) {
 	if err := rest.BeforeCreate(e.CreateStrategy, ctx, obj); err != nil {
 		return nil, err
 	}
+	obj, err := e.updateManagedFields(ctx, e.NewFunc(), obj)
+	if err != nil {
+		return nil, err
+	}
 	name, err := e.ObjectNameFunc(obj)
 	if err != nil {
 		return nil, err
@@ -336,6 +464,9 @@ func (e *Store) Update(ctx api.Context, name string, objInfo rest.UpdatedObjectI
 			if err := rest.BeforeCreate(e.CreateStrategy, ctx, obj); err != nil {
 				return nil, nil, err
 			}
+			if obj, err = e.updateManagedFields(ctx, existing, obj); err != nil {
+				return nil, nil, err
+			}
 			ttl, err := e.calculateTTL(obj, 0, false)
 			if err != nil {
 				return nil, nil, err
@@ -372,11 +503,25 @@ func (e *Store) Update(ctx api.Context, name string, objInfo rest.UpdatedObjectI
 		if err := rest.BeforeUpdate(e.UpdateStrategy, ctx, obj, existing); err != nil {
 			return nil, nil, err
 		}
+		if obj, err = e.updateManagedFields(ctx, existing, obj); err != nil {
+			return nil, nil, err
+		}
 		delete := e.shouldDelete(ctx, key, obj, existing)
 		if delete {
 			deleteObj = obj
 			return nil, nil, errEmptiedFinalizers
 		}
+		if e.ElideTimestampOnlyUpdates {
+			timestampOnly, err := fieldmanager.TimestampOnlyChange(existing, obj)
+			if err != nil {
+				return nil, nil, err
+			}
+			if timestampOnly {
+				// returning the existing object unchanged lets the storage
+				// layer skip the write entirely
+				return existing, nil, nil
+			}
+		}
 		ttl, err := e.calculateTTL(obj, res.TTL, true)
 		if err != nil {
 			return nil, nil, err
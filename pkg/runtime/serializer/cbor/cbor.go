@@ -0,0 +1,187 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cbor implements a runtime.Serializer for the Concise Binary Object
+// Representation (RFC 7049). CBOR is a compact, self-describing binary format:
+// unlike protobuf, objects carry their field names on the wire, so the format
+// also works for types that have no generated marshallers.
+package cbor
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/ugorji/go/codec"
+
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// selfDescribedCBOR is the encoded form of the self-described CBOR tag (55799).
+// Encoded objects are prefixed with it so the format can be distinguished from
+// JSON and protobuf when recognizing arbitrary data.
+var selfDescribedCBOR = []byte{0xd9, 0xd9, 0xf7}
+
+// NewSerializer creates a CBOR serializer that handles encoding versioned objects
+// into the proper CBOR form. If typer is not nil, the object has the group,
+// version, and kind fields set.
+func NewSerializer(creater runtime.ObjectCreater, typer runtime.ObjectTyper) *Serializer {
+	return &Serializer{
+		creater: creater,
+		typer:   typer,
+	}
+}
+
+type Serializer struct {
+	creater runtime.ObjectCreater
+	typer   runtime.ObjectTyper
+}
+
+var _ runtime.Serializer = &Serializer{}
+
+// Decode attempts to convert the provided data into a CBOR object, extract the stored
+// schema kind, apply the provided default gvk, and then load that data into an object
+// matching the desired schema kind or the provided into. If into is *runtime.Unknown,
+// the raw data will be extracted and no decoding will be performed. If into is provided
+// and the original data is not fully qualified with kind/version/group, the type of the
+// into will be used to alter the returned gvk. On success or most errors, the method
+// will return the calculated schema kind.
+func (s *Serializer) Decode(originalData []byte, gvk *unversioned.GroupVersionKind, into runtime.Object) (runtime.Object, *unversioned.GroupVersionKind, error) {
+	if versioned, ok := into.(*runtime.VersionedObjects); ok {
+		into = versioned.Last()
+		obj, actual, err := s.Decode(originalData, gvk, into)
+		if err != nil {
+			return nil, actual, err
+		}
+		// record both the decoded object and the caller's into when they
+		// differ, as the other serializers do, so callers see the original
+		// form alongside the converted one
+		if into != nil && into != obj {
+			versioned.Objects = []runtime.Object{obj, into}
+		} else {
+			versioned.Objects = []runtime.Object{obj}
+		}
+		return versioned, actual, nil
+	}
+
+	data := bytes.TrimPrefix(originalData, selfDescribedCBOR)
+
+	actual := &unversioned.GroupVersionKind{}
+	findKind := struct {
+		// +optional
+		APIVersion string `json:"apiVersion,omitempty"`
+		// +optional
+		Kind string `json:"kind,omitempty"`
+	}{}
+	if err := codec.NewDecoderBytes(data, new(codec.CborHandle)).Decode(&findKind); err != nil {
+		return nil, nil, fmt.Errorf("couldn't get version/kind; cbor parse error: %v", err)
+	}
+	gv, err := unversioned.ParseGroupVersion(findKind.APIVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	*actual = gv.WithKind(findKind.Kind)
+
+	if gvk != nil {
+		// apply kind and version defaulting from provided default
+		if len(actual.Kind) == 0 {
+			actual.Kind = gvk.Kind
+		}
+		if len(actual.Version) == 0 && len(actual.Group) == 0 {
+			actual.Group = gvk.Group
+			actual.Version = gvk.Version
+		}
+		if len(actual.Version) == 0 && actual.Group == gvk.Group {
+			actual.Version = gvk.Version
+		}
+	}
+
+	if unk, ok := into.(*runtime.Unknown); ok && unk != nil {
+		unk.Raw = originalData
+		unk.ContentType = runtime.ContentTypeCBOR
+		unk.GetObjectKind().SetGroupVersionKind(*actual)
+		return unk, actual, nil
+	}
+
+	if into != nil {
+		types, _, err := s.typer.ObjectKinds(into)
+		switch {
+		case runtime.IsNotRegisteredError(err):
+			if err := codec.NewDecoderBytes(data, new(codec.CborHandle)).Decode(into); err != nil {
+				return nil, actual, err
+			}
+			return into, actual, nil
+		case err != nil:
+			return nil, actual, err
+		default:
+			typed := types[0]
+			if len(actual.Kind) == 0 {
+				actual.Kind = typed.Kind
+			}
+			if len(actual.Version) == 0 && len(actual.Group) == 0 {
+				actual.Group = typed.Group
+				actual.Version = typed.Version
+			}
+			if len(actual.Version) == 0 && actual.Group == typed.Group {
+				actual.Version = typed.Version
+			}
+		}
+	}
+
+	if len(actual.Kind) == 0 {
+		return nil, actual, runtime.NewMissingKindErr("<cbor encoded body>")
+	}
+	if len(actual.Version) == 0 {
+		return nil, actual, runtime.NewMissingVersionErr("<cbor encoded body>")
+	}
+
+	// use the target if necessary
+	obj, err := runtime.UseOrCreateObject(s.typer, s.creater, *actual, into)
+	if err != nil {
+		return nil, actual, err
+	}
+
+	if err := codec.NewDecoderBytes(data, new(codec.CborHandle)).Decode(obj); err != nil {
+		return nil, actual, err
+	}
+	return obj, actual, nil
+}
+
+// Encode serializes the provided object to the given writer, prefixed with the
+// self-described CBOR tag.
+func (s *Serializer) Encode(obj runtime.Object, w io.Writer) error {
+	if _, err := w.Write(selfDescribedCBOR); err != nil {
+		return err
+	}
+	return codec.NewEncoder(w, new(codec.CborHandle)).Encode(obj)
+}
+
+// RecognizesData implements the RecognizingDecoder interface.
+func (s *Serializer) RecognizesData(peek io.Reader) (ok, unknown bool, err error) {
+	prefix := make([]byte, len(selfDescribedCBOR))
+	n, err := peek.Read(prefix)
+	if err != nil {
+		if err == io.EOF {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+	if n != len(selfDescribedCBOR) {
+		return false, false, nil
+	}
+	return bytes.Equal(selfDescribedCBOR, prefix), false, nil
+}
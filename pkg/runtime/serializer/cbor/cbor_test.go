@@ -0,0 +1,156 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cbor_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/runtime/serializer/cbor"
+)
+
+type testDecodable struct {
+	unversioned.TypeMeta `json:",inline"`
+
+	Other string `json:"other,omitempty"`
+	Value int    `json:"value"`
+}
+
+func (d *testDecodable) GetObjectKind() unversioned.ObjectKind { return &d.TypeMeta }
+
+type mockCreater struct {
+	obj runtime.Object
+	err error
+}
+
+func (c *mockCreater) New(kind unversioned.GroupVersionKind) (runtime.Object, error) {
+	return c.obj, c.err
+}
+
+type mockTyper struct {
+	gvk *unversioned.GroupVersionKind
+	err error
+}
+
+func (t *mockTyper) ObjectKinds(obj runtime.Object) ([]unversioned.GroupVersionKind, bool, error) {
+	if t.gvk == nil {
+		return nil, false, t.err
+	}
+	return []unversioned.GroupVersionKind{*t.gvk}, false, t.err
+}
+
+func (t *mockTyper) Recognizes(_ unversioned.GroupVersionKind) bool {
+	return false
+}
+
+func TestRoundTrip(t *testing.T) {
+	gvk := unversioned.GroupVersionKind{Group: "other", Version: "blah", Kind: "Test"}
+	obj := &testDecodable{
+		TypeMeta: unversioned.TypeMeta{APIVersion: "other/blah", Kind: "Test"},
+		Other:    "test",
+		Value:    42,
+	}
+
+	s := cbor.NewSerializer(&mockCreater{obj: &testDecodable{}}, &mockTyper{gvk: &gvk})
+	buf := &bytes.Buffer{}
+	if err := s.Encode(obj, buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	decoded, actual, err := s.Decode(buf.Bytes(), nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *actual != gvk {
+		t.Errorf("unexpected gvk: %#v", actual)
+	}
+	if !reflect.DeepEqual(obj, decoded) {
+		t.Errorf("unexpected object:\n%#v\n%#v", obj, decoded)
+	}
+}
+
+func TestDecodeVersionedObjects(t *testing.T) {
+	gvk := unversioned.GroupVersionKind{Group: "other", Version: "blah", Kind: "Test"}
+	obj := &testDecodable{
+		TypeMeta: unversioned.TypeMeta{APIVersion: "other/blah", Kind: "Test"},
+		Other:    "test",
+		Value:    42,
+	}
+
+	s := cbor.NewSerializer(&mockCreater{obj: &testDecodable{}}, &mockTyper{gvk: &gvk})
+	buf := &bytes.Buffer{}
+	if err := s.Encode(obj, buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// an empty list records just the decoded object
+	versioned := &runtime.VersionedObjects{}
+	decoded, actual, err := s.Decode(buf.Bytes(), nil, versioned)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *actual != gvk {
+		t.Errorf("unexpected gvk: %#v", actual)
+	}
+	if decoded != runtime.Object(versioned) {
+		t.Fatalf("expected the versioned list to be returned, got %#v", decoded)
+	}
+	if len(versioned.Objects) != 1 || !reflect.DeepEqual(obj, versioned.Objects[0]) {
+		t.Errorf("unexpected objects: %#v", versioned.Objects)
+	}
+
+	// when the caller's into cannot hold the decoded kind, both the decoded
+	// object and the into are recorded
+	other := unversioned.GroupVersionKind{Group: "other", Version: "blah", Kind: "Other"}
+	s = cbor.NewSerializer(&mockCreater{obj: &testDecodable{}}, &mockTyper{gvk: &other})
+	into := &testDecodable{}
+	versioned = &runtime.VersionedObjects{Objects: []runtime.Object{into}}
+	if _, _, err := s.Decode(buf.Bytes(), nil, versioned); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(versioned.Objects) != 2 || versioned.Objects[1] != runtime.Object(into) {
+		t.Errorf("expected the decoded object and the into, got %#v", versioned.Objects)
+	}
+	if !reflect.DeepEqual(obj, versioned.Objects[0]) {
+		t.Errorf("unexpected decoded object: %#v", versioned.Objects[0])
+	}
+}
+
+func TestRecognizesData(t *testing.T) {
+	s := cbor.NewSerializer(nil, nil)
+	buf := &bytes.Buffer{}
+	if err := s.Encode(&testDecodable{}, buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ok, unknown, err := s.RecognizesData(buf)
+	if err != nil || unknown {
+		t.Fatalf("unexpected result: %v %v", unknown, err)
+	}
+	if !ok {
+		t.Errorf("expected encoded output to be recognized")
+	}
+	ok, _, err = s.RecognizesData(bytes.NewBufferString("{\"kind\":\"Test\"}"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Errorf("JSON must not be recognized as CBOR")
+	}
+}
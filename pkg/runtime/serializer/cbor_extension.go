@@ -0,0 +1,36 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serializer
+
+import (
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/runtime/serializer/cbor"
+)
+
+func cborSerializer(scheme *runtime.Scheme) (serializerType, bool) {
+	serializer := cbor.NewSerializer(scheme, scheme)
+	return serializerType{
+		AcceptContentTypes: []string{runtime.ContentTypeCBOR},
+		ContentType:        runtime.ContentTypeCBOR,
+		FileExtensions:     []string{"cbor"},
+		Serializer:         serializer,
+	}, true
+}
+
+func init() {
+	serializerExtensions = append(serializerExtensions, cborSerializer)
+}
@@ -59,8 +59,16 @@ type serializerType struct {
 	StreamSpecialize func(map[string]string) (runtime.Serializer, bool)
 }
 
+// EnableGeneratedJSONCodecs switches the application/json serializer to the
+// generated encode fast path for types with codecgen output. It must be set
+// before any CodecFactory is constructed, typically from a server flag.
+var EnableGeneratedJSONCodecs = false
+
 func newSerializersForScheme(scheme *runtime.Scheme, mf json.MetaFactory) []serializerType {
 	jsonSerializer := json.NewSerializer(mf, scheme, scheme, false)
+	if EnableGeneratedJSONCodecs {
+		jsonSerializer = json.NewFastSerializer(mf, scheme, scheme)
+	}
 	jsonPrettySerializer := json.NewSerializer(mf, scheme, scheme, true)
 	yamlSerializer := json.NewYAMLSerializer(mf, scheme, scheme)
 
@@ -0,0 +1,103 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package encodecache provides an encoder wrapper that memoizes the encoded
+// form of recently encoded objects. A watch event fanned out to hundreds of
+// watchers is otherwise re-serialized once per watcher even though the bytes
+// are identical for every connection using the same media type and version.
+package encodecache
+
+import (
+	"bytes"
+	"io"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// cacheSize bounds the number of encoded objects retained across all watch
+// connections. Entries are evicted in LRU order.
+const cacheSize = 1024
+
+// encodedObjects is shared by every caching encoder so that watchers created
+// for different connections benefit from each other's work.
+var encodedObjects, _ = lru.New(cacheSize)
+
+type cachingEncoder struct {
+	encoder runtime.Encoder
+	// id distinguishes encoders that produce different bytes for the same
+	// object, such as different media types or target versions.
+	id string
+}
+
+// NewCachingEncoder wraps encoder with a cache of encoded objects. id must
+// uniquely identify the byte output of the encoder (media type and target
+// version); encoders constructed with the same id share cached entries.
+func NewCachingEncoder(encoder runtime.Encoder, id string) runtime.Encoder {
+	return &cachingEncoder{encoder: encoder, id: id}
+}
+
+type cacheKey struct {
+	id        string
+	name      string
+	namespace string
+	uid       string
+	rv        string
+	selfLink  string
+}
+
+// keyFor returns a cache key for obj. Only objects with a resource version are
+// cacheable: the resource version uniquely identifies the content of a stored
+// object, so it is safe to reuse bytes across watchers.
+func (c *cachingEncoder) keyFor(obj runtime.Object) (cacheKey, bool) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return cacheKey{}, false
+	}
+	rv := accessor.GetResourceVersion()
+	if len(rv) == 0 {
+		return cacheKey{}, false
+	}
+	return cacheKey{
+		id:        c.id,
+		name:      accessor.GetName(),
+		namespace: accessor.GetNamespace(),
+		uid:       string(accessor.GetUID()),
+		rv:        rv,
+		selfLink:  accessor.GetSelfLink(),
+	}, true
+}
+
+func (c *cachingEncoder) Encode(obj runtime.Object, w io.Writer) error {
+	key, ok := c.keyFor(obj)
+	if !ok {
+		return c.encoder.Encode(obj, w)
+	}
+	if value, found := encodedObjects.Get(key); found {
+		_, err := w.Write(value.([]byte))
+		return err
+	}
+	buf := &bytes.Buffer{}
+	if err := c.encoder.Encode(obj, buf); err != nil {
+		return err
+	}
+	data := buf.Bytes()
+	encodedObjects.Add(key, data)
+	_, err := w.Write(data)
+	return err
+}
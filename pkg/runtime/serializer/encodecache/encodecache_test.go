@@ -0,0 +1,87 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encodecache
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// countingEncoder writes a fixed payload and records how often it was invoked.
+type countingEncoder struct {
+	encodes int
+}
+
+func (e *countingEncoder) Encode(obj runtime.Object, w io.Writer) error {
+	e.encodes++
+	_, err := fmt.Fprintf(w, "encoded-%d", e.encodes)
+	return err
+}
+
+func pod(name, rv string) *api.Pod {
+	return &api.Pod{ObjectMeta: api.ObjectMeta{Name: name, ResourceVersion: rv}}
+}
+
+func encodeToString(t *testing.T, e runtime.Encoder, obj runtime.Object) string {
+	buf := &bytes.Buffer{}
+	if err := e.Encode(obj, buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return buf.String()
+}
+
+func TestCachingEncoder(t *testing.T) {
+	counting := &countingEncoder{}
+	encoder := NewCachingEncoder(counting, "test/v1")
+
+	first := encodeToString(t, encoder, pod("a", "1"))
+	second := encodeToString(t, encoder, pod("a", "1"))
+	if counting.encodes != 1 {
+		t.Errorf("expected one encode for the same object version, got %d", counting.encodes)
+	}
+	if first != second {
+		t.Errorf("expected identical bytes, got %q and %q", first, second)
+	}
+
+	encodeToString(t, encoder, pod("a", "2"))
+	if counting.encodes != 2 {
+		t.Errorf("expected a new resource version to be encoded, got %d encodes", counting.encodes)
+	}
+
+	// encoders with a different id must not share bytes
+	other := NewCachingEncoder(counting, "other/v1")
+	encodeToString(t, other, pod("a", "1"))
+	if counting.encodes != 3 {
+		t.Errorf("expected a different encoder id to be encoded separately, got %d encodes", counting.encodes)
+	}
+}
+
+func TestCachingEncoderSkipsUnversionedObjects(t *testing.T) {
+	counting := &countingEncoder{}
+	encoder := NewCachingEncoder(counting, "test/v1")
+
+	encodeToString(t, encoder, pod("a", ""))
+	encodeToString(t, encoder, pod("a", ""))
+	if counting.encodes != 2 {
+		t.Errorf("objects without a resource version must not be cached, got %d encodes", counting.encodes)
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// NewFastSerializer creates a JSON serializer that encodes types carrying
+// generated ugorji codecs (hack/update-codecgen.sh) without reflection.
+// Decoding already routes through the generated codecs; this serializer adds
+// the same fast path on encode. Types without generated codecs fall back to
+// encoding/json, so the output is unchanged for them.
+//
+// The generated codecs produce the same JSON as encoding/json for the struct
+// tag subset our API types use, but the two encoders are distinct code paths;
+// this serializer stays opt-in until the generated output has soaked behind
+// the flag.
+func NewFastSerializer(meta MetaFactory, creater runtime.ObjectCreater, typer runtime.ObjectTyper) *Serializer {
+	return &Serializer{
+		meta:    meta,
+		creater: creater,
+		typer:   typer,
+		fast:    true,
+	}
+}
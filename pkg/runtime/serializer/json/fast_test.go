@@ -0,0 +1,77 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ugorji/go/codec"
+
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/runtime/serializer/json"
+)
+
+// testSelfer stands in for a type with codecgen output: its generated-style
+// codec methods emit a marker field the reflective encoder does not.
+type testSelfer struct {
+	Value string `json:"value"`
+	gvk   unversioned.GroupVersionKind
+}
+
+func (s *testSelfer) GetObjectKind() unversioned.ObjectKind                { return s }
+func (s *testSelfer) SetGroupVersionKind(gvk unversioned.GroupVersionKind) { s.gvk = gvk }
+func (s *testSelfer) GroupVersionKind() unversioned.GroupVersionKind       { return s.gvk }
+
+func (s *testSelfer) CodecEncodeSelf(e *codec.Encoder) {
+	e.MustEncode(map[string]string{"value": s.Value, "encoder": "generated"})
+}
+
+func (s *testSelfer) CodecDecodeSelf(d *codec.Decoder) {
+	fields := map[string]string{}
+	d.MustDecode(&fields)
+	s.Value = fields["value"]
+}
+
+func TestFastSerializerUsesGeneratedCodecs(t *testing.T) {
+	s := json.NewFastSerializer(json.DefaultMetaFactory, nil, nil)
+	buf := &bytes.Buffer{}
+	if err := s.Encode(&testSelfer{Value: "a"}, buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"encoder":"generated"`) {
+		t.Errorf("expected the generated codec to encode, got %q", buf.String())
+	}
+}
+
+func TestFastSerializerFallsBack(t *testing.T) {
+	fast := json.NewFastSerializer(json.DefaultMetaFactory, nil, nil)
+	plain := json.NewSerializer(json.DefaultMetaFactory, nil, nil, false)
+	obj := &testDecodable{Other: "test", Value: 1}
+
+	fastBuf, plainBuf := &bytes.Buffer{}, &bytes.Buffer{}
+	if err := fast.Encode(obj, fastBuf); err != nil {
+		t.Fatal(err)
+	}
+	if err := plain.Encode(obj, plainBuf); err != nil {
+		t.Fatal(err)
+	}
+	if fastBuf.String() != plainBuf.String() {
+		t.Errorf("expected types without generated codecs to encode identically, got %q and %q", fastBuf.String(), plainBuf.String())
+	}
+}
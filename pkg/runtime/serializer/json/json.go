@@ -59,6 +59,18 @@ type Serializer struct {
 	typer   runtime.ObjectTyper
 	yaml    bool
 	pretty  bool
+	strict  bool
+	fast    bool
+}
+
+// jsonHandle returns the ugorji codec handle used for decoding. In strict mode
+// unknown fields are surfaced as errors instead of being skipped.
+func (s *Serializer) jsonHandle() *codec.JsonHandle {
+	h := new(codec.JsonHandle)
+	if s.strict {
+		h.ErrorIfNoField = true
+	}
+	return h
 }
 
 // Serializer implements Serializer
@@ -89,6 +101,12 @@ func (s *Serializer) Decode(originalData []byte, gvk *unversioned.GroupVersionKi
 		data = altered
 	}
 
+	if s.strict {
+		if err := validateDuplicateFields(data); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	actual, err := s.meta.Interpret(data)
 	if err != nil {
 		return nil, nil, err
@@ -119,7 +137,7 @@ func (s *Serializer) Decode(originalData []byte, gvk *unversioned.GroupVersionKi
 		types, _, err := s.typer.ObjectKinds(into)
 		switch {
 		case runtime.IsNotRegisteredError(err):
-			if err := codec.NewDecoderBytes(data, new(codec.JsonHandle)).Decode(into); err != nil {
+			if err := codec.NewDecoderBytes(data, s.jsonHandle()).Decode(into); err != nil {
 				return nil, actual, err
 			}
 			return into, actual, nil
@@ -153,7 +171,7 @@ func (s *Serializer) Decode(originalData []byte, gvk *unversioned.GroupVersionKi
 		return nil, actual, err
 	}
 
-	if err := codec.NewDecoderBytes(data, new(codec.JsonHandle)).Decode(obj); err != nil {
+	if err := codec.NewDecoderBytes(data, s.jsonHandle()).Decode(obj); err != nil {
 		return nil, actual, err
 	}
 	return obj, actual, nil
@@ -182,6 +200,11 @@ func (s *Serializer) Encode(obj runtime.Object, w io.Writer) error {
 		_, err = w.Write(data)
 		return err
 	}
+	if s.fast {
+		if _, ok := obj.(codec.Selfer); ok {
+			return codec.NewEncoder(w, s.jsonHandle()).Encode(obj)
+		}
+	}
 	encoder := json.NewEncoder(w)
 	return encoder.Encode(obj)
 }
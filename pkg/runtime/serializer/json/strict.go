@@ -0,0 +1,102 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// NewStrictSerializer creates a JSON serializer that fails when the data
+// contains fields the target type does not have, or when the same field
+// appears twice. The default serializer silently drops both, which turns
+// typo'd manifest fields into silently ignored configuration.
+func NewStrictSerializer(meta MetaFactory, creater runtime.ObjectCreater, typer runtime.ObjectTyper) *Serializer {
+	return &Serializer{
+		meta:    meta,
+		creater: creater,
+		typer:   typer,
+		strict:  true,
+	}
+}
+
+// validateDuplicateFields walks the JSON data once at the token level and
+// returns an error naming the path of the first field that appears more than
+// once within the same object.
+func validateDuplicateFields(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	return checkDuplicates(dec, "")
+}
+
+// checkDuplicates consumes one JSON value from dec. path is the dotted field
+// path to the value being consumed, used in error messages.
+func checkDuplicates(dec *json.Decoder, path string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		// scalar values contain no fields
+		return nil
+	}
+	switch delim {
+	case '{':
+		seen := map[string]struct{}{}
+		for dec.More() {
+			keyToken, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key, ok := keyToken.(string)
+			if !ok {
+				return fmt.Errorf("unexpected non-string key at %q", path)
+			}
+			fieldPath := key
+			if len(path) > 0 {
+				fieldPath = path + "." + key
+			}
+			if _, duplicate := seen[key]; duplicate {
+				return fmt.Errorf("duplicate field %q", fieldPath)
+			}
+			seen[key] = struct{}{}
+			if err := checkDuplicates(dec, fieldPath); err != nil {
+				return err
+			}
+		}
+		// consume the closing brace
+		_, err = dec.Token()
+		return err
+	case '[':
+		for i := 0; dec.More(); i++ {
+			if err := checkDuplicates(dec, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		// consume the closing bracket
+		_, err = dec.Token()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json_test
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/runtime/serializer/json"
+)
+
+func TestStrictDecode(t *testing.T) {
+	testCases := []struct {
+		data   []byte
+		strict bool
+		errFn  func(error) bool
+		name   string
+	}{
+		{
+			data:   []byte(`{"value":1}`),
+			strict: true,
+			name:   "known fields are accepted",
+		},
+		{
+			data:   []byte(`{"value":1,"bogus":"unknown"}`),
+			strict: true,
+			errFn:  func(err error) bool { return strings.Contains(err.Error(), "bogus") },
+			name:   "unknown fields are rejected",
+		},
+		{
+			data:   []byte(`{"value":1,"value":2}`),
+			strict: true,
+			errFn:  func(err error) bool { return strings.Contains(err.Error(), `duplicate field "value"`) },
+			name:   "duplicate fields are rejected",
+		},
+		{
+			data:   []byte(`{"nested":{"a":1,"a":2}}`),
+			strict: true,
+			errFn:  func(err error) bool { return strings.Contains(err.Error(), `duplicate field "nested.a"`) },
+			name:   "duplicate fields are reported with their path",
+		},
+		{
+			data: []byte(`{"value":1,"bogus":"unknown","value":2}`),
+			name: "the default serializer ignores unknown and duplicate fields",
+		},
+	}
+
+	for _, testCase := range testCases {
+		var s *json.Serializer
+		typer := &mockTyper{err: runtime.NewNotRegisteredErr(unversioned.GroupVersionKind{}, nil)}
+		if testCase.strict {
+			s = json.NewStrictSerializer(json.DefaultMetaFactory, nil, typer)
+		} else {
+			s = json.NewSerializer(json.DefaultMetaFactory, nil, typer, false)
+		}
+
+		_, _, err := s.Decode(testCase.data, &unversioned.GroupVersionKind{Kind: "Test", Version: "v1"}, &testDecodable{})
+		if testCase.errFn == nil {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", testCase.name, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%s: expected an error, got none", testCase.name)
+			continue
+		}
+		if !testCase.errFn(err) {
+			t.Errorf("%s: unexpected error: %v", testCase.name, err)
+		}
+	}
+}
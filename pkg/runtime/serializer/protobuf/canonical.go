@@ -0,0 +1,224 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// The generated marshallers iterate Go maps directly, so two encodes of the
+// same object can emit map entries in different orders. Types can opt into
+// stable bytes by implementing MarshalDeterministicTo, but the generated
+// types do not; rather than silently fall back to unstable output, the
+// deterministic serializer canonicalizes the regular marshaller's bytes.
+// Guided by the protobuf struct tags of the object's Go type, it locates the
+// wire entries belonging to map fields and sorts them in place. Sorting
+// reorders whole entries without changing any lengths, so every enclosing
+// length prefix stays valid and the pass can work on the encoded buffer
+// directly.
+//
+// Entries are ordered by their encoded bytes rather than strictly by key;
+// any fixed order makes the output deterministic. Map values in this tree
+// are scalars, byte strings, or Quantity and contain no maps themselves, so
+// entries can be treated as opaque once sorted.
+
+// canonicalField describes what the canonicalization pass must do with one
+// wire field: sort its entries (maps), or recurse into its payload (nested
+// messages, including the elements of repeated messages).
+type canonicalField struct {
+	isMap bool
+	elem  reflect.Type
+}
+
+var canonicalFieldsLock sync.RWMutex
+var canonicalFieldsCache = map[reflect.Type]map[int32]canonicalField{}
+
+// canonicalFieldsFor maps the protobuf field numbers of the struct type t to
+// the action the canonicalization pass takes for them. Fields that are
+// neither maps nor messages need no action and are omitted; untagged fields
+// (such as inlined TypeMeta) never reach the wire and are skipped.
+func canonicalFieldsFor(t reflect.Type) map[int32]canonicalField {
+	canonicalFieldsLock.RLock()
+	fields, ok := canonicalFieldsCache[t]
+	canonicalFieldsLock.RUnlock()
+	if ok {
+		return fields
+	}
+
+	fields = map[int32]canonicalField{}
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("protobuf")
+		if len(tag) == 0 {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		if len(parts) < 2 {
+			continue
+		}
+		num, err := strconv.Atoi(parts[1])
+		if err != nil {
+			continue
+		}
+		fieldType := t.Field(i).Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		switch fieldType.Kind() {
+		case reflect.Map:
+			fields[int32(num)] = canonicalField{isMap: true}
+		case reflect.Struct:
+			fields[int32(num)] = canonicalField{elem: fieldType}
+		case reflect.Slice:
+			elem := fieldType.Elem()
+			for elem.Kind() == reflect.Ptr {
+				elem = elem.Elem()
+			}
+			if elem.Kind() == reflect.Struct {
+				fields[int32(num)] = canonicalField{elem: elem}
+			}
+		}
+	}
+
+	canonicalFieldsLock.Lock()
+	canonicalFieldsCache[t] = fields
+	canonicalFieldsLock.Unlock()
+	return fields
+}
+
+// canonicalize sorts the map entries in the encoded bytes of obj in place.
+// Objects that are not structs (such as runtime.Unknown wrappers built
+// elsewhere) have no map fields and are left untouched.
+func canonicalize(data []byte, obj interface{}) error {
+	t := reflect.TypeOf(obj)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	return canonicalizeMessage(data, t)
+}
+
+func canonicalizeMessage(data []byte, t reflect.Type) error {
+	fields := canonicalFieldsFor(t)
+	if len(fields) == 0 {
+		return nil
+	}
+	i := 0
+	for i < len(data) {
+		entryStart := i
+		wire, n, err := canonicalVarint(data[i:])
+		if err != nil {
+			return err
+		}
+		i += n
+		fieldNum := int32(wire >> 3)
+		switch wire & 0x7 {
+		case 0:
+			if _, n, err = canonicalVarint(data[i:]); err != nil {
+				return err
+			}
+			i += n
+		case 1:
+			i += 8
+		case 5:
+			i += 4
+		case 2:
+			length, n, err := canonicalVarint(data[i:])
+			if err != nil {
+				return err
+			}
+			i += n
+			payloadStart := i
+			i += int(length)
+			if i > len(data) {
+				return fmt.Errorf("field %d of %v overruns the buffer", fieldNum, t)
+			}
+			field, ok := fields[fieldNum]
+			switch {
+			case !ok:
+			case field.isMap:
+				// the generated marshallers emit all entries of one map in a
+				// single loop, so the entries to sort are contiguous
+				entries := [][]byte{data[entryStart:i]}
+				for i < len(data) {
+					next, tagLen, err := canonicalVarint(data[i:])
+					if err != nil {
+						return err
+					}
+					if int32(next>>3) != fieldNum || next&0x7 != 2 {
+						break
+					}
+					length, n, err := canonicalVarint(data[i+tagLen:])
+					if err != nil {
+						return err
+					}
+					end := i + tagLen + n + int(length)
+					if end > len(data) {
+						return fmt.Errorf("field %d of %v overruns the buffer", fieldNum, t)
+					}
+					entries = append(entries, data[i:end])
+					i = end
+				}
+				if len(entries) > 1 {
+					sorted := make([][]byte, len(entries))
+					for j := range entries {
+						sorted[j] = append([]byte(nil), entries[j]...)
+					}
+					sort.Sort(byEncodedEntry(sorted))
+					at := entryStart
+					for _, entry := range sorted {
+						at += copy(data[at:], entry)
+					}
+				}
+			case field.elem != nil:
+				if err := canonicalizeMessage(data[payloadStart:i], field.elem); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("cannot canonicalize wire type %d in %v", wire&0x7, t)
+		}
+	}
+	return nil
+}
+
+type byEncodedEntry [][]byte
+
+func (s byEncodedEntry) Len() int           { return len(s) }
+func (s byEncodedEntry) Less(i, j int) bool { return bytes.Compare(s[i], s[j]) < 0 }
+func (s byEncodedEntry) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+func canonicalVarint(data []byte) (uint64, int, error) {
+	var value uint64
+	for i := 0; i < len(data); i++ {
+		value |= uint64(data[i]&0x7f) << (7 * uint(i))
+		if data[i] < 0x80 {
+			return value, i + 1, nil
+		}
+		if i >= 9 {
+			break
+		}
+	}
+	return 0, 0, fmt.Errorf("malformed varint")
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"github.com/gogo/protobuf/proto"
+
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// DeterministicMarshaller is implemented by generated types that can encode
+// themselves with map fields ordered by key, so that encoding the same object
+// twice yields identical bytes. The regular generated MarshalTo iterates maps
+// in random order and provides no such guarantee.
+type DeterministicMarshaller interface {
+	proto.Sizer
+	MarshalDeterministicTo(data []byte) (int, error)
+}
+
+// deterministicAdapter exposes MarshalDeterministicTo through the MarshalTo
+// interface expected by runtime.Unknown.NestedMarshalTo.
+type deterministicAdapter struct {
+	m DeterministicMarshaller
+}
+
+func (a deterministicAdapter) MarshalTo(data []byte) (int, error) {
+	return a.m.MarshalDeterministicTo(data)
+}
+
+// canonicalAdapter makes the bytes of an ordinary generated marshaller
+// deterministic by sorting its map entries in place after MarshalTo returns.
+// A payload the canonicalization pass cannot walk fails the encode instead of
+// producing unstable bytes.
+type canonicalAdapter struct {
+	m runtime.ProtobufMarshaller
+}
+
+func (a canonicalAdapter) MarshalTo(data []byte) (int, error) {
+	n, err := a.m.MarshalTo(data)
+	if err != nil {
+		return n, err
+	}
+	if err := canonicalize(data[:n], a.m); err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// marshaller returns the marshaller Encode should use for m: m itself for the
+// default serializer, and for the deterministic serializer either m's own
+// MarshalDeterministicTo or, for the generated types that lack one, m wrapped
+// so its map entries are canonicalized after marshalling.
+func (s *Serializer) marshaller(m runtime.ProtobufMarshaller) runtime.ProtobufMarshaller {
+	if s.deterministic {
+		if d, ok := m.(DeterministicMarshaller); ok {
+			return deterministicAdapter{d}
+		}
+		return canonicalAdapter{m}
+	}
+	return m
+}
+
+// marshal encodes t into a freshly allocated buffer, preferring t's own
+// deterministic marshaller when stable bytes were requested and otherwise
+// canonicalizing the regular output.
+func marshal(t proto.Marshaler, deterministic bool) ([]byte, error) {
+	if deterministic {
+		if d, ok := t.(DeterministicMarshaller); ok {
+			data := make([]byte, d.Size())
+			n, err := d.MarshalDeterministicTo(data)
+			if err != nil {
+				return nil, err
+			}
+			return data[:n], nil
+		}
+		data, err := t.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		if err := canonicalize(data, t); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+	return t.Marshal()
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api/unversioned"
+)
+
+// fakeMapObject marshals a map field the way generated code does: MarshalTo
+// iterates the map in random order, MarshalDeterministicTo sorts the keys.
+type fakeMapObject struct {
+	unversioned.TypeMeta
+	Labels map[string]string
+}
+
+func (m *fakeMapObject) entry(k string) []byte {
+	return []byte(k + "=" + m.Labels[k])
+}
+
+func (m *fakeMapObject) Size() int {
+	n := 0
+	for k := range m.Labels {
+		l := len(m.entry(k))
+		n += 1 + l + sovEnvelope(uint64(l))
+	}
+	return n
+}
+
+func (m *fakeMapObject) marshalKeysTo(data []byte, keys []string) (int, error) {
+	i := 0
+	for _, k := range keys {
+		entry := m.entry(k)
+		data[i] = 0xa
+		i++
+		i = encodeVarintEnvelope(data, i, uint64(len(entry)))
+		i += copy(data[i:], entry)
+	}
+	return i, nil
+}
+
+func (m *fakeMapObject) MarshalTo(data []byte) (int, error) {
+	keys := make([]string, 0, len(m.Labels))
+	for k := range m.Labels {
+		keys = append(keys, k)
+	}
+	return m.marshalKeysTo(data, keys)
+}
+
+func (m *fakeMapObject) MarshalDeterministicTo(data []byte) (int, error) {
+	keys := make([]string, 0, len(m.Labels))
+	for k := range m.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return m.marshalKeysTo(data, keys)
+}
+
+func TestDeterministicEncode(t *testing.T) {
+	obj := &fakeMapObject{
+		TypeMeta: unversioned.TypeMeta{Kind: "FakeMapObject", APIVersion: "v1"},
+		Labels: map[string]string{
+			"a": "1", "b": "2", "c": "3", "d": "4", "e": "5",
+			"f": "6", "g": "7", "h": "8", "i": "9", "j": "10",
+		},
+	}
+	s := NewDeterministicSerializer(nil, nil, "application/vnd.kubernetes.protobuf")
+
+	first := &bytes.Buffer{}
+	if err := s.Encode(obj, first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 50; i++ {
+		buf := &bytes.Buffer{}
+		if err := s.Encode(obj, buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !bytes.Equal(first.Bytes(), buf.Bytes()) {
+			t.Fatalf("deterministic encoding produced different bytes:\n%x\n%x", first.Bytes(), buf.Bytes())
+		}
+	}
+}
+
+func TestMarshallerSelection(t *testing.T) {
+	obj := &fakeMapObject{}
+	s := NewSerializer(nil, nil, "application/vnd.kubernetes.protobuf")
+	if _, ok := s.marshaller(obj).(deterministicAdapter); ok {
+		t.Errorf("default serializer must not use the deterministic marshaller")
+	}
+	s = NewDeterministicSerializer(nil, nil, "application/vnd.kubernetes.protobuf")
+	if _, ok := s.marshaller(obj).(deterministicAdapter); !ok {
+		t.Errorf("deterministic serializer should prefer MarshalDeterministicTo")
+	}
+	if _, ok := s.marshaller(&canonicalMapObject{}).(canonicalAdapter); !ok {
+		t.Errorf("deterministic serializer should canonicalize types without MarshalDeterministicTo")
+	}
+}
+
+// canonicalMapObject mirrors the generated types: its map field is declared
+// through a protobuf struct tag and MarshalTo iterates the map in whatever
+// order the runtime yields, with no MarshalDeterministicTo.
+type canonicalMapObject struct {
+	unversioned.TypeMeta
+	Labels map[string]string `protobuf:"bytes,1,rep,name=labels"`
+}
+
+func (m *canonicalMapObject) entry(k string) []byte {
+	return []byte(k + "=" + m.Labels[k])
+}
+
+func (m *canonicalMapObject) Size() int {
+	n := 0
+	for k := range m.Labels {
+		l := len(m.entry(k))
+		n += 1 + l + sovEnvelope(uint64(l))
+	}
+	return n
+}
+
+func (m *canonicalMapObject) MarshalTo(data []byte) (int, error) {
+	i := 0
+	for k := range m.Labels {
+		entry := m.entry(k)
+		data[i] = 0xa
+		i++
+		i = encodeVarintEnvelope(data, i, uint64(len(entry)))
+		i += copy(data[i:], entry)
+	}
+	return i, nil
+}
+
+func TestDeterministicEncodeGeneratedStyle(t *testing.T) {
+	obj := &canonicalMapObject{
+		TypeMeta: unversioned.TypeMeta{Kind: "CanonicalMapObject", APIVersion: "v1"},
+		Labels: map[string]string{
+			"a": "1", "b": "2", "c": "3", "d": "4", "e": "5",
+			"f": "6", "g": "7", "h": "8", "i": "9", "j": "10",
+		},
+	}
+	s := NewDeterministicSerializer(nil, nil, "application/vnd.kubernetes.protobuf")
+
+	first := &bytes.Buffer{}
+	if err := s.Encode(obj, first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a := bytes.Index(first.Bytes(), []byte("a=1"))
+	b := bytes.Index(first.Bytes(), []byte("b=2"))
+	c := bytes.Index(first.Bytes(), []byte("c=3"))
+	if a < 0 || b < 0 || c < 0 || a > b || b > c {
+		t.Fatalf("expected sorted map entries, got %x", first.Bytes())
+	}
+	for i := 0; i < 50; i++ {
+		buf := &bytes.Buffer{}
+		if err := s.Encode(obj, buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !bytes.Equal(first.Bytes(), buf.Bytes()) {
+			t.Fatalf("deterministic encoding produced different bytes:\n%x\n%x", first.Bytes(), buf.Bytes())
+		}
+	}
+}
+
+func TestCanonicalizeNestedMaps(t *testing.T) {
+	type inner struct {
+		Name   string            `protobuf:"bytes,1,opt,name=name"`
+		Limits map[string]string `protobuf:"bytes,2,rep,name=limits"`
+	}
+	type outer struct {
+		Labels map[string]string `protobuf:"bytes,1,rep,name=labels"`
+		Spec   inner             `protobuf:"bytes,2,opt,name=spec"`
+		Items  []inner           `protobuf:"bytes,3,rep,name=items"`
+	}
+	field := func(tag byte, payload ...[]byte) []byte {
+		data := []byte{tag, 0}
+		for _, p := range payload {
+			data = append(data, p...)
+		}
+		data[1] = byte(len(data) - 2)
+		return data
+	}
+	str := func(s string) []byte { return []byte(s) }
+
+	data := bytes.Join([][]byte{
+		field(0xa, str("b=2")),
+		field(0xa, str("a=1")),
+		field(0x12, field(0xa, str("spec")), field(0x12, str("z=9")), field(0x12, str("a=1"))),
+		field(0x1a, field(0xa, str("item")), field(0x12, str("y=8")), field(0x12, str("x=7"))),
+	}, nil)
+	expected := bytes.Join([][]byte{
+		field(0xa, str("a=1")),
+		field(0xa, str("b=2")),
+		field(0x12, field(0xa, str("spec")), field(0x12, str("a=1")), field(0x12, str("z=9"))),
+		field(0x1a, field(0xa, str("item")), field(0x12, str("x=7")), field(0x12, str("y=8"))),
+	}, nil)
+
+	if err := canonicalizeMessage(data, reflect.TypeOf(outer{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(data, expected) {
+		t.Errorf("expected canonical bytes\n%x, got\n%x", expected, data)
+	}
+
+	// a payload the pass cannot walk must fail rather than pass through
+	if err := canonicalizeMessage([]byte{0xa, 0x5, 'x'}, reflect.TypeOf(outer{})); err == nil {
+		t.Errorf("expected an error for a truncated message")
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// unmarshalTargets are generated types whose Unmarshal methods are exercised
+// with hostile input. The skip and varint paths are shared by all generated
+// code, so a small set of representative messages covers them.
+func unmarshalTargets() []proto.Message {
+	return []proto.Message{
+		&unversioned.Status{},
+		&unversioned.APIGroup{},
+		&unversioned.APIResourceList{},
+		&runtime.Unknown{},
+	}
+}
+
+// seedMessages provide valid encodings that the mutator corrupts, which finds
+// far more interesting paths than purely random bytes.
+func seedMessages() []proto.Message {
+	return []proto.Message{
+		&unversioned.Status{
+			Status:  "Failure",
+			Message: "forbidden",
+			Reason:  "Forbidden",
+			Code:    403,
+			Details: &unversioned.StatusDetails{
+				Name: "test",
+				Causes: []unversioned.StatusCause{
+					{Type: "FieldValueInvalid", Message: "bad", Field: "spec.field"},
+				},
+			},
+		},
+		&unversioned.APIGroup{
+			Name: "extensions",
+			Versions: []unversioned.GroupVersionForDiscovery{
+				{GroupVersion: "extensions/v1beta1", Version: "v1beta1"},
+			},
+		},
+		&runtime.Unknown{
+			TypeMeta:    runtime.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+			Raw:         []byte("some nested payload"),
+			ContentType: "application/json",
+		},
+	}
+}
+
+// mutate returns a copy of data with a random corruption applied: flipped
+// bits, truncation, duplication, or random insertions.
+func mutate(r *rand.Rand, data []byte) []byte {
+	out := append([]byte{}, data...)
+	if len(out) == 0 {
+		out = make([]byte, r.Intn(32))
+		r.Read(out)
+		return out
+	}
+	switch r.Intn(4) {
+	case 0:
+		// flip a random bit
+		i := r.Intn(len(out))
+		out[i] ^= 1 << uint(r.Intn(8))
+	case 1:
+		// truncate
+		out = out[:r.Intn(len(out))]
+	case 2:
+		// duplicate a suffix
+		i := r.Intn(len(out))
+		out = append(out, out[i:]...)
+	case 3:
+		// insert random bytes
+		extra := make([]byte, 1+r.Intn(16))
+		r.Read(extra)
+		i := r.Intn(len(out))
+		out = append(out[:i], append(extra, out[i:]...)...)
+	}
+	return out
+}
+
+// decodeOrError runs a single Unmarshal, converting panics into test failures.
+func decodeOrError(t *testing.T, target proto.Message, data []byte) {
+	defer func() {
+		if p := recover(); p != nil {
+			t.Errorf("panic unmarshalling %d bytes into %T: %v\ndata: %x", len(data), target, p, data)
+		}
+	}()
+	// errors are expected for corrupt input; panics are not
+	_ = proto.Unmarshal(data, target)
+}
+
+func TestUnmarshalFuzzed(t *testing.T) {
+	// the seed is fixed so failures are reproducible
+	r := rand.New(rand.NewSource(42))
+
+	seeds := [][]byte{}
+	for _, msg := range seedMessages() {
+		data, err := proto.Marshal(msg)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		seeds = append(seeds, data)
+	}
+
+	for i := 0; i < 5000; i++ {
+		data := mutate(r, seeds[r.Intn(len(seeds))])
+		for _, target := range unmarshalTargets() {
+			decodeOrError(t, target, data)
+		}
+	}
+}
+
+func TestUnmarshalRandomBytes(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for i := 0; i < 2000; i++ {
+		data := make([]byte, r.Intn(256))
+		r.Read(data)
+		for _, target := range unmarshalTargets() {
+			decodeOrError(t, target, data)
+		}
+	}
+}
+
+// TestUnmarshalLengthBomb feeds messages that declare enormous nested lengths
+// with no corresponding payload. Unmarshal must fail instead of allocating
+// the declared size.
+func TestUnmarshalLengthBomb(t *testing.T) {
+	// field 1, wire type 2, declared length 1GB, no payload
+	data := []byte{0xa, 0x80, 0x80, 0x80, 0x80, 0x4}
+	for _, target := range unmarshalTargets() {
+		if err := proto.Unmarshal(data, target); err == nil {
+			t.Errorf("expected an error decoding a truncated length declaration into %T", target)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf_test
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api/v1"
+)
+
+// Generated Marshal computes Size() and then walks the object again in
+// MarshalTo, and MarshalTo itself re-sizes every nested message for its
+// length prefix, so deeply nested objects pay for the size walk once per
+// nesting level. These benchmarks isolate the size walk from the full
+// marshal on a nested object (Pod) and a wide one (Endpoints), as the
+// baseline for a generation mode that computes child sizes in a single
+// pass.
+
+func benchmarkPod() *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: "pod", Namespace: "ns", Labels: map[string]string{"app": "test"}},
+		Spec: v1.PodSpec{
+			NodeName: "node",
+			Containers: []v1.Container{
+				{Name: "test", Image: "test/image", Command: []string{"/bin/sh", "-c", "sleep"}},
+			},
+		},
+	}
+}
+
+func benchmarkEndpoints() *v1.Endpoints {
+	subsets := make([]v1.EndpointSubset, 0, 100)
+	for i := 0; i < 100; i++ {
+		subsets = append(subsets, v1.EndpointSubset{
+			Addresses: []v1.EndpointAddress{{IP: fmt.Sprintf("10.0.0.%d", i)}},
+			Ports:     []v1.EndpointPort{{Name: "http", Port: 80}},
+		})
+	}
+	return &v1.Endpoints{
+		ObjectMeta: v1.ObjectMeta{Name: "endpoints", Namespace: "ns"},
+		Subsets:    subsets,
+	}
+}
+
+func BenchmarkMarshalPod(b *testing.B) {
+	pod := benchmarkPod()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := pod.Marshal(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSizePod(b *testing.B) {
+	pod := benchmarkPod()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pod.Size()
+	}
+}
+
+func BenchmarkMarshalEndpoints(b *testing.B) {
+	endpoints := benchmarkEndpoints()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := endpoints.Marshal(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSizeEndpoints(b *testing.B) {
+	endpoints := benchmarkEndpoints()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		endpoints.Size()
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"sync"
+)
+
+// bufferSizeClasses are the capacities of the pooled marshalling buffers.
+// Encoding requests larger than the largest class allocate directly and the
+// resulting buffer is never pooled.
+var bufferSizeClasses = []int{1 << 10, 1 << 14, 1 << 18, 1 << 22}
+
+var bufferPools = newBufferPools()
+
+func newBufferPools() []*sync.Pool {
+	pools := make([]*sync.Pool, len(bufferSizeClasses))
+	for i := range pools {
+		size := bufferSizeClasses[i]
+		pools[i] = &sync.Pool{
+			New: func() interface{} { return make([]byte, size) },
+		}
+	}
+	return pools
+}
+
+// getBuffer returns a buffer of at least size bytes, reusing a pooled buffer
+// from the smallest size class that fits. Callers must hand the buffer back
+// with putBuffer once its contents have been flushed to the writer.
+func getBuffer(size uint64) []byte {
+	for i, class := range bufferSizeClasses {
+		if size <= uint64(class) {
+			return bufferPools[i].Get().([]byte)[:size]
+		}
+	}
+	return make([]byte, size)
+}
+
+// putBuffer returns a buffer obtained from getBuffer to its pool. Buffers
+// whose capacity does not match a size class are left for the garbage
+// collector.
+func putBuffer(buf []byte) {
+	for i, class := range bufferSizeClasses {
+		if cap(buf) == class {
+			bufferPools[i].Put(buf[:class])
+			return
+		}
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"testing"
+)
+
+func TestGetBuffer(t *testing.T) {
+	testCases := []struct {
+		size        uint64
+		expectedCap int
+	}{
+		{size: 0, expectedCap: 1 << 10},
+		{size: 1, expectedCap: 1 << 10},
+		{size: 1 << 10, expectedCap: 1 << 10},
+		{size: (1 << 10) + 1, expectedCap: 1 << 14},
+		{size: 1 << 22, expectedCap: 1 << 22},
+		// oversize requests are allocated exactly and bypass the pool
+		{size: (1 << 22) + 1, expectedCap: (1 << 22) + 1},
+	}
+	for _, testCase := range testCases {
+		buf := getBuffer(testCase.size)
+		if uint64(len(buf)) != testCase.size {
+			t.Errorf("getBuffer(%d): unexpected length %d", testCase.size, len(buf))
+		}
+		if cap(buf) != testCase.expectedCap {
+			t.Errorf("getBuffer(%d): expected capacity %d, got %d", testCase.size, testCase.expectedCap, cap(buf))
+		}
+		putBuffer(buf)
+	}
+}
@@ -68,11 +68,25 @@ func NewSerializer(creater runtime.ObjectCreater, typer runtime.ObjectTyper, def
 	}
 }
 
+// NewDeterministicSerializer is identical to NewSerializer except that map fields
+// are encoded with their entries in a fixed order, so encoding the same object
+// always produces the same bytes. Objects implementing DeterministicMarshaller
+// encode themselves; the generated types are encoded normally and their map
+// entries are then sorted in place on the wire. It is intended for callers that
+// compare or hash the encoded output, such as the storage layer.
+func NewDeterministicSerializer(creater runtime.ObjectCreater, typer runtime.ObjectTyper, defaultContentType string) *Serializer {
+	s := NewSerializer(creater, typer, defaultContentType)
+	s.deterministic = true
+	return s
+}
+
 type Serializer struct {
-	prefix      []byte
-	creater     runtime.ObjectCreater
-	typer       runtime.ObjectTyper
-	contentType string
+	prefix        []byte
+	creater       runtime.ObjectCreater
+	typer         runtime.ObjectTyper
+	contentType   string
+	deterministic bool
+	strict        bool
 }
 
 var _ runtime.Serializer = &Serializer{}
@@ -130,6 +144,12 @@ func (s *Serializer) Decode(originalData []byte, gvk *unversioned.GroupVersionKi
 		return intoUnknown, &actual, nil
 	}
 
+	// third party resources have no generated protobuf message and are stored
+	// as JSON inside the envelope (see encodeUnstructured)
+	if unk.ContentType == runtime.ContentTypeJSON {
+		return decodeUnstructured(&unk, &actual, into)
+	}
+
 	if into != nil {
 		types, _, err := s.typer.ObjectKinds(into)
 		switch {
@@ -162,7 +182,7 @@ func (s *Serializer) Decode(originalData []byte, gvk *unversioned.GroupVersionKi
 		return nil, &actual, runtime.NewMissingVersionErr(fmt.Sprintf("%#v", unk.TypeMeta))
 	}
 
-	return unmarshalToObject(s.typer, s.creater, &actual, into, unk.Raw)
+	return unmarshalToObject(s.typer, s.creater, &actual, into, unk.Raw, s.strict)
 }
 
 // Encode serializes the provided object to the given writer.
@@ -173,13 +193,15 @@ func (s *Serializer) Encode(obj runtime.Object, w io.Writer) error {
 	switch t := obj.(type) {
 	case *runtime.Unknown:
 		estimatedSize := prefixSize + uint64(t.Size())
-		data := make([]byte, estimatedSize)
+		data := getBuffer(estimatedSize)
 		i, err := t.MarshalTo(data[prefixSize:])
 		if err != nil {
+			putBuffer(data)
 			return err
 		}
 		copy(data, s.prefix)
 		_, err = w.Write(data[:prefixSize+uint64(i)])
+		putBuffer(data)
 		return err
 	default:
 		kind := obj.GetObjectKind().GroupVersionKind()
@@ -195,43 +217,75 @@ func (s *Serializer) Encode(obj runtime.Object, w io.Writer) error {
 	case bufferedMarshaller:
 		// this path performs a single allocation during write but requires the caller to implement
 		// the more efficient Size and MarshalTo methods
-		encodedSize := uint64(t.Size())
+		encodedSize := cachedSize(obj, t)
+
+		// large lists are streamed item by item so the response does not
+		// have to be assembled in a single contiguous buffer
+		if encodedSize > streamingListThreshold {
+			if items, ok := streamableList(obj); ok {
+				// the streamed length prefix cannot be repaired once bytes
+				// are on the wire, so size the list directly rather than
+				// trusting a possibly stale cached value
+				return s.encodeListStreaming(unk, t, uint64(t.Size()), items, w)
+			}
+		}
+
 		estimatedSize := prefixSize + estimateUnknownSize(&unk, encodedSize)
-		data := make([]byte, estimatedSize)
+		data := getBuffer(estimatedSize)
 
-		i, err := unk.NestedMarshalTo(data[prefixSize:], t, encodedSize)
+		i, err := nestedMarshalToSafe(&unk, data[prefixSize:], s.marshaller(t), encodedSize)
 		if err != nil {
-			return err
+			putBuffer(data)
+			// a stale cached size either overruns the buffer or disagrees
+			// with the bytes written; both surface as errors here, so drop
+			// the entry and retry once with a freshly computed size
+			invalidateSize(obj)
+			freshSize := uint64(t.Size())
+			if freshSize == encodedSize {
+				return err
+			}
+			encodedSize = freshSize
+			estimatedSize = prefixSize + estimateUnknownSize(&unk, encodedSize)
+			data = getBuffer(estimatedSize)
+			if i, err = nestedMarshalToSafe(&unk, data[prefixSize:], s.marshaller(t), encodedSize); err != nil {
+				putBuffer(data)
+				return err
+			}
 		}
 
 		copy(data, s.prefix)
 
 		_, err = w.Write(data[:prefixSize+uint64(i)])
+		putBuffer(data)
 		return err
 
 	case proto.Marshaler:
 		// this path performs extra allocations
-		data, err := t.Marshal()
+		data, err := marshal(t, s.deterministic)
 		if err != nil {
 			return err
 		}
 		unk.Raw = data
 
 		estimatedSize := prefixSize + uint64(unk.Size())
-		data = make([]byte, estimatedSize)
+		data = getBuffer(estimatedSize)
 
 		i, err := unk.MarshalTo(data[prefixSize:])
 		if err != nil {
+			putBuffer(data)
 			return err
 		}
 
 		copy(data, s.prefix)
 
 		_, err = w.Write(data[:prefixSize+uint64(i)])
+		putBuffer(data)
 		return err
 
 	default:
-		// TODO: marshal with a different content type and serializer (JSON for third party objects)
+		if u, ok := obj.(*runtime.Unstructured); ok {
+			return s.encodeUnstructured(unk, u, w)
+		}
 		return errNotMarshalable{reflect.TypeOf(obj)}
 	}
 }
@@ -274,6 +328,28 @@ type bufferedMarshaller interface {
 	runtime.ProtobufMarshaller
 }
 
+// nestedMarshalToSafe invokes NestedMarshalTo, converting the panic a
+// generated MarshalTo raises on an undersized buffer into an error so a
+// stale cached size cannot crash the encode path.
+func nestedMarshalToSafe(unk *runtime.Unknown, data []byte, b runtime.ProtobufMarshaller, size uint64) (n int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("buffer of %d bytes too small marshalling %T: %v", len(data), b, r)
+		}
+	}()
+	return unk.NestedMarshalTo(data, b, size)
+}
+
+// marshalToSafe is nestedMarshalToSafe for the raw, envelope-free path.
+func marshalToSafe(t bufferedMarshaller, data []byte) (n int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("buffer of %d bytes too small marshalling %T: %v", len(data), t, r)
+		}
+	}()
+	return t.MarshalTo(data)
+}
+
 // estimateUnknownSize returns the expected bytes consumed by a given runtime.Unknown
 // object with a nil RawJSON struct and the expected size of the provided buffer. The
 // returned size will not be correct if RawJSOn is set on unk.
@@ -304,6 +380,7 @@ type RawSerializer struct {
 	creater     runtime.ObjectCreater
 	typer       runtime.ObjectTyper
 	contentType string
+	strict      bool
 }
 
 var _ runtime.Serializer = &RawSerializer{}
@@ -360,6 +437,11 @@ func (s *RawSerializer) Decode(originalData []byte, gvk *unversioned.GroupVersio
 		if err := proto.Unmarshal(data, pb); err != nil {
 			return nil, actual, err
 		}
+		if s.strict {
+			if err := verifyNoUnknownFields(pb, data); err != nil {
+				return nil, actual, err
+			}
+		}
 		return into, actual, nil
 	case err != nil:
 		return nil, actual, err
@@ -380,11 +462,11 @@ func (s *RawSerializer) Decode(originalData []byte, gvk *unversioned.GroupVersio
 		return nil, actual, runtime.NewMissingVersionErr("<protobuf encoded body - must provide default type>")
 	}
 
-	return unmarshalToObject(s.typer, s.creater, actual, into, data)
+	return unmarshalToObject(s.typer, s.creater, actual, into, data, s.strict)
 }
 
 // unmarshalToObject is the common code between decode in the raw and normal serializer.
-func unmarshalToObject(typer runtime.ObjectTyper, creater runtime.ObjectCreater, actual *unversioned.GroupVersionKind, into runtime.Object, data []byte) (runtime.Object, *unversioned.GroupVersionKind, error) {
+func unmarshalToObject(typer runtime.ObjectTyper, creater runtime.ObjectCreater, actual *unversioned.GroupVersionKind, into runtime.Object, data []byte, strict bool) (runtime.Object, *unversioned.GroupVersionKind, error) {
 	// use the target if necessary
 	obj, err := runtime.UseOrCreateObject(typer, creater, *actual, into)
 	if err != nil {
@@ -398,6 +480,11 @@ func unmarshalToObject(typer runtime.ObjectTyper, creater runtime.ObjectCreater,
 	if err := proto.Unmarshal(data, pb); err != nil {
 		return nil, actual, err
 	}
+	if strict {
+		if err := verifyNoUnknownFields(pb, data); err != nil {
+			return nil, actual, err
+		}
+	}
 	return obj, actual, nil
 }
 
@@ -407,14 +494,27 @@ func (s *RawSerializer) Encode(obj runtime.Object, w io.Writer) error {
 	case bufferedMarshaller:
 		// this path performs a single allocation during write but requires the caller to implement
 		// the more efficient Size and MarshalTo methods
-		encodedSize := uint64(t.Size())
-		data := make([]byte, encodedSize)
+		encodedSize := cachedSize(obj, t)
+		data := getBuffer(encodedSize)
 
-		n, err := t.MarshalTo(data)
+		n, err := marshalToSafe(t, data)
 		if err != nil {
-			return err
+			putBuffer(data)
+			// an undersized buffer from a stale cached size surfaces as an
+			// error; drop the entry and retry once with a direct Size()
+			invalidateSize(obj)
+			freshSize := uint64(t.Size())
+			if freshSize == encodedSize {
+				return err
+			}
+			data = getBuffer(freshSize)
+			if n, err = marshalToSafe(t, data); err != nil {
+				putBuffer(data)
+				return err
+			}
 		}
 		_, err = w.Write(data[:n])
+		putBuffer(data)
 		return err
 
 	case proto.Marshaler:
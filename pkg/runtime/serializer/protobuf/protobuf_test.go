@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 	"reflect"
 	"strings"
 	"testing"
@@ -349,3 +350,51 @@ func TestDecodeObjects(t *testing.T) {
 		}
 	}
 }
+
+func benchmarkEncode(b *testing.B, obj runtime.Object) {
+	s := protobuf.NewSerializer(api.Scheme, api.Scheme, "application/protobuf")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := s.Encode(obj, ioutil.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodePod(b *testing.B) {
+	benchmarkEncode(b, &v1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: "pod", Namespace: "ns", Labels: map[string]string{"app": "test"}},
+		Spec: v1.PodSpec{
+			NodeName: "node",
+			Containers: []v1.Container{
+				{Name: "test", Image: "test/image", Command: []string{"/bin/sh", "-c", "sleep"}},
+			},
+		},
+	})
+}
+
+func BenchmarkEncodeNode(b *testing.B) {
+	benchmarkEncode(b, &v1.Node{
+		ObjectMeta: v1.ObjectMeta{Name: "node", Labels: map[string]string{"zone": "a"}},
+		Status: v1.NodeStatus{
+			Addresses: []v1.NodeAddress{
+				{Type: v1.NodeInternalIP, Address: "10.0.0.1"},
+			},
+		},
+	})
+}
+
+func BenchmarkEncodeEndpoints(b *testing.B) {
+	subsets := make([]v1.EndpointSubset, 0, 100)
+	for i := 0; i < 100; i++ {
+		subsets = append(subsets, v1.EndpointSubset{
+			Addresses: []v1.EndpointAddress{{IP: fmt.Sprintf("10.0.0.%d", i)}},
+			Ports:     []v1.EndpointPort{{Name: "http", Port: 80}},
+		})
+	}
+	benchmarkEncode(b, &v1.Endpoints{
+		ObjectMeta: v1.ObjectMeta{Name: "endpoints", Namespace: "ns"},
+		Subsets:    subsets,
+	})
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"reflect"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// sizeCacheSize bounds the number of remembered object sizes. A size entry is
+// two small structs, so this can be considerably larger than a cache of
+// encoded bytes.
+const sizeCacheSize = 4096
+
+// objectSizes memoizes the result of Size() for recently encoded objects.
+// Size() walks the entire object graph, and during watch fan-out the same
+// object is encoded once per watcher, repeating that walk each time.
+var objectSizes, _ = lru.New(sizeCacheSize)
+
+type sizeCacheKey struct {
+	// objType distinguishes objects that share identity metadata but encode
+	// differently: the same resource carried by its internal and external
+	// types, or by different external versions, has different sizes.
+	objType         reflect.Type
+	apiVersion      string
+	kind            string
+	name            string
+	namespace       string
+	uid             string
+	resourceVersion string
+}
+
+// sizeKeyFor returns the cache key identifying obj's encoded content, or
+// false if the object cannot be keyed safely.
+func sizeKeyFor(obj runtime.Object) (sizeCacheKey, bool) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return sizeCacheKey{}, false
+	}
+	rv := accessor.GetResourceVersion()
+	if len(rv) == 0 {
+		return sizeCacheKey{}, false
+	}
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	return sizeCacheKey{
+		objType:         reflect.TypeOf(obj),
+		apiVersion:      gvk.GroupVersion().String(),
+		kind:            gvk.Kind,
+		name:            accessor.GetName(),
+		namespace:       accessor.GetNamespace(),
+		uid:             string(accessor.GetUID()),
+		resourceVersion: rv,
+	}, true
+}
+
+// cachedSize returns t.Size(), consulting the size cache first. Only objects
+// with a resource version are cached: the resource version identifies the
+// content of a stored object, so equal keys imply an equal encoded size.
+// Objects without one (or without object metadata at all) are sized directly.
+//
+// The key can still collide when an object is copied and mutated without
+// changing its resource version — for example a variant with managedFields
+// stripped before delivery. Callers that size a buffer from this value must
+// therefore verify the bytes actually written and fall back to a direct
+// Size() on a mismatch, invalidating the stale entry with invalidateSize.
+func cachedSize(obj runtime.Object, t bufferedMarshaller) uint64 {
+	key, ok := sizeKeyFor(obj)
+	if !ok {
+		return uint64(t.Size())
+	}
+	if value, found := objectSizes.Get(key); found {
+		return value.(uint64)
+	}
+	size := uint64(t.Size())
+	objectSizes.Add(key, size)
+	return size
+}
+
+// invalidateSize drops any cached size for obj, so one stale entry cannot
+// keep corrupting encodes after a collision is detected.
+func invalidateSize(obj runtime.Object) {
+	if key, ok := sizeKeyFor(obj); ok {
+		objectSizes.Remove(key)
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf_test
+
+import (
+	"bytes"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/runtime/serializer/protobuf"
+)
+
+// sizedObject counts calls to Size so tests can observe cache hits, and
+// writes exactly size bytes so the serializer's buffer math is exercised
+// the same way a generated type would.
+type sizedObject struct {
+	unversioned.TypeMeta
+	api.ObjectMeta
+
+	size      int
+	sizeCalls int
+}
+
+func (o *sizedObject) GetObjectKind() unversioned.ObjectKind { return &o.TypeMeta }
+
+func (o *sizedObject) Size() int {
+	o.sizeCalls++
+	return o.size
+}
+
+func (o *sizedObject) MarshalTo(data []byte) (int, error) {
+	// index explicitly so an undersized buffer panics, as generated
+	// marshallers do
+	for i := 0; i < o.size; i++ {
+		data[i] = 0xff
+	}
+	return o.size, nil
+}
+
+// sizedObjectVariant is a second concrete type carrying the same identity
+// metadata, standing in for the same resource held by a different Go type.
+type sizedObjectVariant struct {
+	sizedObject
+}
+
+func TestEncodeCachesSizes(t *testing.T) {
+	s := protobuf.NewSerializer(nil, nil, "application/protobuf")
+	obj := &sizedObject{ObjectMeta: api.ObjectMeta{Name: "cached", Namespace: "b", UID: "c", ResourceVersion: "10"}, size: 42}
+
+	for i := 0; i < 2; i++ {
+		if err := s.Encode(obj, &bytes.Buffer{}); err != nil {
+			t.Fatalf("unexpected encode error: %v", err)
+		}
+	}
+	if obj.sizeCalls != 1 {
+		t.Errorf("expected the second encode to be served from the cache, Size was called %d times", obj.sizeCalls)
+	}
+
+	// a new resource version invalidates the cached entry
+	obj.ResourceVersion = "11"
+	if err := s.Encode(obj, &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+	if obj.sizeCalls != 2 {
+		t.Errorf("expected a changed resource version to miss the cache, Size was called %d times", obj.sizeCalls)
+	}
+}
+
+func TestEncodeSkipsCacheForUnversionedObjects(t *testing.T) {
+	s := protobuf.NewSerializer(nil, nil, "application/protobuf")
+	obj := &sizedObject{ObjectMeta: api.ObjectMeta{Name: "unversioned"}, size: 42}
+
+	for i := 0; i < 2; i++ {
+		if err := s.Encode(obj, &bytes.Buffer{}); err != nil {
+			t.Fatalf("unexpected encode error: %v", err)
+		}
+	}
+	if obj.sizeCalls != 2 {
+		t.Errorf("expected objects without a resource version to be sized every time, Size was called %d times", obj.sizeCalls)
+	}
+}
+
+func TestEncodeDistinguishesTypesWithEqualIdentity(t *testing.T) {
+	s := protobuf.NewSerializer(nil, nil, "application/protobuf")
+	meta := api.ObjectMeta{Name: "shared", Namespace: "b", UID: "c", ResourceVersion: "10"}
+	a := &sizedObject{ObjectMeta: meta, size: 10}
+	b := &sizedObjectVariant{sizedObject{ObjectMeta: meta, size: 50}}
+
+	if err := s.Encode(a, &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+	if err := s.Encode(b, &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+	if b.sizeCalls == 0 {
+		t.Errorf("expected a different concrete type to miss the cache")
+	}
+}
+
+func TestEncodeRecoversFromStaleCachedSize(t *testing.T) {
+	s := protobuf.NewSerializer(nil, nil, "application/protobuf")
+	obj := &sizedObject{ObjectMeta: api.ObjectMeta{Name: "stale", Namespace: "b", UID: "c", ResourceVersion: "10"}, size: 10}
+
+	if err := s.Encode(obj, &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+
+	// grow the object in place without changing its identity metadata: the
+	// cached size is now too small, which must surface as a retried encode,
+	// never a panic
+	obj.size = 4000
+	buf := &bytes.Buffer{}
+	if err := s.Encode(obj, buf); err != nil {
+		t.Fatalf("expected the encode to recover from the stale size, got %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), bytes.Repeat([]byte{0xff}, 4000)) {
+		t.Errorf("expected the recovered encode to carry the full payload")
+	}
+
+	// shrink it again: the cached size is now too large and the envelope's
+	// length prefix would lie about the payload
+	obj.size = 10
+	buf.Reset()
+	if err := s.Encode(obj, buf); err != nil {
+		t.Fatalf("expected the encode to recover from the stale size, got %v", err)
+	}
+}
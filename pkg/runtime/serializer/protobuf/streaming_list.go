@@ -0,0 +1,206 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+const (
+	// streamingListThreshold is the encoded size in bytes above which Encode
+	// writes list objects to the writer incrementally instead of building the
+	// entire response in one contiguous buffer.
+	streamingListThreshold = 4 * 1024 * 1024
+
+	// itemsFieldTag is the protobuf key of the Items field of every generated
+	// list type: field number 2, wire type 2 (length-delimited).
+	itemsFieldTag = 0x12
+)
+
+// listStreamingMarshaller is implemented by generated list types whose items
+// can be marshalled independently.
+type listStreamingMarshaller interface {
+	bufferedMarshaller
+}
+
+// streamableList returns the Items slice of obj if obj is a generated list
+// type whose encoding can be streamed item by item, along with the marshal
+// size of the list with its items removed.
+func streamableList(obj runtime.Object) (items reflect.Value, ok bool) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return reflect.Value{}, false
+	}
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	items = v.FieldByName("Items")
+	if !items.IsValid() || items.Kind() != reflect.Slice {
+		return reflect.Value{}, false
+	}
+	return items, true
+}
+
+// encodeListStreaming writes the same bytes Encode would produce for a list,
+// but item by item: the Unknown envelope and list metadata are written first,
+// then every item is marshalled into a buffer that is reused across items.
+// Memory usage is bounded by the largest item rather than the whole list.
+func (s *Serializer) encodeListStreaming(unk runtime.Unknown, t listStreamingMarshaller, listSize uint64, items reflect.Value, w io.Writer) error {
+	// Marshal the list with its items removed. For generated list types this
+	// yields exactly the leading (metadata) portion of the list message.
+	listValue := reflect.ValueOf(t).Elem()
+	header := reflect.New(listValue.Type())
+	header.Elem().Set(listValue)
+	headerItems := header.Elem().FieldByName("Items")
+	headerItems.Set(reflect.MakeSlice(headerItems.Type(), 0, 0))
+	headerMarshaller, ok := header.Interface().(listStreamingMarshaller)
+	if !ok {
+		return errNotMarshalable{header.Type()}
+	}
+	headerSize := headerMarshaller.Size()
+	buf := getBuffer(uint64(headerSize))
+	defer func() { putBuffer(buf) }()
+	n, err := s.marshaller(headerMarshaller).MarshalTo(buf)
+	if err != nil {
+		return err
+	}
+
+	// Write the prefix and the envelope through the Raw field's key and
+	// length, followed by the list metadata.
+	envelope := make([]byte, len(s.prefix)+estimateEnvelopeSize(&unk, listSize))
+	i := copy(envelope, s.prefix)
+	i, err = marshalEnvelopeTo(&unk, envelope, i, listSize)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(envelope[:i]); err != nil {
+		return err
+	}
+	if _, err := w.Write(buf[:n]); err != nil {
+		return err
+	}
+	written := uint64(n)
+
+	for i := 0; i < items.Len(); i++ {
+		item, ok := items.Index(i).Addr().Interface().(bufferedMarshaller)
+		if !ok {
+			return errNotMarshalable{items.Index(i).Type()}
+		}
+		itemSize := uint64(item.Size())
+		// key byte + varint length + payload
+		need := 1 + sovEnvelope(itemSize) + int(itemSize)
+		if cap(buf) < need {
+			putBuffer(buf)
+			buf = getBuffer(uint64(need))
+		}
+		buf = buf[:need]
+		buf[0] = itemsFieldTag
+		offset := encodeVarintEnvelope(buf, 1, itemSize)
+		n, err := s.marshaller(item).MarshalTo(buf[offset:])
+		if err != nil {
+			return err
+		}
+		if uint64(n) != itemSize {
+			return fmt.Errorf("the Size() value of %v was %d, but MarshalTo wrote %d bytes", items.Index(i).Type(), itemSize, n)
+		}
+		if _, err := w.Write(buf[:offset+n]); err != nil {
+			return err
+		}
+		written += uint64(offset + n)
+	}
+
+	if written != listSize {
+		return fmt.Errorf("streamed %d bytes for a list whose Size() was %d; the response is corrupt", written, listSize)
+	}
+
+	// The trailing envelope fields follow the Raw bytes.
+	trailer := make([]byte, estimateEnvelopeSize(&unk, 0))
+	i, err = marshalEnvelopeTrailerTo(&unk, trailer)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(trailer[:i])
+	return err
+}
+
+// marshalEnvelopeTo writes the Unknown envelope fields that precede the Raw
+// payload: the TypeMeta message and the Raw field's key and length.
+func marshalEnvelopeTo(unk *runtime.Unknown, data []byte, offset int, rawSize uint64) (int, error) {
+	i := offset
+	data[i] = 0xa
+	i++
+	i = encodeVarintEnvelope(data, i, uint64(unk.TypeMeta.Size()))
+	n, err := unk.TypeMeta.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n
+	data[i] = 0x12
+	i++
+	i = encodeVarintEnvelope(data, i, rawSize)
+	return i, nil
+}
+
+// marshalEnvelopeTrailerTo writes the Unknown envelope fields that follow the
+// Raw payload.
+func marshalEnvelopeTrailerTo(unk *runtime.Unknown, data []byte) (int, error) {
+	i := 0
+	data[i] = 0x1a
+	i++
+	i = encodeVarintEnvelope(data, i, uint64(len(unk.ContentEncoding)))
+	i += copy(data[i:], unk.ContentEncoding)
+	data[i] = 0x22
+	i++
+	i = encodeVarintEnvelope(data, i, uint64(len(unk.ContentType)))
+	i += copy(data[i:], unk.ContentType)
+	return i, nil
+}
+
+// estimateEnvelopeSize returns an upper bound for the envelope bytes around a
+// Raw payload of the given size. The payload itself is streamed separately
+// and not included.
+func estimateEnvelopeSize(unk *runtime.Unknown, rawSize uint64) int {
+	// unk.Size() covers the TypeMeta, ContentEncoding and ContentType
+	// fields along with the (empty) Raw field.
+	return int(uint64(unk.Size())+uint64(sovEnvelope(rawSize))) + 1
+}
+
+func encodeVarintEnvelope(data []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		data[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	data[offset] = uint8(v)
+	return offset + 1
+}
+
+func sovEnvelope(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
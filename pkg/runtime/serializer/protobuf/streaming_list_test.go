@@ -0,0 +1,141 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"bytes"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// fakeStreamItem marshals to a fixed byte payload, mimicking a generated item type.
+type fakeStreamItem struct {
+	data []byte
+}
+
+func (m *fakeStreamItem) Size() int { return len(m.data) }
+
+func (m *fakeStreamItem) MarshalTo(data []byte) (int, error) {
+	return copy(data, m.data), nil
+}
+
+// fakeStreamList marshals the way generated list types do: the list metadata
+// first, followed by each item as field 2.
+type fakeStreamList struct {
+	unversioned.TypeMeta
+
+	// Metadata stands in for the marshalled ListMeta field of a generated list.
+	Metadata []byte
+	Items    []fakeStreamItem
+}
+
+func (m *fakeStreamList) Size() int {
+	n := len(m.Metadata)
+	for i := range m.Items {
+		l := m.Items[i].Size()
+		n += 1 + l + sovEnvelope(uint64(l))
+	}
+	return n
+}
+
+func (m *fakeStreamList) MarshalTo(data []byte) (int, error) {
+	i := copy(data, m.Metadata)
+	for j := range m.Items {
+		data[i] = 0x12
+		i++
+		i = encodeVarintEnvelope(data, i, uint64(m.Items[j].Size()))
+		n, err := m.Items[j].MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n
+	}
+	return i, nil
+}
+
+func TestEncodeListStreaming(t *testing.T) {
+	testCases := []struct {
+		list *fakeStreamList
+		name string
+	}{
+		{
+			list: &fakeStreamList{},
+			name: "empty list",
+		},
+		{
+			list: &fakeStreamList{
+				Metadata: []byte{0xa, 0x2, 0x1, 0x2},
+			},
+			name: "metadata only",
+		},
+		{
+			list: &fakeStreamList{
+				Metadata: []byte{0xa, 0x2, 0x1, 0x2},
+				Items: []fakeStreamItem{
+					{data: []byte{0x1}},
+					{data: []byte{}},
+					{data: bytes.Repeat([]byte{0x2}, 1000)},
+				},
+			},
+			name: "items of varying sizes",
+		},
+	}
+
+	s := NewSerializer(nil, nil, "application/vnd.kubernetes.protobuf")
+	for _, testCase := range testCases {
+		unk := runtime.Unknown{
+			TypeMeta:    runtime.TypeMeta{Kind: "FakeStreamList", APIVersion: "v1"},
+			ContentType: "application/vnd.kubernetes.protobuf",
+		}
+		listSize := uint64(testCase.list.Size())
+
+		// the buffered path is the reference encoding
+		expect := make([]byte, uint64(len(s.prefix))+estimateUnknownSize(&unk, listSize))
+		n, err := unk.NestedMarshalTo(expect[len(s.prefix):], testCase.list, listSize)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", testCase.name, err)
+			continue
+		}
+		copy(expect, s.prefix)
+		expect = expect[:len(s.prefix)+n]
+
+		items, ok := streamableList(testCase.list)
+		if !ok {
+			t.Errorf("%s: expected list to be streamable", testCase.name)
+			continue
+		}
+		buf := &bytes.Buffer{}
+		if err := s.encodeListStreaming(unk, testCase.list, listSize, items, buf); err != nil {
+			t.Errorf("%s: unexpected error: %v", testCase.name, err)
+			continue
+		}
+		if !bytes.Equal(expect, buf.Bytes()) {
+			t.Errorf("%s: streamed bytes do not match the buffered encoding:\n%x\n%x", testCase.name, expect, buf.Bytes())
+		}
+	}
+}
+
+func TestStreamableList(t *testing.T) {
+	if _, ok := streamableList(&unversioned.Status{}); ok {
+		t.Errorf("objects without an Items slice must not be streamable")
+	}
+	if _, ok := streamableList(&fakeStreamList{}); !ok {
+		t.Errorf("expected list with an Items slice to be streamable")
+	}
+}
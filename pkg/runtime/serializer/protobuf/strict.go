@@ -0,0 +1,77 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// NewStrictSerializer is identical to NewSerializer except that decoding fails
+// when the data contains fields the target type does not know about. The
+// generated Unmarshal methods silently skip unknown field numbers, which turns
+// version skew between components into silent data loss; strict mode surfaces
+// it as an error instead.
+func NewStrictSerializer(creater runtime.ObjectCreater, typer runtime.ObjectTyper, defaultContentType string) *Serializer {
+	s := NewSerializer(creater, typer, defaultContentType)
+	s.strict = true
+	return s
+}
+
+// NewStrictRawSerializer is the strict counterpart of NewRawSerializer.
+func NewStrictRawSerializer(creater runtime.ObjectCreater, typer runtime.ObjectTyper, defaultContentType string) *RawSerializer {
+	s := NewRawSerializer(creater, typer, defaultContentType)
+	s.strict = true
+	return s
+}
+
+// strictDecodingError is returned when strict decoding finds data the target
+// type did not recognize.
+type strictDecodingError struct {
+	message string
+}
+
+func (e strictDecodingError) Error() string {
+	return e.message
+}
+
+// IsStrictDecodingError returns true if the error was produced by a serializer
+// operating in strict mode.
+func IsStrictDecodingError(err error) bool {
+	_, ok := err.(strictDecodingError)
+	return ok
+}
+
+// verifyNoUnknownFields returns an error if unmarshalling data into pb dropped
+// information. Generated Unmarshal methods skip field numbers they do not know,
+// so if re-encoding the decoded message would yield fewer bytes than were
+// provided, the data contained unknown (or duplicated) fields.
+func verifyNoUnknownFields(pb proto.Message, data []byte) error {
+	sizer, ok := pb.(proto.Sizer)
+	if !ok {
+		return nil
+	}
+	if size := sizer.Size(); size != len(data) {
+		return strictDecodingError{
+			message: fmt.Sprintf("strict decoding: only %d of %d bytes were recognized as fields of %T (unknown or duplicate fields, possibly from a newer API version)", size, len(data), pb),
+		}
+	}
+	return nil
+}
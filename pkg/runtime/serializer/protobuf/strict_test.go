@@ -0,0 +1,77 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+
+	"k8s.io/kubernetes/pkg/api/unversioned"
+)
+
+func TestVerifyNoUnknownFields(t *testing.T) {
+	status := &unversioned.Status{Message: "test", Reason: "reason"}
+	data, err := status.Marshal()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// field number 100, wire type 2, a payload the Status type does not know about
+	unknownField := []byte{0xa2, 0x6, 0x3, 0x1, 0x2, 0x3}
+
+	testCases := []struct {
+		data    []byte
+		wantErr bool
+		name    string
+	}{
+		{
+			data: data,
+			name: "known fields only",
+		},
+		{
+			data:    append(append([]byte{}, data...), unknownField...),
+			wantErr: true,
+			name:    "trailing unknown field",
+		},
+		{
+			data:    append(append([]byte{}, unknownField...), data...),
+			wantErr: true,
+			name:    "leading unknown field",
+		},
+	}
+
+	for _, testCase := range testCases {
+		decoded := &unversioned.Status{}
+		if err := proto.Unmarshal(testCase.data, decoded); err != nil {
+			t.Errorf("%s: unexpected error: %v", testCase.name, err)
+			continue
+		}
+		err := verifyNoUnknownFields(decoded, testCase.data)
+		if testCase.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected a strict decoding error, got none", testCase.name)
+			} else if !IsStrictDecodingError(err) {
+				t.Errorf("%s: expected a strict decoding error, got: %v", testCase.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", testCase.name, err)
+		}
+	}
+}
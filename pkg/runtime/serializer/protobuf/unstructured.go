@@ -0,0 +1,70 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"encoding/json"
+	"io"
+
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// encodeUnstructured writes objects that have no generated protobuf message -
+// third party resources - to the wire by storing their JSON serialization in
+// the Raw field of the runtime.Unknown envelope. ContentType identifies the
+// nested encoding so Decode can unwrap it again.
+func (s *Serializer) encodeUnstructured(unk runtime.Unknown, obj *runtime.Unstructured, w io.Writer) error {
+	raw, err := json.Marshal(obj.Object)
+	if err != nil {
+		return err
+	}
+	unk.Raw = raw
+	unk.ContentType = runtime.ContentTypeJSON
+
+	prefixSize := uint64(len(s.prefix))
+	estimatedSize := prefixSize + uint64(unk.Size())
+	data := getBuffer(estimatedSize)
+
+	i, err := unk.MarshalTo(data[prefixSize:])
+	if err != nil {
+		putBuffer(data)
+		return err
+	}
+
+	copy(data, s.prefix)
+
+	_, err = w.Write(data[:prefixSize+uint64(i)])
+	putBuffer(data)
+	return err
+}
+
+// decodeUnstructured unwraps an envelope whose Raw payload is JSON produced by
+// encodeUnstructured. The payload is decoded into into when it is an
+// Unstructured object, and into a new Unstructured object otherwise.
+func decodeUnstructured(unk *runtime.Unknown, actual *unversioned.GroupVersionKind, into runtime.Object) (runtime.Object, *unversioned.GroupVersionKind, error) {
+	obj, ok := into.(*runtime.Unstructured)
+	if !ok {
+		obj = &runtime.Unstructured{}
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(unk.Raw, &m); err != nil {
+		return nil, actual, err
+	}
+	obj.Object = m
+	return obj, actual, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+func TestEncodeDecodeUnstructured(t *testing.T) {
+	u := &runtime.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "company.com/v1",
+			"kind":       "Foo",
+			"metadata": map[string]interface{}{
+				"name": "test",
+			},
+			"spec": map[string]interface{}{
+				"replicas": float64(3),
+			},
+		},
+	}
+
+	s := NewSerializer(nil, nil, "application/vnd.kubernetes.protobuf")
+	buf := &bytes.Buffer{}
+	if err := s.Encode(u, buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	decoded, actual, err := s.Decode(buf.Bytes(), nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedGVK := unversioned.GroupVersionKind{Group: "company.com", Version: "v1", Kind: "Foo"}
+	if *actual != expectedGVK {
+		t.Errorf("unexpected gvk: %#v", actual)
+	}
+	out, ok := decoded.(*runtime.Unstructured)
+	if !ok {
+		t.Fatalf("unexpected object: %#v", decoded)
+	}
+	if !reflect.DeepEqual(u.Object, out.Object) {
+		t.Errorf("unexpected object:\n%#v\n%#v", u.Object, out.Object)
+	}
+
+	// decoding into an existing Unstructured object reuses it
+	into := &runtime.Unstructured{}
+	decoded, _, err = s.Decode(buf.Bytes(), nil, into)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded != into {
+		t.Errorf("expected decode to reuse the provided object")
+	}
+	if !reflect.DeepEqual(u.Object, into.Object) {
+		t.Errorf("unexpected object:\n%#v\n%#v", u.Object, into.Object)
+	}
+}
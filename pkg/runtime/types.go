@@ -31,10 +31,12 @@ import (
 
 // TypeMeta is shared by all top level objects. The proper way to use it is to inline it in your type,
 // like this:
-// type MyAwesomeAPIObject struct {
-//      runtime.TypeMeta    `json:",inline"`
-//      ... // other fields
-// }
+//
+//	type MyAwesomeAPIObject struct {
+//	     runtime.TypeMeta    `json:",inline"`
+//	     ... // other fields
+//	}
+//
 // func (obj *MyAwesomeAPIObject) SetGroupVersionKind(gvk *unversioned.GroupVersionKind) { unversioned.UpdateTypeMeta(obj,gvk) }; GroupVersionKind() *GroupVersionKind
 //
 // TypeMeta is provided here for convenience. You may use it directly from this package or define
@@ -49,6 +51,7 @@ type TypeMeta struct {
 
 const (
 	ContentTypeJSON string = "application/json"
+	ContentTypeCBOR string = "application/cbor"
 )
 
 // RawExtension is used to hold extensions in external versions.
@@ -58,32 +61,37 @@ const (
 // various plugin types.
 //
 // // Internal package:
-// type MyAPIObject struct {
-// 	runtime.TypeMeta `json:",inline"`
-//	MyPlugin runtime.Object `json:"myPlugin"`
-// }
-// type PluginA struct {
-//	AOption string `json:"aOption"`
-// }
+//
+//	type MyAPIObject struct {
+//		runtime.TypeMeta `json:",inline"`
+//		MyPlugin runtime.Object `json:"myPlugin"`
+//	}
+//
+//	type PluginA struct {
+//		AOption string `json:"aOption"`
+//	}
 //
 // // External package:
-// type MyAPIObject struct {
-// 	runtime.TypeMeta `json:",inline"`
-//	MyPlugin runtime.RawExtension `json:"myPlugin"`
-// }
-// type PluginA struct {
-//	AOption string `json:"aOption"`
-// }
+//
+//	type MyAPIObject struct {
+//		runtime.TypeMeta `json:",inline"`
+//		MyPlugin runtime.RawExtension `json:"myPlugin"`
+//	}
+//
+//	type PluginA struct {
+//		AOption string `json:"aOption"`
+//	}
 //
 // // On the wire, the JSON will look something like this:
-// {
-//	"kind":"MyAPIObject",
-//	"apiVersion":"v1",
-//	"myPlugin": {
-//		"kind":"PluginA",
-//		"aOption":"foo",
-//	},
-// }
+//
+//	{
+//		"kind":"MyAPIObject",
+//		"apiVersion":"v1",
+//		"myPlugin": {
+//			"kind":"PluginA",
+//			"aOption":"foo",
+//		},
+//	}
 //
 // So what happens? Decode first uses json or yaml to unmarshal the serialized data into
 // your external MyAPIObject. That causes the raw JSON to be stored, but not unpacked.
@@ -313,6 +321,44 @@ func (u *Unstructured) SetOwnerReferences(references []metatypes.OwnerReference)
 	u.setNestedField(newReferences, "metadata", "ownerReferences")
 }
 
+// GetManagedFields returns the managedFields entries in their raw map
+// form. The returned maps alias the object's own storage rather than being
+// converted through typed structs, so hot server paths can inspect entries
+// with minimal allocation.
+func (u *Unstructured) GetManagedFields() []map[string]interface{} {
+	field := getNestedField(u.Object, "metadata", "managedFields")
+	if field == nil {
+		return nil
+	}
+	entries, ok := field.([]interface{})
+	if !ok {
+		return nil
+	}
+	ret := make([]map[string]interface{}, 0, len(entries))
+	for i := range entries {
+		if entry, ok := entries[i].(map[string]interface{}); ok {
+			ret = append(ret, entry)
+		}
+	}
+	return ret
+}
+
+// SetManagedFields replaces the managedFields entries with the given raw
+// maps. The maps are stored as-is without copying; nil removes the field.
+func (u *Unstructured) SetManagedFields(entries []map[string]interface{}) {
+	if entries == nil {
+		if metadata, ok := u.Object["metadata"].(map[string]interface{}); ok {
+			delete(metadata, "managedFields")
+		}
+		return
+	}
+	raw := make([]interface{}, 0, len(entries))
+	for i := range entries {
+		raw = append(raw, entries[i])
+	}
+	u.setNestedField(raw, "metadata", "managedFields")
+}
+
 func (u *Unstructured) GetAPIVersion() string {
 	return getNestedString(u.Object, "apiVersion")
 }
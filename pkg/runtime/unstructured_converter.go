@@ -0,0 +1,108 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"k8s.io/kubernetes/pkg/util/json"
+)
+
+// ToUnstructuredFunc converts an object into its unstructured (JSON compatible
+// map) representation without going through an intermediate serialization.
+type ToUnstructuredFunc func(obj Object) (map[string]interface{}, error)
+
+// FromUnstructuredFunc populates obj from its unstructured representation.
+type FromUnstructuredFunc func(u map[string]interface{}, obj Object) error
+
+// UnstructuredConverter converts between typed objects and their unstructured
+// representation. Types may register direct conversion functions (typically
+// from generated code); all other types fall back to a JSON round trip driven
+// by reflection.
+type UnstructuredConverter struct {
+	lock      sync.RWMutex
+	toFuncs   map[reflect.Type]ToUnstructuredFunc
+	fromFuncs map[reflect.Type]FromUnstructuredFunc
+}
+
+// DefaultUnstructuredConverter is the converter used throughout the system.
+// Generated code registers direct conversions against it at init time.
+var DefaultUnstructuredConverter = NewUnstructuredConverter()
+
+func NewUnstructuredConverter() *UnstructuredConverter {
+	return &UnstructuredConverter{
+		toFuncs:   map[reflect.Type]ToUnstructuredFunc{},
+		fromFuncs: map[reflect.Type]FromUnstructuredFunc{},
+	}
+}
+
+// Register records direct conversion functions for the type of obj. Either
+// function may be nil, in which case the reflection fallback is used for that
+// direction.
+func (c *UnstructuredConverter) Register(obj Object, to ToUnstructuredFunc, from FromUnstructuredFunc) error {
+	t := reflect.TypeOf(obj)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return fmt.Errorf("all objects are expected to be pointers to structs, got %v", t)
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if to != nil {
+		c.toFuncs[t] = to
+	}
+	if from != nil {
+		c.fromFuncs[t] = from
+	}
+	return nil
+}
+
+// ToUnstructured returns the unstructured representation of obj, using a
+// registered direct conversion when one exists.
+func (c *UnstructuredConverter) ToUnstructured(obj Object) (map[string]interface{}, error) {
+	c.lock.RLock()
+	fn, ok := c.toFuncs[reflect.TypeOf(obj)]
+	c.lock.RUnlock()
+	if ok {
+		return fn(obj)
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// FromUnstructured populates obj from the provided unstructured representation,
+// using a registered direct conversion when one exists.
+func (c *UnstructuredConverter) FromUnstructured(u map[string]interface{}, obj Object) error {
+	c.lock.RLock()
+	fn, ok := c.fromFuncs[reflect.TypeOf(obj)]
+	c.lock.RUnlock()
+	if ok {
+		return fn(u, obj)
+	}
+	data, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, obj)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime_test
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+type convertible struct {
+	unversioned.TypeMeta `json:",inline"`
+
+	Value int `json:"value"`
+}
+
+func (c *convertible) GetObjectKind() unversioned.ObjectKind { return &c.TypeMeta }
+
+func TestUnstructuredConverterFallback(t *testing.T) {
+	c := runtime.NewUnstructuredConverter()
+
+	obj := &convertible{Value: 7}
+	u, err := c.ToUnstructured(obj)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(u, map[string]interface{}{"value": int64(7)}) {
+		t.Errorf("unexpected unstructured content: %#v", u)
+	}
+
+	out := &convertible{}
+	if err := c.FromUnstructured(u, out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(obj, out) {
+		t.Errorf("unexpected object:\n%#v\n%#v", obj, out)
+	}
+}
+
+func TestUnstructuredConverterRegistered(t *testing.T) {
+	c := runtime.NewUnstructuredConverter()
+	toCalls, fromCalls := 0, 0
+	err := c.Register(&convertible{},
+		func(obj runtime.Object) (map[string]interface{}, error) {
+			toCalls++
+			return map[string]interface{}{"value": int64(obj.(*convertible).Value)}, nil
+		},
+		func(u map[string]interface{}, obj runtime.Object) error {
+			fromCalls++
+			obj.(*convertible).Value = int(u["value"].(int64))
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	u, err := c.ToUnstructured(&convertible{Value: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := &convertible{}
+	if err := c.FromUnstructured(u, out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if toCalls != 1 || fromCalls != 1 {
+		t.Errorf("expected the registered conversions to be used, got %d/%d calls", toCalls, fromCalls)
+	}
+	if out.Value != 3 {
+		t.Errorf("unexpected value: %d", out.Value)
+	}
+}
@@ -432,3 +432,45 @@ func TestDecodeNumbers(t *testing.T) {
 		t.Fatalf("Expected\n\t%#v, got \n\t%#v", pod, pod2)
 	}
 }
+
+func TestUnstructuredManagedFieldsAccessors(t *testing.T) {
+	unstruct := runtime.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name": "test_name",
+				"managedFields": []interface{}{
+					map[string]interface{}{
+						"manager":   "kubelet",
+						"operation": "Update",
+					},
+				},
+			},
+		},
+	}
+
+	entries := unstruct.GetManagedFields()
+	if len(entries) != 1 || entries[0]["manager"] != "kubelet" {
+		t.Fatalf("GetManagedFields()=%v, want the raw entry maps", entries)
+	}
+	// the returned maps alias the object's storage
+	entries[0]["manager"] = "other"
+	if got := unstruct.GetManagedFields(); got[0]["manager"] != "other" {
+		t.Errorf("expected the entry maps to alias the object, got %v", got)
+	}
+
+	unstruct.SetManagedFields([]map[string]interface{}{
+		{"manager": "deploy-tool", "operation": "Apply"},
+	})
+	if got := unstruct.GetManagedFields(); len(got) != 1 || got[0]["manager"] != "deploy-tool" {
+		t.Errorf("SetManagedFields did not round-trip, got %v", got)
+	}
+
+	unstruct.SetManagedFields(nil)
+	metadata := unstruct.Object["metadata"].(map[string]interface{})
+	if _, present := metadata["managedFields"]; present {
+		t.Errorf("expected a nil set to remove the field, got %v", metadata)
+	}
+	if unstruct.GetManagedFields() != nil {
+		t.Errorf("expected nil for an object without managedFields")
+	}
+}
@@ -291,14 +291,34 @@ func (c *Cacher) Watch(ctx context.Context, key string, resourceVersion string,
 	// TODO: Currently we assume that in a given Cacher object, any <filter> that is
 	// passed here is aware of exactly the same trigger (at most one).
 	// Thus, either 0 or 1 values will be returned.
-	if matchValues := filter.Trigger(); len(matchValues) > 0 {
-		triggerValue, triggerSupported = matchValues[0].Value, true
+	for _, matchValue := range filter.Trigger() {
+		// The managedFields manager index only serves lists; the cacher does
+		// not dispatch events on it, so a watcher selecting on it must not be
+		// registered under a trigger value.
+		if matchValue.IndexName == ManagedFieldsManagerIndex {
+			continue
+		}
+		triggerValue, triggerSupported = matchValue.Value, true
+		break
+	}
+
+	omitManagedFields := false
+	if omitter, ok := filter.(managedFieldsOmitter); ok {
+		omitManagedFields = omitter.OmitsManagedFields()
+	}
+	// A filter selecting on the manager index still needs managedFields on
+	// the objects it inspects, even if its consumer omits them from output.
+	needsManagedFields := false
+	for _, matchValue := range filter.Trigger() {
+		if matchValue.IndexName == ManagedFieldsManagerIndex {
+			needsManagedFields = true
+		}
 	}
 
 	c.Lock()
 	defer c.Unlock()
 	forget := forgetWatcher(c, c.watcherIdx, triggerValue, triggerSupported)
-	watcher := newCacheWatcher(watchRV, initEvents, filterFunction(key, c.keyFunc, filter), forget)
+	watcher := newCacheWatcher(watchRV, initEvents, filterFunction(key, c.keyFunc, filter), forget, omitManagedFields, needsManagedFields)
 
 	c.watchers.addWatcher(watcher, c.watcherIdx, triggerValue, triggerSupported)
 	c.watcherIdx++
@@ -350,7 +370,7 @@ func (c *Cacher) List(ctx context.Context, key string, resourceVersion string, f
 	}
 	filterFunc := filterFunction(key, c.keyFunc, filter)
 
-	objs, readResourceVersion, err := c.watchCache.WaitUntilFreshAndList(listRV)
+	objs, readResourceVersion, err := c.watchCache.WaitUntilFreshAndListByIndex(listRV, filter.Trigger())
 	if err != nil {
 		return fmt.Errorf("failed to wait for fresh list: %v", err)
 	}
@@ -412,6 +432,13 @@ func (c *Cacher) processEvent(event watchCacheEvent) {
 
 	c.Lock()
 	defer c.Unlock()
+	// When every current watcher asked to omit managedFields (and none
+	// filters on them), dispatch a stripped representation: the per-watcher
+	// copies made during delivery then never materialize the field sets.
+	if c.allWatchersOmitManagedFields() {
+		event.Object = stripManagedFields(event.Object)
+		event.PrevObject = stripManagedFields(event.PrevObject)
+	}
 	// Iterate over "allWatchers" no matter what the trigger function is.
 	for _, watcher := range c.watchers.allWatchers {
 		watcher.add(event)
@@ -439,6 +466,48 @@ func (c *Cacher) processEvent(event watchCacheEvent) {
 	}
 }
 
+// allWatchersOmitManagedFields returns true if there is at least one watcher
+// and none of them needs managedFields. Must be called under the Cacher lock.
+func (c *Cacher) allWatchersOmitManagedFields() bool {
+	found := false
+	for _, watcher := range c.watchers.allWatchers {
+		if !watcher.omitManagedFields || watcher.needsManagedFields {
+			return false
+		}
+		found = true
+	}
+	for _, watchers := range c.watchers.valueWatchers {
+		for _, watcher := range watchers {
+			if !watcher.omitManagedFields || watcher.needsManagedFields {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
+}
+
+// stripManagedFields returns obj without its managedFields. The returned
+// object is a copy when stripping is necessary; objects without managedFields
+// are returned as-is.
+func stripManagedFields(obj runtime.Object) runtime.Object {
+	if obj == nil {
+		return nil
+	}
+	objectMeta, err := api.ObjectMetaFor(obj)
+	if err != nil || len(objectMeta.ManagedFields) == 0 {
+		return obj
+	}
+	copied, err := api.Scheme.Copy(obj)
+	if err != nil {
+		return obj
+	}
+	if objectMeta, err := api.ObjectMetaFor(copied); err == nil {
+		objectMeta.ManagedFields = nil
+	}
+	return copied
+}
+
 func (c *Cacher) terminateAllWatchers() {
 	c.Lock()
 	defer c.Unlock()
@@ -575,15 +644,22 @@ type cacheWatcher struct {
 	filter  Filter
 	stopped bool
 	forget  func(bool)
+	// omitManagedFields is true when the consumer asked not to receive
+	// managedFields; needsManagedFields is true when the filter inspects
+	// them regardless.
+	omitManagedFields  bool
+	needsManagedFields bool
 }
 
-func newCacheWatcher(resourceVersion uint64, initEvents []watchCacheEvent, filter Filter, forget func(bool)) *cacheWatcher {
+func newCacheWatcher(resourceVersion uint64, initEvents []watchCacheEvent, filter Filter, forget func(bool), omitManagedFields, needsManagedFields bool) *cacheWatcher {
 	watcher := &cacheWatcher{
-		input:   make(chan watchCacheEvent, 10),
-		result:  make(chan watch.Event, 10),
-		filter:  filter,
-		stopped: false,
-		forget:  forget,
+		input:              make(chan watchCacheEvent, 10),
+		result:             make(chan watch.Event, 10),
+		filter:             filter,
+		stopped:            false,
+		forget:             forget,
+		omitManagedFields:  omitManagedFields,
+		needsManagedFields: needsManagedFields,
 	}
 	go watcher.process(initEvents, resourceVersion)
 	return watcher
@@ -659,6 +735,11 @@ func (c *cacheWatcher) sendWatchCacheEvent(event watchCacheEvent) {
 		return
 	}
 
+	if c.omitManagedFields {
+		// stripManagedFields copies when there is anything to strip, so the
+		// shared event object is never mutated
+		event.Object = stripManagedFields(event.Object)
+	}
 	object, err := api.Scheme.Copy(event.Object)
 	if err != nil {
 		glog.Errorf("unexpected copy error: %v", err)
@@ -125,6 +125,7 @@ func (h *etcdHelper) Create(ctx context.Context, key string, obj, out runtime.Ob
 	if err != nil {
 		return err
 	}
+	metrics.RecordObjectSize(getTypeName(obj), len(data), storage.ManagedFieldsSize(obj))
 	if version, err := h.versioner.ObjectResourceVersion(obj); err == nil && version != 0 {
 		return errors.New("resourceVersion may not be set on objects to be created")
 	}
@@ -516,6 +517,7 @@ func (h *etcdHelper) GuaranteedUpdate(ctx context.Context, key string, ptrToType
 		if err != nil {
 			return err
 		}
+		metrics.RecordObjectSize(getTypeName(ptrToType), len(data), storage.ManagedFieldsSize(ret))
 
 		// First time this key has been used, try creating new value.
 		if index == 0 {
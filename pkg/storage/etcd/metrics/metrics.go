@@ -62,6 +62,22 @@ var (
 		},
 		[]string{"operation", "type"},
 	)
+	objectSizeHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "etcd_object_encoded_size_bytes",
+			Help:    "Encoded size in bytes of objects written to etcd for each object type.",
+			Buckets: prometheus.ExponentialBuckets(256, 4, 8),
+		},
+		[]string{"type"},
+	)
+	objectManagedFieldsSizeHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "etcd_object_managed_fields_size_bytes",
+			Help:    "Size in bytes of the managedFields portion of objects written to etcd for each object type.",
+			Buckets: prometheus.ExponentialBuckets(64, 4, 8),
+		},
+		[]string{"type"},
+	)
 )
 
 var registerMetrics sync.Once
@@ -76,9 +92,19 @@ func Register() {
 		prometheus.MustRegister(cacheAddLatency)
 		prometheus.MustRegister(cacheGetLatency)
 		prometheus.MustRegister(etcdRequestLatenciesSummary)
+		prometheus.MustRegister(objectSizeHistogram)
+		prometheus.MustRegister(objectManagedFieldsSizeHistogram)
 	})
 }
 
+// RecordObjectSize records the encoded size of an object written to etcd and
+// the portion of it consumed by managedFields, so that the storage cost of
+// field tracking is measurable per object type.
+func RecordObjectSize(resource string, size, managedFieldsSize int) {
+	objectSizeHistogram.WithLabelValues(resource).Observe(float64(size))
+	objectManagedFieldsSizeHistogram.WithLabelValues(resource).Observe(float64(managedFieldsSize))
+}
+
 func RecordEtcdRequestLatency(verb, resource string, startTime time.Time) {
 	etcdRequestLatenciesSummary.WithLabelValues(verb, resource).Observe(float64(time.Since(startTime) / time.Microsecond))
 }
@@ -110,4 +136,6 @@ func Reset() {
 	// TODO: Reset cacheAddLatency.
 	// TODO: Reset cacheGetLatency.
 	etcdRequestLatenciesSummary.Reset()
+	objectSizeHistogram.Reset()
+	objectManagedFieldsSizeHistogram.Reset()
 }
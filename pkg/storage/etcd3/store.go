@@ -30,6 +30,7 @@ import (
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/storage"
 	"k8s.io/kubernetes/pkg/storage/etcd"
+	"k8s.io/kubernetes/pkg/storage/etcd/metrics"
 	"k8s.io/kubernetes/pkg/watch"
 
 	"github.com/coreos/etcd/clientv3"
@@ -124,6 +125,7 @@ func (s *store) Create(ctx context.Context, key string, obj, out runtime.Object,
 	if err != nil {
 		return err
 	}
+	metrics.RecordObjectSize(reflect.TypeOf(obj).String(), len(data), storage.ManagedFieldsSize(obj))
 	key = keyWithPrefix(s.pathPrefix, key)
 
 	opts, err := s.ttlOpts(ctx, int64(ttl))
@@ -247,6 +249,7 @@ func (s *store) GuaranteedUpdate(ctx context.Context, key string, out runtime.Ob
 		if bytes.Equal(data, origState.data) {
 			return decode(s.codec, s.versioner, origState.data, out, origState.rev)
 		}
+		metrics.RecordObjectSize(reflect.TypeOf(ret).String(), len(data), storage.ManagedFieldsSize(ret))
 
 		opts, err := s.ttlOpts(ctx, int64(ttl))
 		if err != nil {
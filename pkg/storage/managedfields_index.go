@@ -0,0 +1,58 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// ManagedFieldsManagerIndex is the name of the watch cache index over the
+// managers recorded in an object's managedFields. The name is deliberately
+// the same as the field selector key, so a match value produced from a field
+// selector can be served directly from the index. The index is maintained
+// for every resource and is used to serve lists; it is not a watch trigger.
+const ManagedFieldsManagerIndex = "metadata.managedFields.manager"
+
+// managedFieldsManagerIndexFunc indexes an object under each distinct manager
+// present in its managedFields. Objects without object metadata (or without
+// managed fields) are simply not indexed; returning an error here would fail
+// the watch cache store operation for the object.
+func managedFieldsManagerIndexFunc(obj interface{}) ([]string, error) {
+	object, ok := obj.(runtime.Object)
+	if !ok {
+		return nil, nil
+	}
+	objectMeta, err := api.ObjectMetaFor(object)
+	if err != nil {
+		return nil, nil
+	}
+	var managers []string
+	for _, entry := range objectMeta.ManagedFields {
+		found := false
+		for _, manager := range managers {
+			if manager == entry.Manager {
+				found = true
+				break
+			}
+		}
+		if !found {
+			managers = append(managers, entry.Manager)
+		}
+	}
+	return managers, nil
+}
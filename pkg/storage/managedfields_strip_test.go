@@ -0,0 +1,63 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func TestStripManagedFields(t *testing.T) {
+	pod := &api.Pod{ObjectMeta: api.ObjectMeta{
+		Name:          "foo",
+		ManagedFields: []api.ManagedFieldsEntry{{Manager: "kubectl"}},
+	}}
+
+	stripped := stripManagedFields(pod)
+	strippedPod, ok := stripped.(*api.Pod)
+	if !ok || strippedPod == pod {
+		t.Errorf("expected a copy when there is something to strip")
+	}
+	if strippedPod.ManagedFields != nil {
+		t.Errorf("expected managedFields to be removed, got %#v", strippedPod.ManagedFields)
+	}
+	// the original is untouched
+	if len(pod.ManagedFields) != 1 {
+		t.Errorf("expected the original object to keep its managedFields, got %#v", pod.ManagedFields)
+	}
+
+	// objects without managedFields are returned as-is
+	plain := &api.Pod{ObjectMeta: api.ObjectMeta{Name: "bar"}}
+	if stripManagedFields(plain).(*api.Pod) != plain {
+		t.Errorf("expected the object to be returned unchanged")
+	}
+	if stripManagedFields(nil) != nil {
+		t.Errorf("expected nil to be returned for nil")
+	}
+}
+
+func TestWithManagedFieldsOmitted(t *testing.T) {
+	filter := WithManagedFieldsOmitted(Everything)
+	omitter, ok := filter.(managedFieldsOmitter)
+	if !ok || !omitter.OmitsManagedFields() {
+		t.Errorf("expected the filter to be marked as omitting managedFields")
+	}
+	if !filter.Filter(&api.Pod{}) {
+		t.Errorf("expected the wrapped filter to keep its behavior")
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"strconv"
 	"strings"
 
+	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/meta"
 	"k8s.io/kubernetes/pkg/api/validation"
 	"k8s.io/kubernetes/pkg/runtime"
@@ -64,6 +65,36 @@ func EverythingFunc(runtime.Object) bool {
 	return true
 }
 
+// managedFieldsOmitter is implemented by filters belonging to consumers that
+// asked not to receive managedFields in the objects delivered to them.
+type managedFieldsOmitter interface {
+	OmitsManagedFields() bool
+}
+
+// WithManagedFieldsOmitted marks filter as belonging to a consumer that does
+// not want managedFields in the objects it receives. The cacher uses the mark
+// to deliver stripped objects, and to avoid materializing managedFields at
+// all when every watcher on a resource has asked to omit them.
+func WithManagedFieldsOmitted(filter Filter) Filter {
+	return &omitManagedFieldsFilter{filter}
+}
+
+type omitManagedFieldsFilter struct {
+	inner Filter
+}
+
+func (f *omitManagedFieldsFilter) Filter(obj runtime.Object) bool {
+	return f.inner.Filter(obj)
+}
+
+func (f *omitManagedFieldsFilter) Trigger() []MatchValue {
+	return f.inner.Trigger()
+}
+
+func (f *omitManagedFieldsFilter) OmitsManagedFields() bool {
+	return true
+}
+
 func NoTriggerFunc() []MatchValue {
 	return nil
 }
@@ -149,3 +180,20 @@ func hasPathPrefix(s, pathPrefix string) bool {
 	}
 	return false
 }
+
+// ManagedFieldsSize returns the number of bytes of managedFields data carried
+// by obj, or 0 if the object has no object metadata. The fixed parts of the
+// entries are ignored; the field sets dominate the size.
+func ManagedFieldsSize(obj runtime.Object) int {
+	objectMeta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		return 0
+	}
+	size := 0
+	for _, entry := range objectMeta.ManagedFields {
+		if entry.FieldsV1 != nil {
+			size += len(entry.FieldsV1.Raw)
+		}
+	}
+	return size
+}
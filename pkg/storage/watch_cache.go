@@ -83,7 +83,9 @@ type watchCache struct {
 	// store will effectively support LIST operation from the "end of cache
 	// history" i.e. from the moment just after the newest cached watched event.
 	// It is necessary to effectively allow clients to start watching at now.
-	store cache.Store
+	// It is indexed by the managers in each object's managedFields so lists
+	// filtered by manager do not have to scan every object.
+	store cache.Indexer
 
 	// ResourceVersion up to which the watchCache is propagated.
 	resourceVersion uint64
@@ -101,11 +103,13 @@ type watchCache struct {
 
 func newWatchCache(capacity int) *watchCache {
 	wc := &watchCache{
-		capacity:        capacity,
-		cache:           make([]watchCacheElement, capacity),
-		startIndex:      0,
-		endIndex:        0,
-		store:           cache.NewStore(cache.MetaNamespaceKeyFunc),
+		capacity:   capacity,
+		cache:      make([]watchCacheElement, capacity),
+		startIndex: 0,
+		endIndex:   0,
+		store: cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{
+			ManagedFieldsManagerIndex: managedFieldsManagerIndexFunc,
+		}),
 		resourceVersion: 0,
 		clock:           util.RealClock{},
 	}
@@ -207,6 +211,14 @@ func (w *watchCache) List() []interface{} {
 }
 
 func (w *watchCache) WaitUntilFreshAndList(resourceVersion uint64) ([]interface{}, uint64, error) {
+	return w.WaitUntilFreshAndListByIndex(resourceVersion, nil)
+}
+
+// WaitUntilFreshAndListByIndex behaves as WaitUntilFreshAndList, but when one
+// of the provided match values names an index maintained by the store, only
+// the objects under that index value are returned instead of the full list.
+// Callers must still filter the result; an index may over-select.
+func (w *watchCache) WaitUntilFreshAndListByIndex(resourceVersion uint64, matchValues []MatchValue) ([]interface{}, uint64, error) {
 	startTime := w.clock.Now()
 	go func() {
 		// Wake us up when the time limit has expired.  The docs
@@ -228,6 +240,11 @@ func (w *watchCache) WaitUntilFreshAndList(resourceVersion uint64) ([]interface{
 		}
 		w.cond.Wait()
 	}
+	for _, matchValue := range matchValues {
+		if result, err := w.store.ByIndex(matchValue.IndexName, matchValue.Value); err == nil {
+			return result, w.resourceVersion, nil
+		}
+	}
 	return w.store.List(), w.resourceVersion, nil
 }
 
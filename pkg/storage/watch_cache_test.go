@@ -260,6 +260,46 @@ func TestWaitUntilFreshAndList(t *testing.T) {
 	}
 }
 
+func TestWaitUntilFreshAndListByIndex(t *testing.T) {
+	store := newTestWatchCache(3)
+
+	foo := makeTestPod("foo", 2)
+	foo.ManagedFields = []api.ManagedFieldsEntry{{Manager: "my-operator"}}
+	bar := makeTestPod("bar", 5)
+	bar.ManagedFields = []api.ManagedFieldsEntry{{Manager: "kubectl"}}
+
+	// In background, update the store.
+	go func() {
+		store.Add(foo)
+		store.Add(bar)
+	}()
+
+	matchValues := []MatchValue{{IndexName: ManagedFieldsManagerIndex, Value: "my-operator"}}
+	list, resourceVersion, err := store.WaitUntilFreshAndListByIndex(5, matchValues)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resourceVersion != 5 {
+		t.Errorf("unexpected resourceVersion: %v, expected: 5", resourceVersion)
+	}
+	if len(list) != 1 {
+		t.Fatalf("unexpected list returned: %#v", list)
+	}
+	if pod, ok := list[0].(*api.Pod); !ok || pod.Name != "foo" {
+		t.Errorf("unexpected object returned: %#v", list[0])
+	}
+
+	// An unknown index falls back to listing everything.
+	matchValues = []MatchValue{{IndexName: "spec.nodeName", Value: "node-1"}}
+	list, _, err = store.WaitUntilFreshAndListByIndex(5, matchValues)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 2 {
+		t.Errorf("unexpected list returned: %#v", list)
+	}
+}
+
 func TestWaitUntilFreshAndListTimeout(t *testing.T) {
 	store := newTestWatchCache(3)
 	fc := store.clock.(*util.FakeClock)
@@ -0,0 +1,203 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fieldset provides set algebra over the field sets stored in
+// metadata.managedFields. Policy engines, admission plugins, and diff
+// tooling can parse an entry's stored form, combine sets, and walk their
+// leaves without reimplementing the encoding.
+package fieldset
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// fieldPrefix marks member keys in the stored JSON form of a field set.
+const fieldPrefix = "f:"
+
+// Set is a set of field paths, stored as a trie: each key is one path
+// segment, and a path is a member when the walk to it exists. A node with
+// children marks its whole subtree; leaves are empty maps.
+type Set map[string]Set
+
+// New returns a set containing the given dot-separated paths.
+func New(paths ...string) Set {
+	s := Set{}
+	for _, path := range paths {
+		s.Insert(path)
+	}
+	return s
+}
+
+// FromJSON parses the stored form of a field set, as found in a
+// ManagedFieldsEntry's FieldsV1, into a Set.
+func FromJSON(data []byte) (Set, error) {
+	if len(data) == 0 {
+		return Set{}, nil
+	}
+	raw := map[string]interface{}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	return fromRaw(raw), nil
+}
+
+func fromRaw(raw map[string]interface{}) Set {
+	s := Set{}
+	for key, value := range raw {
+		segment := strings.TrimPrefix(key, fieldPrefix)
+		if nested, ok := value.(map[string]interface{}); ok {
+			s[segment] = fromRaw(nested)
+			continue
+		}
+		s[segment] = Set{}
+	}
+	return s
+}
+
+// ToJSON renders the set in the stored form, with "f:" key prefixes.
+func (s Set) ToJSON() ([]byte, error) {
+	return json.Marshal(s.toRaw())
+}
+
+func (s Set) toRaw() map[string]interface{} {
+	raw := map[string]interface{}{}
+	for segment, nested := range s {
+		raw[fieldPrefix+segment] = nested.toRaw()
+	}
+	return raw
+}
+
+// Insert adds a dot-separated path to the set.
+func (s Set) Insert(path string) {
+	node := s
+	for _, segment := range strings.Split(path, ".") {
+		nested, ok := node[segment]
+		if !ok {
+			nested = Set{}
+			node[segment] = nested
+		}
+		node = nested
+	}
+}
+
+// Has returns true when the dot-separated path is a member, i.e. the walk
+// to it exists in the set.
+func (s Set) Has(path string) bool {
+	node := s
+	for _, segment := range strings.Split(path, ".") {
+		nested, ok := node[segment]
+		if !ok {
+			return false
+		}
+		node = nested
+	}
+	return true
+}
+
+// Empty returns true when the set has no members.
+func (s Set) Empty() bool {
+	return len(s) == 0
+}
+
+// Union returns the paths present in either set.
+func (s Set) Union(other Set) Set {
+	out := Set{}
+	for segment, nested := range s {
+		out[segment] = nested.Union(other[segment])
+	}
+	for segment, nested := range other {
+		if _, ok := out[segment]; !ok {
+			out[segment] = nested.Union(nil)
+		}
+	}
+	return out
+}
+
+// Intersection returns the paths present in both sets. A leaf in one set
+// intersected with a subtree in the other keeps the leaf: the leaf stands
+// for the whole subtree.
+func (s Set) Intersection(other Set) Set {
+	out := Set{}
+	for segment, nested := range s {
+		otherNested, ok := other[segment]
+		if !ok {
+			continue
+		}
+		if len(nested) == 0 || len(otherNested) == 0 {
+			out[segment] = Set{}
+			continue
+		}
+		common := nested.Intersection(otherNested)
+		if len(common) > 0 {
+			out[segment] = common
+		}
+	}
+	return out
+}
+
+// Difference returns the paths present in the set but not in other.
+// Subtracting a leaf removes its whole subtree.
+func (s Set) Difference(other Set) Set {
+	out := Set{}
+	for segment, nested := range s {
+		otherNested, ok := other[segment]
+		if !ok {
+			out[segment] = nested.Union(nil)
+			continue
+		}
+		if len(otherNested) == 0 {
+			// the other set owns the whole subtree
+			continue
+		}
+		remaining := nested.Difference(otherNested)
+		if len(remaining) > 0 {
+			out[segment] = remaining
+		}
+	}
+	return out
+}
+
+// Leaves returns the dot-separated paths of the set's leaves, sorted.
+func (s Set) Leaves() []string {
+	var leaves []string
+	s.walkLeaves("", func(path string) {
+		leaves = append(leaves, path)
+	})
+	sort.Strings(leaves)
+	return leaves
+}
+
+// WalkLeaves calls fn for every leaf path in the set, in unspecified
+// order.
+func (s Set) WalkLeaves(fn func(path string)) {
+	s.walkLeaves("", fn)
+}
+
+func (s Set) walkLeaves(prefix string, fn func(path string)) {
+	for segment, nested := range s {
+		path := segment
+		if len(prefix) > 0 {
+			path = prefix + "." + segment
+		}
+		if len(nested) == 0 {
+			fn(path)
+			continue
+		}
+		nested.walkLeaves(path, fn)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoundTripJSON(t *testing.T) {
+	stored := `{"f:metadata":{"f:labels":{"f:app":{}}},"f:spec":{"f:replicas":{}}}`
+	s, err := FromJSON([]byte(stored))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"metadata.labels.app", "spec.replicas"}; !reflect.DeepEqual(s.Leaves(), expected) {
+		t.Errorf("expected leaves %v, got %v", expected, s.Leaves())
+	}
+	data, err := s.ToJSON()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	back, err := FromJSON(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(s, back) {
+		t.Errorf("expected the stored form to round-trip, got %v and %v", s, back)
+	}
+
+	if _, err := FromJSON([]byte(`not json`)); err == nil {
+		t.Errorf("expected malformed data to error")
+	}
+	empty, err := FromJSON(nil)
+	if err != nil || !empty.Empty() {
+		t.Errorf("expected no data to parse as the empty set, got %v, %v", empty, err)
+	}
+}
+
+func TestHas(t *testing.T) {
+	s := New("metadata.labels.app", "spec.replicas")
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"metadata.labels.app", true},
+		{"metadata.labels", true},
+		{"metadata", true},
+		{"spec.replicas", true},
+		{"spec.paused", false},
+		{"status", false},
+	}
+	for _, test := range tests {
+		if has := s.Has(test.path); has != test.expected {
+			t.Errorf("Has(%q): expected %v, got %v", test.path, test.expected, has)
+		}
+	}
+}
+
+func TestUnion(t *testing.T) {
+	a := New("spec.replicas", "metadata.labels.app")
+	b := New("spec.paused", "metadata.labels.app")
+	expected := []string{"metadata.labels.app", "spec.paused", "spec.replicas"}
+	if leaves := a.Union(b).Leaves(); !reflect.DeepEqual(leaves, expected) {
+		t.Errorf("expected %v, got %v", expected, leaves)
+	}
+	// the inputs are not mutated
+	if len(a.Leaves()) != 2 || len(b.Leaves()) != 2 {
+		t.Errorf("expected the inputs to be untouched, got %v and %v", a, b)
+	}
+}
+
+func TestIntersection(t *testing.T) {
+	a := New("spec.replicas", "spec.paused", "metadata.labels.app")
+	b := New("spec.replicas", "metadata.labels.tier")
+	expected := []string{"spec.replicas"}
+	if leaves := a.Intersection(b).Leaves(); !reflect.DeepEqual(leaves, expected) {
+		t.Errorf("expected %v, got %v", expected, leaves)
+	}
+
+	// a leaf stands for its whole subtree
+	whole := New("spec")
+	expected = []string{"spec"}
+	if leaves := a.Intersection(whole).Leaves(); !reflect.DeepEqual(leaves, expected) {
+		t.Errorf("expected a leaf to intersect its subtree as %v, got %v", expected, leaves)
+	}
+}
+
+func TestDifference(t *testing.T) {
+	a := New("spec.replicas", "spec.paused", "metadata.labels.app")
+	b := New("spec.paused")
+	expected := []string{"metadata.labels.app", "spec.replicas"}
+	if leaves := a.Difference(b).Leaves(); !reflect.DeepEqual(leaves, expected) {
+		t.Errorf("expected %v, got %v", expected, leaves)
+	}
+
+	// subtracting a leaf removes its whole subtree
+	expected = []string{"metadata.labels.app"}
+	if leaves := a.Difference(New("spec")).Leaves(); !reflect.DeepEqual(leaves, expected) {
+		t.Errorf("expected %v, got %v", expected, leaves)
+	}
+
+	if !a.Difference(a).Empty() {
+		t.Errorf("expected a set minus itself to be empty")
+	}
+}
+
+func TestWalkLeaves(t *testing.T) {
+	s := New("a.b", "a.c", "d")
+	seen := map[string]bool{}
+	s.WalkLeaves(func(path string) {
+		seen[path] = true
+	})
+	if len(seen) != 3 || !seen["a.b"] || !seen["a.c"] || !seen["d"] {
+		t.Errorf("expected every leaf to be visited once, got %v", seen)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtimeclass
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/golang/glog"
+	"k8s.io/kubernetes/pkg/admission"
+	"k8s.io/kubernetes/pkg/api"
+	apierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/apis/node.k8s.io"
+	clientset "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
+)
+
+func init() {
+	admission.RegisterPlugin("RuntimeClassExists", func(client clientset.Interface, config io.Reader) (admission.Interface, error) {
+		return NewRuntimeClassExists(), nil
+	})
+}
+
+// RuntimeClassLookup provides access to the RuntimeClass a pod references.
+// It matches the scheduler predicate's source so both can share one
+// implementation.
+type RuntimeClassLookup interface {
+	GetRuntimeClassInfo(name string) (*node.RuntimeClass, error)
+}
+
+// plugin rejects pods that reference a RuntimeClass that does not exist, so
+// the pod fails at admission with a clear error instead of opaquely at
+// kubelet admission after it has been scheduled.
+type plugin struct {
+	*admission.Handler
+	// Lookup resolves RuntimeClass references. When nil the plugin only
+	// logs, since it has no way to tell a missing class from a missing
+	// source.
+	Lookup RuntimeClassLookup
+	// WarnOnly logs instead of rejecting, for clusters migrating to
+	// RuntimeClasses that still have pods referencing classes yet to be
+	// created.
+	WarnOnly bool
+}
+
+// NewRuntimeClassExists creates an admission controller that checks the
+// RuntimeClass referenced by a pod exists at admission time.
+func NewRuntimeClassExists() *plugin {
+	return &plugin{
+		Handler: admission.NewHandler(admission.Create),
+	}
+}
+
+// Admit rejects pods whose runtimeClassName resolves to no RuntimeClass.
+func (p *plugin) Admit(attributes admission.Attributes) error {
+	if attributes.GetResource().GroupResource() != api.Resource("pods") {
+		return nil
+	}
+	pod, ok := attributes.GetObject().(*api.Pod)
+	if !ok {
+		return apierrors.NewBadRequest("Resource was marked with kind Pod but was unable to be converted")
+	}
+	if pod.Spec.RuntimeClassName == nil {
+		return nil
+	}
+	name := *pod.Spec.RuntimeClassName
+	if p.Lookup == nil {
+		glog.V(2).Infof("no RuntimeClass source configured: cannot verify RuntimeClass %q referenced by pod %s/%s", name, pod.Namespace, pod.Name)
+		return nil
+	}
+	rc, err := p.Lookup.GetRuntimeClassInfo(name)
+	if err == nil && rc != nil {
+		return nil
+	}
+	if p.WarnOnly {
+		glog.Warningf("pod %s/%s references RuntimeClass %q which does not exist", pod.Namespace, pod.Name, name)
+		return nil
+	}
+	return apierrors.NewForbidden(attributes.GetResource().GroupResource(), pod.Name, fmt.Errorf("pod references RuntimeClass %q which does not exist: create the RuntimeClass or remove spec.runtimeClassName", name))
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtimeclass
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/admission"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/apis/node.k8s.io"
+)
+
+type fakeLookup struct {
+	classes map[string]*node.RuntimeClass
+}
+
+func (f *fakeLookup) GetRuntimeClassInfo(name string) (*node.RuntimeClass, error) {
+	if rc, ok := f.classes[name]; ok {
+		return rc, nil
+	}
+	return nil, fmt.Errorf("runtimeclass %q not found", name)
+}
+
+func admitPod(p *plugin, pod *api.Pod) error {
+	return p.Admit(admission.NewAttributesRecord(pod, nil, api.Kind("Pod").WithVersion("version"), pod.Namespace, pod.Name, api.Resource("pods").WithVersion("version"), "", admission.Create, nil))
+}
+
+func podWithRuntimeClass(name string) *api.Pod {
+	pod := &api.Pod{ObjectMeta: api.ObjectMeta{Name: "test", Namespace: "ns"}}
+	if len(name) > 0 {
+		pod.Spec.RuntimeClassName = &name
+	}
+	return pod
+}
+
+func TestAdmitRejectsMissingRuntimeClass(t *testing.T) {
+	p := NewRuntimeClassExists()
+	p.Lookup = &fakeLookup{classes: map[string]*node.RuntimeClass{
+		"sandboxed": {ObjectMeta: api.ObjectMeta{Name: "sandboxed"}, Handler: "runsc"},
+	}}
+
+	if err := admitPod(p, podWithRuntimeClass("sandboxed")); err != nil {
+		t.Errorf("expected a pod referencing an existing class to be admitted, got %v", err)
+	}
+	if err := admitPod(p, podWithRuntimeClass("")); err != nil {
+		t.Errorf("expected a pod without a runtimeClassName to be admitted, got %v", err)
+	}
+	if err := admitPod(p, podWithRuntimeClass("missing")); err == nil {
+		t.Errorf("expected a pod referencing a missing class to be rejected")
+	}
+}
+
+func TestAdmitWarnOnly(t *testing.T) {
+	p := NewRuntimeClassExists()
+	p.Lookup = &fakeLookup{}
+	p.WarnOnly = true
+
+	if err := admitPod(p, podWithRuntimeClass("missing")); err != nil {
+		t.Errorf("expected warn-only mode to admit the pod, got %v", err)
+	}
+}
+
+func TestAdmitWithoutLookup(t *testing.T) {
+	p := NewRuntimeClassExists()
+	if err := admitPod(p, podWithRuntimeClass("missing")); err != nil {
+		t.Errorf("expected a plugin without a RuntimeClass source to admit the pod, got %v", err)
+	}
+}
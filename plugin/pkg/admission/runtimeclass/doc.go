@@ -0,0 +1,21 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// RuntimeClassExists admission controller rejects pods that reference a
+// RuntimeClass that does not exist, so misconfigured pods fail at admission
+// with an actionable error rather than opaquely at kubelet admission. It can
+// be configured to warn instead of reject for clusters still migrating.
+package runtimeclass
@@ -63,7 +63,30 @@ func (r *RBACAuthorizer) Authorize(attr authorizer.Attributes) error {
 		}
 	}
 
-	return validation.ConfirmNoEscalation(ctx, r.authorizationRuleResolver, []rbac.PolicyRule{requestedRule})
+	err := validation.ConfirmNoEscalation(ctx, r.authorizationRuleResolver, []rbac.PolicyRule{requestedRule})
+	if err != nil && attr.IsResourceRequest() && attr.GetVerb() == "apply" {
+		// Before apply was a distinct verb it was authorized as a patch that
+		// may create the object, so a subject granted patch and create keeps
+		// the ability to apply.
+		fallbackRules := []rbac.PolicyRule{
+			{
+				Verbs:         []string{"patch"},
+				APIGroups:     []string{attr.GetAPIGroup()},
+				Resources:     []string{attr.GetResource()},
+				ResourceNames: []string{attr.GetName()},
+			},
+			{
+				Verbs:         []string{"create"},
+				APIGroups:     []string{attr.GetAPIGroup()},
+				Resources:     []string{attr.GetResource()},
+				ResourceNames: []string{attr.GetName()},
+			},
+		}
+		if validation.ConfirmNoEscalation(ctx, r.authorizationRuleResolver, fallbackRules) == nil {
+			return nil
+		}
+	}
+	return err
 }
 
 func New(roleRegistry role.Registry, roleBindingRegistry rolebinding.Registry, clusterRoleRegistry clusterrole.Registry, clusterRoleBindingRegistry clusterrolebinding.Registry, superUser string) *RBACAuthorizer {
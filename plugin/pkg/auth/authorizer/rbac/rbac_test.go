@@ -203,3 +203,43 @@ func TestAuthorizer(t *testing.T) {
 		}
 	}
 }
+
+func TestAuthorizeApplyVerb(t *testing.T) {
+	roles := []rbac.Role{
+		newRole("applier", "ns1", newRule("apply", "", "pods", "")),
+		newRole("patcher", "ns1", newRule("patch,create", "", "pods", "")),
+		newRole("patch-only", "ns1", newRule("patch", "", "pods", "")),
+	}
+	roleBindings := []rbac.RoleBinding{
+		newRoleBinding("ns1", "applier", bindToRole, "User:applier"),
+		newRoleBinding("ns1", "patcher", bindToRole, "User:patcher"),
+		newRoleBinding("ns1", "patch-only", bindToRole, "User:patch-only"),
+	}
+
+	ruleResolver := validation.NewTestRuleResolver(roles, roleBindings, nil, nil)
+	a := RBACAuthorizer{"", ruleResolver}
+
+	shouldPass := []authorizer.Attributes{
+		// the dedicated verb grants apply without patch
+		&defaultAttributes{"applier", "", "apply", "pods", "ns1", ""},
+		// compatibility: patch plus create continues to grant apply
+		&defaultAttributes{"patcher", "", "apply", "pods", "ns1", ""},
+	}
+	shouldFail := []authorizer.Attributes{
+		// the dedicated verb does not grant arbitrary patches
+		&defaultAttributes{"applier", "", "patch", "pods", "ns1", ""},
+		// patch alone is not enough: apply may create the object
+		&defaultAttributes{"patch-only", "", "apply", "pods", "ns1", ""},
+	}
+
+	for _, attr := range shouldPass {
+		if err := a.Authorize(attr); err != nil {
+			t.Errorf("incorrectly restricted %s: %v", attr, err)
+		}
+	}
+	for _, attr := range shouldFail {
+		if err := a.Authorize(attr); err == nil {
+			t.Errorf("incorrectly passed %s", attr)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicates
+
+import (
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/apis/node.k8s.io"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/algorithm"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/schedulercache"
+)
+
+// RuntimeClassInfo provides access to the RuntimeClass a pod references.
+type RuntimeClassInfo interface {
+	GetRuntimeClassInfo(name string) (*node.RuntimeClass, error)
+}
+
+// RuntimeClassChecker determines whether the scheduling constraints declared
+// by a pod's RuntimeClass are satisfied by a node.
+type RuntimeClassChecker struct {
+	info RuntimeClassInfo
+}
+
+// NewRuntimeClassPredicate creates a predicate which evaluates the
+// nodeSelector and tolerations of the RuntimeClass referenced by a pod, so
+// that pods using a runtime class are only scheduled to nodes that actually
+// have that handler installed.
+func NewRuntimeClassPredicate(info RuntimeClassInfo) algorithm.FitPredicate {
+	c := &RuntimeClassChecker{
+		info: info,
+	}
+	return c.PodMatchesRuntimeClass
+}
+
+func (c *RuntimeClassChecker) PodMatchesRuntimeClass(pod *api.Pod, meta interface{}, nodeInfo *schedulercache.NodeInfo) (bool, error) {
+	if pod.Spec.RuntimeClassName == nil {
+		return true, nil
+	}
+	if c.info == nil {
+		// No source of RuntimeClasses was configured; leave enforcement
+		// to the kubelet rather than failing every pod.
+		return true, nil
+	}
+	rc, err := c.info.GetRuntimeClassInfo(*pod.Spec.RuntimeClassName)
+	if err != nil || rc == nil {
+		// A missing RuntimeClass fails the pod with an explicit message
+		// instead of letting it land on an incompatible node.
+		return false, fmt.Errorf("pod references RuntimeClass %q which could not be found: %v", *pod.Spec.RuntimeClassName, err)
+	}
+	if rc.Scheduling == nil {
+		return true, nil
+	}
+
+	nodeObj := nodeInfo.Node()
+	if nodeObj == nil {
+		return false, fmt.Errorf("node not found")
+	}
+
+	for key, value := range rc.Scheduling.NodeSelector {
+		if nodeObj.Labels[key] != value {
+			return false, ErrRuntimeClassNotMatch
+		}
+	}
+
+	taints, err := api.GetTaintsFromNodeAnnotations(nodeObj.Annotations)
+	if err != nil {
+		return false, err
+	}
+	podTolerations, err := api.GetTolerationsFromPodAnnotations(pod.Annotations)
+	if err != nil {
+		return false, err
+	}
+	// The RuntimeClass tolerations extend the set of nodes the pod itself
+	// tolerates.
+	tolerations := append(podTolerations, rc.Scheduling.Tolerations...)
+	if !tolerationsToleratesTaints(tolerations, taints) {
+		return false, ErrRuntimeClassNotMatch
+	}
+	return true, nil
+}
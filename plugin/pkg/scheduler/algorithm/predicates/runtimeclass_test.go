@@ -0,0 +1,153 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicates
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/apis/node.k8s.io"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/schedulercache"
+)
+
+// FakeRuntimeClassInfo declares a set of runtime classes for testing.
+type FakeRuntimeClassInfo []node.RuntimeClass
+
+func (classes FakeRuntimeClassInfo) GetRuntimeClassInfo(name string) (*node.RuntimeClass, error) {
+	for _, rc := range classes {
+		if rc.Name == name {
+			return &rc, nil
+		}
+	}
+	return nil, fmt.Errorf("unable to find runtime class: %s", name)
+}
+
+func podWithRuntimeClass(name string) *api.Pod {
+	return &api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: "pod"},
+		Spec: api.PodSpec{
+			RuntimeClassName: &name,
+		},
+	}
+}
+
+func TestPodMatchesRuntimeClass(t *testing.T) {
+	classes := FakeRuntimeClassInfo{
+		{
+			ObjectMeta: api.ObjectMeta{Name: "unconstrained"},
+			Handler:    "runc",
+		},
+		{
+			ObjectMeta: api.ObjectMeta{Name: "sandboxed"},
+			Handler:    "kata",
+			Scheduling: &node.Scheduling{
+				NodeSelector: map[string]string{"runtime": "kata"},
+				Tolerations: []api.Toleration{{
+					Key:      "sandboxed",
+					Operator: api.TolerationOpExists,
+					Effect:   api.TaintEffectNoSchedule,
+				}},
+			},
+		},
+	}
+
+	taintedNodeAnnotations := map[string]string{
+		api.TaintsAnnotationKey: `[{"key": "sandboxed", "value": "", "effect": "NoSchedule"}]`,
+	}
+
+	tests := []struct {
+		pod     *api.Pod
+		node    api.Node
+		fits    bool
+		wantErr bool
+		test    string
+	}{
+		{
+			pod:  &api.Pod{},
+			node: api.Node{},
+			fits: true,
+			test: "pod without a runtime class fits any node",
+		},
+		{
+			pod:  podWithRuntimeClass("unconstrained"),
+			node: api.Node{},
+			fits: true,
+			test: "runtime class without scheduling constraints fits any node",
+		},
+		{
+			pod:     podWithRuntimeClass("missing"),
+			node:    api.Node{},
+			fits:    false,
+			wantErr: true,
+			test:    "pod referencing a missing runtime class fails with an error",
+		},
+		{
+			pod: podWithRuntimeClass("sandboxed"),
+			node: api.Node{
+				ObjectMeta: api.ObjectMeta{
+					Labels:      map[string]string{"runtime": "kata"},
+					Annotations: taintedNodeAnnotations,
+				},
+			},
+			fits: true,
+			test: "node matching the runtime class selector and tolerated taints fits",
+		},
+		{
+			pod: podWithRuntimeClass("sandboxed"),
+			node: api.Node{
+				ObjectMeta: api.ObjectMeta{
+					Labels: map[string]string{"runtime": "runc"},
+				},
+			},
+			fits: false,
+			test: "node not matching the runtime class selector does not fit",
+		},
+		{
+			pod: podWithRuntimeClass("sandboxed"),
+			node: api.Node{
+				ObjectMeta: api.ObjectMeta{
+					Labels: map[string]string{"runtime": "kata"},
+					Annotations: map[string]string{
+						api.TaintsAnnotationKey: `[{"key": "dedicated", "value": "user1", "effect": "NoSchedule"}]`,
+					},
+				},
+			},
+			fits: false,
+			test: "taints not tolerated by the pod or its runtime class do not fit",
+		},
+	}
+
+	for _, test := range tests {
+		nodeInfo := schedulercache.NewNodeInfo()
+		nodeInfo.SetNode(&test.node)
+
+		fits, err := NewRuntimeClassPredicate(classes)(test.pod, nil, nodeInfo)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected error, got none", test.test)
+			}
+			continue
+		}
+		if err != nil && err != ErrRuntimeClassNotMatch {
+			t.Errorf("%s: unexpected error: %v", test.test, err)
+		}
+		if fits != test.fits {
+			t.Errorf("%s: expected fits=%v, got %v", test.test, test.fits, fits)
+		}
+	}
+}
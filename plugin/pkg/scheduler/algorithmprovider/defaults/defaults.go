@@ -149,6 +149,14 @@ func defaultPredicates() sets.String {
 		// Fit is determined based on whether a pod can tolerate all of the node's taints
 		factory.RegisterFitPredicate("PodToleratesNodeTaints", predicates.PodToleratesNodeTaints),
 
+		// Fit is determined by the scheduling constraints declared on the pod's RuntimeClass, if any.
+		factory.RegisterFitPredicateFactory(
+			"PodMatchesRuntimeClass",
+			func(args factory.PluginFactoryArgs) algorithm.FitPredicate {
+				return predicates.NewRuntimeClassPredicate(args.RuntimeClassInfo)
+			},
+		),
+
 		// Fit is determined by node memory pressure condition.
 		factory.RegisterFitPredicate("CheckNodeMemoryPressure", predicates.CheckNodeMemoryPressurePredicate),
 	)
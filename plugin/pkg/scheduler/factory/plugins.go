@@ -41,6 +41,7 @@ type PluginFactoryArgs struct {
 	NodeInfo                       predicates.NodeInfo
 	PVInfo                         predicates.PersistentVolumeInfo
 	PVCInfo                        predicates.PersistentVolumeClaimInfo
+	RuntimeClassInfo               predicates.RuntimeClassInfo
 	HardPodAffinitySymmetricWeight int
 	FailureDomains                 []string
 }